@@ -17,6 +17,10 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "silence" {
+		RunSilence(os.Args[2:])
+		return
+	}
 	RunCLI()
 }
 
@@ -26,6 +30,7 @@ func RunCLI() {
 	flag.DurationVar(&config.Interval, "interval", 5*time.Second, "Update interval")
 	flag.BoolVar(&config.OutputJSON, "json", false, "Output in JSON format")
 	flag.BoolVar(&config.ShowProcesses, "processes", false, "Show top processes")
+	flag.BoolVar(&config.ProcessTree, "tree", false, "Show processes as a tree (with -processes)")
 	flag.BoolVar(&config.Continuous, "continuous", false, "Continuous monitoring mode")
 	flag.IntVar(&config.Count, "count", 0, "Number of updates (0 for infinite)")
 	flag.BoolVar(&config.NoColor, "no-color", false, "Disable colored output")
@@ -98,7 +103,11 @@ func RunCLI() {
 			printMetrics(&snapshot.SystemMetrics, config.NoColor)
 
 			if config.ShowProcesses {
-				printTopProcesses(snapshot.TopProcesses, config.NoColor)
+				if config.ProcessTree {
+					printProcessTreeView(systemCollector, config.NoColor)
+				} else {
+					printTopProcesses(snapshot.TopProcesses, config.NoColor)
+				}
 			}
 		}
 		return
@@ -135,9 +144,13 @@ func RunCLI() {
 				printMetrics(metrics, config.NoColor)
 
 				if config.ShowProcesses {
-					topProcesses, err := systemCollector.GetTopProcesses(10, "cpu")
-					if err == nil {
-						printTopProcesses(topProcesses, config.NoColor)
+					if config.ProcessTree {
+						printProcessTreeView(systemCollector, config.NoColor)
+					} else {
+						topProcesses, err := systemCollector.GetTopProcesses(10, "cpu")
+						if err == nil {
+							printTopProcesses(topProcesses, config.NoColor)
+						}
 					}
 				}
 			}
@@ -158,6 +171,7 @@ type CLIConfig struct {
 	Interval      time.Duration
 	OutputJSON    bool
 	ShowProcesses bool
+	ProcessTree   bool
 	Continuous    bool
 	Count         int
 	NoColor       bool
@@ -423,6 +437,65 @@ func printTopProcesses(processes []models.ProcessInfo, noColor bool) {
 	fmt.Println()
 }
 
+// printProcessTreeView collects and prints the process tree
+func printProcessTreeView(systemCollector *collector.SystemCollector, noColor bool) {
+	tree, err := systemCollector.GetProcessTree()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting process tree: %v\n", err)
+		return
+	}
+	printProcessTree(tree, noColor)
+}
+
+// printProcessTree prints the process hierarchy with aggregated subtree usage
+func printProcessTree(roots []*models.ProcessTreeNode, noColor bool) {
+	if len(roots) == 0 {
+		return
+	}
+
+	var header func(string, ...interface{}) string
+	if noColor {
+		header = fmt.Sprintf
+	} else {
+		header = color.New(color.FgRed, color.Bold).SprintfFunc()
+	}
+
+	fmt.Println(header("Process Tree:"))
+	fmt.Printf("%-8s %-40s %-10s %-10s %-6s\n", "PID", "Name", "Tree CPU%", "Tree Mem", "Procs")
+	fmt.Println(strings.Repeat("-", 78))
+
+	var printNode func(node *models.ProcessTreeNode, prefix string, last bool, root bool)
+	printNode = func(node *models.ProcessTreeNode, prefix string, last bool, root bool) {
+		branch := ""
+		childPrefix := prefix
+		if !root {
+			if last {
+				branch = "`- "
+				childPrefix = prefix + "   "
+			} else {
+				branch = "|- "
+				childPrefix = prefix + "|  "
+			}
+		}
+
+		fmt.Printf("%-8d %-40s %8.1f%% %10s %6d\n",
+			node.PID,
+			truncateString(prefix+branch+node.Name, 40),
+			node.TotalCPUPercent,
+			formatBytes(node.TotalMemoryBytes),
+			node.ProcessCount)
+
+		for i, child := range node.Children {
+			printNode(child, childPrefix, i == len(node.Children)-1, false)
+		}
+	}
+
+	for _, root := range roots {
+		printNode(root, "", true, true)
+	}
+	fmt.Println()
+}
+
 // truncateString truncates a string to the specified length
 func truncateString(s string, length int) string {
 	if len(s) <= length {
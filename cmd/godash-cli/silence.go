@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// matcherFlags collects repeated -match flags
+type matcherFlags []string
+
+func (m *matcherFlags) String() string {
+	return strings.Join(*m, ", ")
+}
+
+func (m *matcherFlags) Set(value string) error {
+	*m = append(*m, value)
+	return nil
+}
+
+// silenceMatcher mirrors models.AlertMatcher in the API request
+type silenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"is_regex"`
+}
+
+// RunSilence creates a silence on a GoDash server, e.g.
+//
+//	godash-cli silence -match alertname="High CPU" -match hostname=~"web-.*" -duration 2h
+func RunSilence(args []string) {
+	fs := flag.NewFlagSet("silence", flag.ExitOnError)
+	var matches matcherFlags
+	fs.Var(&matches, "match", "Matcher as name=value or name=~regex (repeatable)")
+	server := fs.String("server", "http://localhost:8080", "GoDash server URL")
+	duration := fs.Duration("duration", 2*time.Hour, "How long the silence lasts")
+	comment := fs.String("comment", "", "Why notifications are silenced")
+	creator := fs.String("creator", defaultCreator(), "Who created the silence")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godash-cli silence -match name=value [-match ...] [options]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Matcher names: alertname, severity, hostname or any alert label")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if len(matches) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	matchers := make([]silenceMatcher, 0, len(matches))
+	for _, match := range matches {
+		matcher, err := parseMatcher(match)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	now := time.Now()
+	body, _ := json.Marshal(map[string]interface{}{
+		"matchers":   matchers,
+		"starts_at":  now,
+		"ends_at":    now.Add(*duration),
+		"created_by": *creator,
+		"comment":    *comment,
+	})
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(strings.TrimRight(*server, "/")+"/api/v1/silences", "application/json", bytes.NewReader(body))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating silence: %v\n", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+		Data    struct {
+			ID     uint      `json:"id"`
+			EndsAt time.Time `json:"ends_at"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading response (HTTP %d): %v\n", resp.StatusCode, err)
+		os.Exit(1)
+	}
+	if !result.Success {
+		fmt.Fprintf(os.Stderr, "Error creating silence: %s: %s\n", result.Error, result.Message)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Silence %d created, active until %s\n", result.Data.ID, result.Data.EndsAt.Local().Format("2006-01-02 15:04:05"))
+}
+
+// parseMatcher parses name=value or name=~regex
+func parseMatcher(match string) (silenceMatcher, error) {
+	index := strings.Index(match, "=")
+	if index <= 0 {
+		return silenceMatcher{}, fmt.Errorf("invalid matcher %q, expected name=value or name=~regex", match)
+	}
+
+	matcher := silenceMatcher{Name: strings.TrimSpace(match[:index]), Value: match[index+1:]}
+	if strings.HasPrefix(matcher.Value, "~") {
+		matcher.Value = matcher.Value[1:]
+		matcher.IsRegex = true
+	}
+	return matcher, nil
+}
+
+// defaultCreator returns the current user name from the environment
+func defaultCreator() string {
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return os.Getenv("USERNAME")
+}
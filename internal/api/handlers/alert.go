@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -16,20 +18,29 @@ import (
 // AlertHandler handles HTTP requests for alerts
 type AlertHandler struct {
 	alertRepo     repository.AlertRepository
+	probeRepo     repository.ProbeRepository
+	policyRepo    repository.EscalationPolicyRepository
 	alertService  *services.AlertService
 	emailSender   services.EmailSender
 	webhookSender services.WebhookSender
+
+	ruleFilesMutex sync.RWMutex
+	ruleFiles      map[string]string // Rule file of each alert synced from the rules directory, by name
 }
 
 // NewAlertHandler creates a new alert handler
 func NewAlertHandler(
 	alertRepo repository.AlertRepository,
+	probeRepo repository.ProbeRepository,
+	policyRepo repository.EscalationPolicyRepository,
 	alertService *services.AlertService,
 	emailSender services.EmailSender,
 	webhookSender services.WebhookSender,
 ) *AlertHandler {
 	return &AlertHandler{
 		alertRepo:     alertRepo,
+		probeRepo:     probeRepo,
+		policyRepo:    policyRepo,
 		alertService:  alertService,
 		emailSender:   emailSender,
 		webhookSender: webhookSender,
@@ -38,33 +49,181 @@ func NewAlertHandler(
 
 // CreateAlertRequest represents the request body for creating alerts
 type CreateAlertRequest struct {
-	Name            string  `json:"name" binding:"required"`
-	MetricType      string  `json:"metric_type" binding:"required"`
-	Condition       string  `json:"condition" binding:"required"`
-	Threshold       float64 `json:"threshold" binding:"required"`
-	Duration        int     `json:"duration"`
-	Severity        string  `json:"severity" binding:"required"`
-	Description     string  `json:"description"`
-	EmailEnabled    bool    `json:"email_enabled"`
-	EmailRecipients string  `json:"email_recipients"`
-	WebhookEnabled  bool    `json:"webhook_enabled"`
-	WebhookURL      string  `json:"webhook_url"`
+	Name               string                             `json:"name" binding:"required"`
+	MetricType         string                             `json:"metric_type" binding:"required"`
+	Condition          string                             `json:"condition"`
+	Threshold          float64                            `json:"threshold"`
+	ClearThreshold     *float64                           `json:"clear_threshold"`
+	Duration           int                                `json:"duration"`
+	Severity           string                             `json:"severity"` // Required unless levels are given
+	Levels             []models.SeverityLevel             `json:"levels"`
+	HostSelector       models.HostSelector                `json:"host_selector"`
+	HostOverrides      []models.HostOverride              `json:"host_overrides"`
+	Description        string                             `json:"description"`
+	Labels             map[string]string                  `json:"labels"`
+	Expression         string                             `json:"expression"`
+	Mountpoint         string                             `json:"mountpoint"`
+	AbsentMetric       string                             `json:"absent_metric"`
+	AnomalyField       string                             `json:"anomaly_field"`
+	AnomalyMethod      string                             `json:"anomaly_method"`
+	Seasonality        string                             `json:"seasonality"`
+	AnomalyDirection   string                             `json:"anomaly_direction"`
+	Aggregation        string                             `json:"aggregation"`
+	EmailEnabled       bool                               `json:"email_enabled"`
+	EmailRecipients    string                             `json:"email_recipients"`
+	WebhookEnabled     bool                               `json:"webhook_enabled"`
+	WebhookURL         string                             `json:"webhook_url"`
+	SkipResolved       bool                               `json:"skip_resolved"`
+	Templates          map[string]models.MessageTemplates `json:"templates"`
+	ProbeID            uint                               `json:"probe_id"`
+	EscalationPolicyID uint                               `json:"escalation_policy_id"`
+	CertificateFilter  string                             `json:"certificate_filter"`
+	ProcessPattern     string                             `json:"process_pattern"`
+	ProcessRegex       bool                               `json:"process_regex"`
+	Window             int                                `json:"window"`
 }
 
 // UpdateAlertRequest represents the request body for updating alerts
 type UpdateAlertRequest struct {
-	Name            string  `json:"name"`
-	MetricType      string  `json:"metric_type"`
-	Condition       string  `json:"condition"`
-	Threshold       float64 `json:"threshold"`
-	Duration        int     `json:"duration"`
-	Severity        string  `json:"severity"`
-	IsActive        *bool   `json:"is_active"`
-	Description     string  `json:"description"`
-	EmailEnabled    bool    `json:"email_enabled"`
-	EmailRecipients string  `json:"email_recipients"`
-	WebhookEnabled  bool    `json:"webhook_enabled"`
-	WebhookURL      string  `json:"webhook_url"`
+	Name               string                             `json:"name"`
+	MetricType         string                             `json:"metric_type"`
+	Condition          string                             `json:"condition"`
+	Threshold          float64                            `json:"threshold"`
+	ClearThreshold     *float64                           `json:"clear_threshold"` // Equal to the threshold removes hysteresis
+	Duration           int                                `json:"duration"`
+	Severity           string                             `json:"severity"`
+	Levels             []models.SeverityLevel             `json:"levels"` // Replaces all levels when present, [] removes them
+	HostSelector       *models.HostSelector               `json:"host_selector"`
+	HostOverrides      []models.HostOverride              `json:"host_overrides"` // Replaces all overrides when present, [] removes them
+	IsActive           *bool                              `json:"is_active"`
+	Description        string                             `json:"description"`
+	Labels             map[string]string                  `json:"labels"` // Replaces all labels when present
+	Expression         *string                            `json:"expression"`
+	Mountpoint         *string                            `json:"mountpoint"`
+	AbsentMetric       *string                            `json:"absent_metric"`
+	AnomalyField       *string                            `json:"anomaly_field"`
+	AnomalyMethod      *string                            `json:"anomaly_method"`
+	Seasonality        *string                            `json:"seasonality"`
+	AnomalyDirection   *string                            `json:"anomaly_direction"`
+	Aggregation        *string                            `json:"aggregation"`
+	EmailEnabled       bool                               `json:"email_enabled"`
+	EmailRecipients    string                             `json:"email_recipients"`
+	WebhookEnabled     bool                               `json:"webhook_enabled"`
+	WebhookURL         string                             `json:"webhook_url"`
+	SkipResolved       *bool                              `json:"skip_resolved"`
+	Templates          map[string]models.MessageTemplates `json:"templates"` // Replaces all templates when present, {} removes them
+	ProbeID            *uint                              `json:"probe_id"`
+	EscalationPolicyID *uint                              `json:"escalation_policy_id"` // 0 detaches the policy
+	CertificateFilter  *string                            `json:"certificate_filter"`
+	ProcessPattern     string                             `json:"process_pattern"`
+	ProcessRegex       *bool                              `json:"process_regex"`
+	Window             int                                `json:"window"`
+}
+
+// BacktestAlertRequest represents the request body for backtesting an alert rule against
+// stored metrics. Either AlertID or Rule selects the rule.
+type BacktestAlertRequest struct {
+	AlertID  uint                `json:"alert_id"` // Existing alert to replay
+	Rule     *CreateAlertRequest `json:"rule"`     // Unsaved alert definition to replay
+	From     time.Time           `json:"from" binding:"required"`
+	To       time.Time           `json:"to"`       // Defaults to now
+	Hostname string              `json:"hostname"` // Only replay this host
+	Cooldown int                 `json:"cooldown"` // Seconds between repeat notifications, 0 = ALERTS_COOLDOWN
+}
+
+// apply sets the fields present in an update on the definition of an alert. Empty strings and
+// zero numbers of non-pointer fields keep the current value, except for notification settings.
+func (req *UpdateAlertRequest) apply(rule *CreateAlertRequest) {
+	if req.Name != "" {
+		rule.Name = req.Name
+	}
+	if req.MetricType != "" {
+		rule.MetricType = req.MetricType
+	}
+	if req.Condition != "" {
+		rule.Condition = req.Condition
+	}
+	if req.Threshold != 0 {
+		rule.Threshold = req.Threshold
+	}
+	if req.ClearThreshold != nil {
+		rule.ClearThreshold = req.ClearThreshold
+	}
+	if req.Duration != 0 {
+		rule.Duration = req.Duration
+	}
+	if req.Severity != "" {
+		rule.Severity = req.Severity
+	}
+	if req.Levels != nil {
+		rule.Levels = req.Levels
+	}
+	if req.HostSelector != nil {
+		rule.HostSelector = *req.HostSelector
+	}
+	if req.HostOverrides != nil {
+		rule.HostOverrides = req.HostOverrides
+	}
+	if req.Description != "" {
+		rule.Description = req.Description
+	}
+	if req.Labels != nil {
+		rule.Labels = req.Labels
+	}
+
+	rule.EmailEnabled = req.EmailEnabled
+	rule.EmailRecipients = req.EmailRecipients
+	rule.WebhookEnabled = req.WebhookEnabled
+	rule.WebhookURL = req.WebhookURL
+	if req.SkipResolved != nil {
+		rule.SkipResolved = *req.SkipResolved
+	}
+	if req.Templates != nil {
+		rule.Templates = req.Templates
+	}
+
+	if req.Expression != nil {
+		rule.Expression = strings.TrimSpace(*req.Expression)
+	}
+	if req.Aggregation != nil {
+		rule.Aggregation = strings.ToLower(strings.TrimSpace(*req.Aggregation))
+	}
+	if req.Mountpoint != nil {
+		rule.Mountpoint = strings.TrimSpace(*req.Mountpoint)
+	}
+	if req.AbsentMetric != nil {
+		rule.AbsentMetric = strings.ToLower(strings.TrimSpace(*req.AbsentMetric))
+	}
+	if req.AnomalyField != nil {
+		rule.AnomalyField = strings.ToLower(strings.TrimSpace(*req.AnomalyField))
+	}
+	if req.AnomalyMethod != nil {
+		rule.AnomalyMethod = strings.ToLower(strings.TrimSpace(*req.AnomalyMethod))
+	}
+	if req.Seasonality != nil {
+		rule.Seasonality = strings.ToLower(strings.TrimSpace(*req.Seasonality))
+	}
+	if req.AnomalyDirection != nil {
+		rule.AnomalyDirection = strings.ToLower(strings.TrimSpace(*req.AnomalyDirection))
+	}
+	if req.ProbeID != nil {
+		rule.ProbeID = *req.ProbeID
+	}
+	if req.EscalationPolicyID != nil {
+		rule.EscalationPolicyID = *req.EscalationPolicyID
+	}
+	if req.CertificateFilter != nil {
+		rule.CertificateFilter = *req.CertificateFilter
+	}
+	if req.ProcessPattern != "" {
+		rule.ProcessPattern = req.ProcessPattern
+	}
+	if req.ProcessRegex != nil {
+		rule.ProcessRegex = *req.ProcessRegex
+	}
+	if req.Window != 0 {
+		rule.Window = req.Window
+	}
 }
 
 // CreateAlert creates a new alert configuration
@@ -90,8 +249,8 @@ func (h *AlertHandler) CreateAlert(c *gin.Context) {
 		return
 	}
 
-	// Validate request
-	if err := h.validateAlertRequest(req.MetricType, req.Condition, req.Severity); err != nil {
+	alert, err := h.alertFromRequest(&req)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Validation failed",
@@ -100,22 +259,6 @@ func (h *AlertHandler) CreateAlert(c *gin.Context) {
 		return
 	}
 
-	// Create alert model
-	alert := &models.Alert{
-		Name:            req.Name,
-		MetricType:      req.MetricType,
-		Condition:       req.Condition,
-		Threshold:       req.Threshold,
-		Duration:        req.Duration,
-		Severity:        req.Severity,
-		IsActive:        true,
-		Description:     req.Description,
-		EmailEnabled:    req.EmailEnabled,
-		EmailRecipients: req.EmailRecipients,
-		WebhookEnabled:  req.WebhookEnabled,
-		WebhookURL:      req.WebhookURL,
-	}
-
 	if err := h.alertRepo.CreateAlert(alert); err != nil {
 		c.JSON(http.StatusInternalServerError, APIResponse{
 			Success: false,
@@ -212,6 +355,7 @@ func (h *AlertHandler) GetAlert(c *gin.Context) {
 // @Success 200 {object} APIResponse{data=models.Alert}
 // @Failure 400 {object} APIResponse
 // @Failure 404 {object} APIResponse
+// @Failure 409 {object} APIResponse
 // @Failure 500 {object} APIResponse
 // @Router /api/v1/alerts/{id} [put]
 func (h *AlertHandler) UpdateAlert(c *gin.Context) {
@@ -226,7 +370,7 @@ func (h *AlertHandler) UpdateAlert(c *gin.Context) {
 	}
 
 	// Get existing alert
-	alert, err := h.alertRepo.GetAlertByID(uint(id))
+	current, err := h.alertRepo.GetAlertByID(uint(id))
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "alert not found" {
@@ -240,6 +384,9 @@ func (h *AlertHandler) UpdateAlert(c *gin.Context) {
 		})
 		return
 	}
+	if h.rejectRulesDirAlert(c, current) {
+		return
+	}
 
 	var req UpdateAlertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -251,39 +398,11 @@ func (h *AlertHandler) UpdateAlert(c *gin.Context) {
 		return
 	}
 
-	// Update fields
-	if req.Name != "" {
-		alert.Name = req.Name
-	}
-	if req.MetricType != "" {
-		alert.MetricType = req.MetricType
-	}
-	if req.Condition != "" {
-		alert.Condition = req.Condition
-	}
-	if req.Threshold != 0 {
-		alert.Threshold = req.Threshold
-	}
-	if req.Duration != 0 {
-		alert.Duration = req.Duration
-	}
-	if req.Severity != "" {
-		alert.Severity = req.Severity
-	}
-	if req.IsActive != nil {
-		alert.IsActive = *req.IsActive
-	}
-	if req.Description != "" {
-		alert.Description = req.Description
-	}
-
-	alert.EmailEnabled = req.EmailEnabled
-	alert.EmailRecipients = req.EmailRecipients
-	alert.WebhookEnabled = req.WebhookEnabled
-	alert.WebhookURL = req.WebhookURL
-
-	// Validate updated alert
-	if err := h.validateAlertRequest(alert.MetricType, alert.Condition, alert.Severity); err != nil {
+	// Apply the updates to the current definition and validate it like a new alert
+	rule := ruleFromAlert(current)
+	req.apply(&rule.CreateAlertRequest)
+	alert, err := h.alertFromRequest(&rule.CreateAlertRequest)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Validation failed",
@@ -292,6 +411,16 @@ func (h *AlertHandler) UpdateAlert(c *gin.Context) {
 		return
 	}
 
+	// Keep the identity, owner and statistics of the alert being updated
+	alert.BaseModel = current.BaseModel
+	alert.ManagedBy = current.ManagedBy
+	alert.TriggeredCount = current.TriggeredCount
+	alert.LastTriggered = current.LastTriggered
+	alert.IsActive = current.IsActive
+	if req.IsActive != nil {
+		alert.IsActive = *req.IsActive
+	}
+
 	if err := h.alertRepo.UpdateAlert(alert); err != nil {
 		c.JSON(http.StatusInternalServerError, APIResponse{
 			Success: false,
@@ -317,6 +446,7 @@ func (h *AlertHandler) UpdateAlert(c *gin.Context) {
 // @Param id path int true "Alert ID"
 // @Success 200 {object} APIResponse
 // @Failure 404 {object} APIResponse
+// @Failure 409 {object} APIResponse
 // @Failure 500 {object} APIResponse
 // @Router /api/v1/alerts/{id} [delete]
 func (h *AlertHandler) DeleteAlert(c *gin.Context) {
@@ -330,6 +460,24 @@ func (h *AlertHandler) DeleteAlert(c *gin.Context) {
 		return
 	}
 
+	alert, err := h.alertRepo.GetAlertByID(uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "alert not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve alert",
+			Message: err.Error(),
+		})
+		return
+	}
+	if h.rejectRulesDirAlert(c, alert) {
+		return
+	}
+
 	if err := h.alertRepo.DeleteAlert(uint(id)); err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "alert not found" {
@@ -454,7 +602,7 @@ func (h *AlertHandler) TestAlert(c *gin.Context) {
 
 	// Test email notification
 	if alert.EmailEnabled && h.emailSender != nil {
-		if err := h.emailSender.SendAlert(alert, testHistory); err != nil {
+		if err := h.emailSender.SendAlert(alert, testHistory, h.testMessage(services.ChannelTypeEmail, alert, testHistory)); err != nil {
 			results = append(results, fmt.Sprintf("Email test failed: %v", err))
 		} else {
 			results = append(results, "Email test successful")
@@ -463,7 +611,8 @@ func (h *AlertHandler) TestAlert(c *gin.Context) {
 
 	// Test webhook notification
 	if alert.WebhookEnabled && h.webhookSender != nil {
-		if err := h.webhookSender.SendAlert(alert, testHistory); err != nil {
+		message := h.testMessage(services.DetectWebhookFormat(alert.WebhookURL), alert, testHistory)
+		if err := h.webhookSender.SendAlert(alert, testHistory, message); err != nil {
 			results = append(results, fmt.Sprintf("Webhook test failed: %v", err))
 		} else {
 			results = append(results, "Webhook test successful")
@@ -484,6 +633,234 @@ func (h *AlertHandler) TestAlert(c *gin.Context) {
 	})
 }
 
+// testMessage renders the message templates of a channel type for a test notification; nil
+// sends the built-in layout
+func (h *AlertHandler) testMessage(channelType string, alert *models.Alert, history *models.AlertHistory) *services.NotificationMessage {
+	if h.alertService == nil {
+		return nil
+	}
+	return h.alertService.RenderNotification(channelType, alert, history)
+}
+
+// BacktestAlert replays stored metrics through an alert rule
+// @Summary Backtest alert
+// @Description Replay stored metrics over a time range through an existing or unsaved alert rule and return the incidents it would have opened. No notifications are sent.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param request body BacktestAlertRequest true "Rule and time range"
+// @Success 200 {object} APIResponse{data=services.BacktestResult}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/backtest [post]
+func (h *AlertHandler) BacktestAlert(c *gin.Context) {
+	var req BacktestAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if err := h.validateBacktest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var alert *models.Alert
+	var err error
+	if req.Rule != nil {
+		if alert, err = h.alertFromRequest(req.Rule); err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   "Validation failed",
+				Message: err.Error(),
+			})
+			return
+		}
+	} else {
+		if alert, err = h.alertRepo.GetAlertByID(req.AlertID); err != nil {
+			statusCode := http.StatusInternalServerError
+			if err.Error() == "alert not found" {
+				statusCode = http.StatusNotFound
+			}
+
+			c.JSON(statusCode, APIResponse{
+				Success: false,
+				Error:   "Failed to retrieve alert",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	if !services.CanBacktest(alert.MetricType) {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: fmt.Sprintf("backtesting is not supported for %s alerts", alert.MetricType),
+		})
+		return
+	}
+
+	if h.alertService == nil {
+		c.JSON(http.StatusServiceUnavailable, APIResponse{
+			Success: false,
+			Error:   "Alert service unavailable",
+			Message: "Alert service is not initialized",
+		})
+		return
+	}
+
+	result, err := h.alertService.Backtest(alert, services.BacktestOptions{
+		From:     req.From,
+		To:       req.To,
+		Hostname: strings.TrimSpace(req.Hostname),
+		Cooldown: time.Duration(req.Cooldown) * time.Second,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to backtest alert",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: fmt.Sprintf("Alert would have opened %d incidents", len(result.Incidents)),
+	})
+}
+
+// GetAlertBaseline returns the learned baselines of an anomaly alert
+// @Summary Get anomaly baseline
+// @Description Get the expected value and band per seasonal bucket for an anomaly alert
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param id path int true "Alert ID"
+// @Param hostname query string false "Only return the baseline for this host"
+// @Success 200 {object} APIResponse{data=[]services.AnomalyBand}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/{id}/baseline [get]
+func (h *AlertHandler) GetAlertBaseline(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid alert ID",
+			Message: "Alert ID must be a valid number",
+		})
+		return
+	}
+
+	alert, err := h.alertRepo.GetAlertByID(uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "alert not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve alert",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if h.alertService == nil {
+		c.JSON(http.StatusServiceUnavailable, APIResponse{
+			Success: false,
+			Error:   "Alert service unavailable",
+			Message: "Alert service is not initialized",
+		})
+		return
+	}
+
+	bands, err := h.alertService.GetAnomalyBands(alert, c.Query("hostname"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Failed to get baseline",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    bands,
+	})
+}
+
+// GetAlertInstances lists the current state of alerts per host
+// @Summary Get alert instances
+// @Description List alert instances (pending, firing or resolved) with their state timestamps
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param alert_id query int false "Only instances of this alert"
+// @Param state query string false "Only instances in this state (pending, firing, resolved)"
+// @Success 200 {object} APIResponse{data=[]models.AlertInstance}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/instances [get]
+func (h *AlertHandler) GetAlertInstances(c *gin.Context) {
+	var alertID uint64
+	if value := c.Query("alert_id"); value != "" {
+		var err error
+		alertID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   "Invalid alert ID",
+				Message: "Alert ID must be a valid number",
+			})
+			return
+		}
+	}
+
+	state := strings.ToLower(c.Query("state"))
+	if state != "" && !services.IsAlertState(state) {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid state",
+			Message: fmt.Sprintf("State must be one of: %s", strings.Join(services.AlertStates(), ", ")),
+		})
+		return
+	}
+
+	instances, err := h.alertRepo.GetAlertInstances(uint(alertID), state)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve alert instances",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    instances,
+	})
+}
+
 // GetAlertStats returns alert statistics
 // @Summary Get alert statistics
 // @Description Get alert system statistics
@@ -520,37 +897,25 @@ func (h *AlertHandler) GetAlertStats(c *gin.Context) {
 
 // ResolveAlert manually resolves an alert
 // @Summary Resolve alert
-// @Description Manually resolve an alert history entry
+// @Description Manually resolve an alert history entry, recording who resolved it on the incident timeline
 // @Tags alerts
 // @Accept json
 // @Produce json
 // @Param id path int true "Alert History ID"
+// @Param request body IncidentActionRequest false "User and comment"
 // @Success 200 {object} APIResponse
 // @Failure 404 {object} APIResponse
+// @Failure 409 {object} APIResponse
 // @Failure 500 {object} APIResponse
 // @Router /api/v1/alerts/history/{id}/resolve [post]
 func (h *AlertHandler) ResolveAlert(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
-			Success: false,
-			Error:   "Invalid alert history ID",
-			Message: "Alert history ID must be a valid number",
-		})
+	id, req, ok := h.bindIncidentAction(c)
+	if !ok {
 		return
 	}
 
-	if err := h.alertRepo.ResolveAlert(uint(id)); err != nil {
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "alert history not found" {
-			statusCode = http.StatusNotFound
-		}
-
-		c.JSON(statusCode, APIResponse{
-			Success: false,
-			Error:   "Failed to resolve alert",
-			Message: err.Error(),
-		})
+	if _, err := h.alertService.ResolveIncidentManually(id, req.User, req.Comment); err != nil {
+		respondIncidentError(c, "Failed to resolve alert", err)
 		return
 	}
 
@@ -560,17 +925,129 @@ func (h *AlertHandler) ResolveAlert(c *gin.Context) {
 	})
 }
 
+// alertFromRequest validates a create request and builds the alert it describes
+func (h *AlertHandler) alertFromRequest(req *CreateAlertRequest) (*models.Alert, error) {
+	// The evaluator matches metric types exactly, so store them in canonical form
+	req.MetricType = strings.ToLower(strings.TrimSpace(req.MetricType))
+
+	// Multi-level alerts take their threshold and severity from the first level
+	if err := services.ValidateSeverityLevels(req.Condition, req.Levels); err != nil {
+		return nil, err
+	}
+	if len(req.Levels) > 0 {
+		req.Threshold = req.Levels[0].Threshold
+		req.Severity = req.Levels[0].Severity
+	}
+
+	// Validate request
+	if err := h.validateAlertRequest(req.MetricType, req.Condition, req.Severity); err != nil {
+		return nil, err
+	}
+	if err := h.validateProcessWatch(req.MetricType, req.ProcessPattern, req.ProcessRegex, req.Window); err != nil {
+		return nil, err
+	}
+	if err := h.validateExpression(req.MetricType, req.Expression); err != nil {
+		return nil, err
+	}
+	if err := h.validateAggregation(req.MetricType, req.Aggregation, req.Window); err != nil {
+		return nil, err
+	}
+	if err := h.validateClearThreshold(req.Condition, req.Threshold, req.ClearThreshold); err != nil {
+		return nil, err
+	}
+	if err := h.validateForecast(req.MetricType, req.Condition, req.Threshold, req.Window); err != nil {
+		return nil, err
+	}
+	if err := h.validateNoData(req.MetricType, req.Condition, req.Threshold, req.AbsentMetric); err != nil {
+		return nil, err
+	}
+	if err := h.validateAnomaly(req.MetricType, req.Condition, req.Threshold, req.AnomalyField, req.AnomalyMethod, req.Seasonality, req.AnomalyDirection); err != nil {
+		return nil, err
+	}
+	if err := h.validateProbe(req.ProbeID); err != nil {
+		return nil, err
+	}
+	if err := h.validateEscalationPolicy(req.EscalationPolicyID); err != nil {
+		return nil, err
+	}
+
+	alert := &models.Alert{
+		Name:               req.Name,
+		MetricType:         req.MetricType,
+		Condition:          req.Condition,
+		Threshold:          req.Threshold,
+		ClearThreshold:     clearThreshold(req.Threshold, req.ClearThreshold),
+		Duration:           req.Duration,
+		Severity:           req.Severity,
+		Levels:             req.Levels,
+		HostSelector:       req.HostSelector,
+		HostOverrides:      req.HostOverrides,
+		IsActive:           true,
+		Description:        req.Description,
+		Labels:             req.Labels,
+		Expression:         strings.TrimSpace(req.Expression),
+		Aggregation:        strings.ToLower(strings.TrimSpace(req.Aggregation)),
+		Mountpoint:         strings.TrimSpace(req.Mountpoint),
+		AbsentMetric:       strings.ToLower(strings.TrimSpace(req.AbsentMetric)),
+		AnomalyField:       strings.ToLower(strings.TrimSpace(req.AnomalyField)),
+		AnomalyMethod:      strings.ToLower(strings.TrimSpace(req.AnomalyMethod)),
+		Seasonality:        strings.ToLower(strings.TrimSpace(req.Seasonality)),
+		AnomalyDirection:   strings.ToLower(strings.TrimSpace(req.AnomalyDirection)),
+		EmailEnabled:       req.EmailEnabled,
+		EmailRecipients:    req.EmailRecipients,
+		WebhookEnabled:     req.WebhookEnabled,
+		WebhookURL:         req.WebhookURL,
+		SkipResolved:       req.SkipResolved,
+		Templates:          req.Templates,
+		ProbeID:            req.ProbeID,
+		EscalationPolicyID: req.EscalationPolicyID,
+		CertificateFilter:  req.CertificateFilter,
+		ProcessPattern:     req.ProcessPattern,
+		ProcessRegex:       req.ProcessRegex,
+		Window:             req.Window,
+	}
+
+	if err := services.ValidateHostScope(alert); err != nil {
+		return nil, err
+	}
+	if err := services.ValidateAlertTemplates(alert.Templates); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// validateBacktest validates the rule selection and time range of a backtest request
+func (h *AlertHandler) validateBacktest(req *BacktestAlertRequest) error {
+	if (req.Rule == nil) == (req.AlertID == 0) {
+		return fmt.Errorf("exactly one of alert_id or rule is required")
+	}
+	if !req.To.After(req.From) {
+		return fmt.Errorf("to must be after from")
+	}
+	if req.Cooldown < 0 {
+		return fmt.Errorf("cooldown must not be negative")
+	}
+	return nil
+}
+
 // validateAlertRequest validates alert request parameters
 func (h *AlertHandler) validateAlertRequest(metricType, condition, severity string) error {
 	// Validate metric type
-	validMetricTypes := []string{"cpu", "memory", "disk", "load_avg_1", "load_avg_5", "load_avg_15"}
+	validMetricTypes := []string{
+		"cpu", "memory", "disk", "load_avg_1", "load_avg_5", "load_avg_15",
+		"file_handles", "pid_usage", "threads", "conntrack", "process_fds",
+	}
+	validMetricTypes = append(validMetricTypes, services.ProcessMetricTypes()...)
+	validMetricTypes = append(validMetricTypes, services.ProbeMetricTypes()...)
+	validMetricTypes = append(validMetricTypes, services.MetricCertExpiryDays, services.MetricDiskForecast, services.MetricAnomaly, services.MetricNoData, services.MetricExpression)
 	if !contains(validMetricTypes, strings.ToLower(metricType)) {
 		return fmt.Errorf("invalid metric type: %s. Valid types: %s", metricType, strings.Join(validMetricTypes, ", "))
 	}
 
-	// Validate condition
+	// Validate condition (optional for expressions, which fire when true)
 	validConditions := []string{">", ">=", "<", "<=", "=", "==", "!=", "gt", "gte", "lt", "lte", "eq", "ne"}
-	if !contains(validConditions, strings.ToLower(condition)) {
+	conditionOptional := services.IsExpressionMetricType(metricType) && condition == ""
+	if !conditionOptional && !contains(validConditions, strings.ToLower(condition)) {
 		return fmt.Errorf("invalid condition: %s. Valid conditions: %s", condition, strings.Join(validConditions, ", "))
 	}
 
@@ -583,6 +1060,189 @@ func (h *AlertHandler) validateAlertRequest(metricType, condition, severity stri
 	return nil
 }
 
+// validateProbe checks that the probe a probe alert is limited to exists (0 = every probe)
+func (h *AlertHandler) validateProbe(id uint) error {
+	if id == 0 {
+		return nil
+	}
+	if _, err := h.probeRepo.GetProbeByID(id); err != nil {
+		if err.Error() == "probe not found" {
+			return fmt.Errorf("probe %d does not exist", id)
+		}
+		return err
+	}
+	return nil
+}
+
+// validateEscalationPolicy checks that the escalation policy of an alert exists (0 = none)
+func (h *AlertHandler) validateEscalationPolicy(id uint) error {
+	if id == 0 {
+		return nil
+	}
+	if _, err := h.policyRepo.GetEscalationPolicyByID(id); err != nil {
+		if err.Error() == "escalation policy not found" {
+			return fmt.Errorf("escalation policy %d does not exist", id)
+		}
+		return err
+	}
+	return nil
+}
+
+// validateProcessWatch validates process watch settings for process metric types
+func (h *AlertHandler) validateProcessWatch(metricType, pattern string, isRegex bool, window int) error {
+	if !services.IsProcessMetricType(metricType) {
+		return nil
+	}
+
+	if _, err := services.NewProcessMatcher(pattern, isRegex); err != nil {
+		return err
+	}
+
+	if window < 0 {
+		return fmt.Errorf("window must not be negative")
+	}
+
+	return nil
+}
+
+// validateExpression parses the expression of expression-based alerts
+func (h *AlertHandler) validateExpression(metricType, expression string) error {
+	if !services.IsExpressionMetricType(metricType) {
+		return nil
+	}
+
+	if _, err := services.ParseExpression(expression); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+
+	return nil
+}
+
+// validateAggregation validates window aggregation settings
+func (h *AlertHandler) validateAggregation(metricType, aggregation string, window int) error {
+	if aggregation == "" {
+		return nil
+	}
+
+	if !services.IsAggregationFunction(aggregation) {
+		return fmt.Errorf("invalid aggregation: %s. Valid aggregations: %s", aggregation, strings.Join(services.AggregationFunctions(), ", "))
+	}
+
+	if !services.IsMetricField(metricType) {
+		return fmt.Errorf("aggregation is not supported for metric type %s", metricType)
+	}
+
+	if window < 0 || time.Duration(window)*time.Second > services.MaxAggregationWindow {
+		return fmt.Errorf("window must be between 0 and %d seconds", int(services.MaxAggregationWindow.Seconds()))
+	}
+
+	return nil
+}
+
+// validateClearThreshold validates the hysteresis of an alert. The clear threshold must lie
+// on the non-firing side of the threshold, e.g. below it for greater-than conditions.
+func (h *AlertHandler) validateClearThreshold(condition string, threshold float64, clear *float64) error {
+	if clear == nil {
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(condition)) {
+	case ">", ">=", "gt", "gte":
+		if *clear > threshold {
+			return fmt.Errorf("clear threshold must not be above the threshold for %s conditions", condition)
+		}
+	case "<", "<=", "lt", "lte":
+		if *clear < threshold {
+			return fmt.Errorf("clear threshold must not be below the threshold for %s conditions", condition)
+		}
+	default:
+		return fmt.Errorf("clear thresholds require a >, >=, < or <= condition")
+	}
+
+	return nil
+}
+
+// clearThreshold drops a clear threshold equal to the threshold, which adds no hysteresis
+func clearThreshold(threshold float64, clear *float64) *float64 {
+	if clear == nil || *clear == threshold {
+		return nil
+	}
+	return clear
+}
+
+// validateForecast validates disk forecast settings. Forecast alerts fire when the
+// projected hours until full drop below a horizon, so only less-than conditions make sense.
+func (h *AlertHandler) validateForecast(metricType, condition string, horizon float64, window int) error {
+	if !services.IsForecastMetricType(metricType) {
+		return nil
+	}
+
+	if !contains([]string{"<", "<=", "lt", "lte"}, condition) {
+		return fmt.Errorf("disk forecast alerts require a < or <= condition")
+	}
+
+	if horizon <= 0 {
+		return fmt.Errorf("disk forecast threshold must be a positive number of hours")
+	}
+
+	if window < 0 || time.Duration(window)*time.Second > services.MaxForecastLookback {
+		return fmt.Errorf("window must be between 0 and %d seconds", int(services.MaxForecastLookback.Seconds()))
+	}
+
+	return nil
+}
+
+// validateNoData validates no-data settings. The alert value is the number of
+// seconds since the last sample, so only greater-than conditions make sense.
+func (h *AlertHandler) validateNoData(metricType, condition string, silence float64, absentMetric string) error {
+	if !services.IsNoDataMetricType(metricType) {
+		return nil
+	}
+
+	if absentMetric != "" && !contains(services.AbsentMetrics(), absentMetric) {
+		return fmt.Errorf("invalid absent metric: %s. Valid metrics: %s", absentMetric, strings.Join(services.AbsentMetrics(), ", "))
+	}
+
+	if !contains([]string{">", ">=", "gt", "gte"}, condition) {
+		return fmt.Errorf("no-data alerts require a > or >= condition")
+	}
+	if silence <= 0 {
+		return fmt.Errorf("no-data threshold must be a positive number of seconds")
+	}
+
+	return nil
+}
+
+// validateAnomaly validates anomaly detection settings. Anomaly alerts compare the
+// deviation from the baseline, in standard deviations, against the threshold.
+func (h *AlertHandler) validateAnomaly(metricType, condition string, sensitivity float64, field, method, seasonality, direction string) error {
+	if !services.IsAnomalyMetricType(metricType) {
+		return nil
+	}
+
+	if !services.IsMetricField(field) {
+		return fmt.Errorf("invalid anomaly field: %s. Valid fields: %s", field, strings.Join(services.MetricFieldNames(), ", "))
+	}
+	if method != "" && !contains(services.AnomalyMethods(), method) {
+		return fmt.Errorf("invalid anomaly method: %s. Valid methods: %s", method, strings.Join(services.AnomalyMethods(), ", "))
+	}
+	if seasonality != "" && !contains(services.AnomalySeasonalities(), seasonality) {
+		return fmt.Errorf("invalid seasonality: %s. Valid values: %s", seasonality, strings.Join(services.AnomalySeasonalities(), ", "))
+	}
+	if direction != "" && !contains(services.AnomalyDirections(), direction) {
+		return fmt.Errorf("invalid anomaly direction: %s. Valid directions: %s", direction, strings.Join(services.AnomalyDirections(), ", "))
+	}
+
+	if !contains([]string{">", ">=", "gt", "gte"}, condition) {
+		return fmt.Errorf("anomaly alerts require a > or >= condition")
+	}
+	if sensitivity <= 0 {
+		return fmt.Errorf("anomaly threshold must be a positive number of standard deviations")
+	}
+
+	return nil
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,606 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// ManagedByRulesDir marks alerts owned by the rules directory
+const ManagedByRulesDir = "rules_dir"
+
+// Alert rule change actions
+const (
+	RuleActionCreate    = "create"
+	RuleActionUpdate    = "update"
+	RuleActionDelete    = "delete"
+	RuleActionUnchanged = "unchanged"
+)
+
+// AlertRule is the declarative form of an alert in rule files. It takes the fields of
+// CreateAlertRequest; rules are matched to alerts by name.
+type AlertRule struct {
+	CreateAlertRequest
+	IsActive *bool `json:"is_active,omitempty"` // Defaults to true
+}
+
+// AlertRuleFile is the YAML or JSON document alerts are exported to and imported from
+type AlertRuleFile struct {
+	Alerts []AlertRule `json:"alerts"`
+}
+
+// AlertRuleFieldChange is the old and new value of a field changed by an import
+type AlertRuleFieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AlertRuleChange describes what an import does to one alert
+type AlertRuleChange struct {
+	Action  string                          `json:"action"` // create, update, delete or unchanged
+	Name    string                          `json:"name"`
+	AlertID uint                            `json:"alert_id,omitempty"`
+	Fields  map[string]AlertRuleFieldChange `json:"fields,omitempty"` // Changed fields of updates
+
+	alert *models.Alert // Alert to create or update, or the alert to delete
+}
+
+// AlertRuleImport is the outcome of an import, or of what it would do on a dry run
+type AlertRuleImport struct {
+	DryRun    bool              `json:"dry_run"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Deleted   int               `json:"deleted"`
+	Unchanged int               `json:"unchanged"`
+	Changes   []AlertRuleChange `json:"changes"`
+}
+
+// alertRuleSync selects which alerts an import may delete and who it marks as their owner
+type alertRuleSync struct {
+	prune     bool   // Delete alerts without a rule
+	managedBy string // Owner set on synced alerts; prunes only alerts of that owner when set
+}
+
+// ExportAlerts exports all alerts as a rule file
+// @Summary Export alerts
+// @Description Export all alert configurations as a YAML or JSON rule file that can be imported again
+// @Tags alerts
+// @Produce json
+// @Produce plain
+// @Param format query string false "yaml (default) or json"
+// @Success 200 {object} AlertRuleFile
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/export [get]
+func (h *AlertHandler) ExportAlerts(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", "yaml"))
+	if format != "yaml" && format != "json" {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid format",
+			Message: "Format must be yaml or json",
+		})
+		return
+	}
+
+	alerts, err := h.alertRepo.GetAlerts()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve alerts",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Name < alerts[j].Name })
+	file := AlertRuleFile{Alerts: make([]AlertRule, 0, len(alerts))}
+	for _, alert := range alerts {
+		file.Alerts = append(file.Alerts, ruleFromAlert(alert))
+	}
+
+	data, err := marshalAlertRules(&file, format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to export alerts",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	contentType := "application/yaml"
+	if format == "json" {
+		contentType = "application/json"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=alerts.%s", format))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// ImportAlerts creates, updates and optionally deletes alerts to match a rule file
+// @Summary Import alerts
+// @Description Import a YAML or JSON rule file: alerts are matched by name, created or updated, and with prune deleted when they have no rule. A dry run only returns the changes.
+// @Tags alerts
+// @Accept plain
+// @Accept json
+// @Produce json
+// @Param rules body AlertRuleFile true "Rule file"
+// @Param dry_run query bool false "Only report the changes"
+// @Param prune query bool false "Delete alerts missing from the file, except alerts of the rules directory"
+// @Success 200 {object} APIResponse{data=AlertRuleImport}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/import [post]
+func (h *AlertHandler) ImportAlerts(c *gin.Context) {
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	rules, err := parseAlertRules(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid rule file",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	changes, err := h.planAlertRules(rules, alertRuleSync{prune: c.Query("prune") == "true"})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if _, invalid := err.(ruleError); invalid {
+			statusCode = http.StatusBadRequest
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result := &AlertRuleImport{DryRun: c.Query("dry_run") == "true", Changes: changes}
+	if !result.DryRun {
+		if err := h.applyAlertRules(changes); err != nil {
+			c.JSON(http.StatusInternalServerError, APIResponse{
+				Success: false,
+				Error:   "Failed to import alerts",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+	result.count()
+
+	message := "Alerts imported successfully"
+	if result.DryRun {
+		message = "Dry run, no alerts were changed"
+	}
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: message,
+	})
+}
+
+// SyncRulesDirectory reconciles the alerts with the rule files in a directory: alerts are
+// created or updated to match their rules, and alerts previously created from the directory
+// whose rule was removed are deleted. Alerts managed through the API are only touched when
+// a rule has their name.
+func (h *AlertHandler) SyncRulesDirectory(dir string) (*AlertRuleImport, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules directory: %w", err)
+	}
+
+	var rules []AlertRule
+	files := make(map[string]string)
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rule file %s: %w", entry.Name(), err)
+		}
+		fileRules, err := parseAlertRules(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule file %s: %w", entry.Name(), err)
+		}
+		for _, rule := range fileRules {
+			files[strings.TrimSpace(rule.Name)] = filepath.Join(dir, entry.Name())
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	changes, err := h.planAlertRules(rules, alertRuleSync{prune: true, managedBy: ManagedByRulesDir})
+	if err != nil {
+		return nil, err
+	}
+	if err := h.applyAlertRules(changes); err != nil {
+		return nil, err
+	}
+
+	h.ruleFilesMutex.Lock()
+	h.ruleFiles = files
+	h.ruleFilesMutex.Unlock()
+
+	result := &AlertRuleImport{Changes: changes}
+	result.count()
+	log.Printf("📜 Synced alert rules from %s: %d created, %d updated, %d deleted, %d unchanged",
+		dir, result.Created, result.Updated, result.Deleted, result.Unchanged)
+	return result, nil
+}
+
+// rejectRulesDirAlert writes a conflict response when an alert belongs to the rules directory,
+// whose rule files are the only place to change it; the next sync would undo API changes
+func (h *AlertHandler) rejectRulesDirAlert(c *gin.Context, alert *models.Alert) bool {
+	if alert.ManagedBy != ManagedByRulesDir {
+		return false
+	}
+
+	h.ruleFilesMutex.RLock()
+	file := h.ruleFiles[alert.Name]
+	h.ruleFilesMutex.RUnlock()
+	if file == "" {
+		file = "the rules directory"
+	}
+
+	c.JSON(http.StatusConflict, APIResponse{
+		Success: false,
+		Error:   "Alert is managed by the rules directory",
+		Message: fmt.Sprintf("Alert %q is defined in %s; change its rule file instead", alert.Name, file),
+	})
+	return true
+}
+
+// count tallies the changes of an import by action
+func (r *AlertRuleImport) count() {
+	for _, change := range r.Changes {
+		switch change.Action {
+		case RuleActionCreate:
+			r.Created++
+		case RuleActionUpdate:
+			r.Updated++
+		case RuleActionDelete:
+			r.Deleted++
+		case RuleActionUnchanged:
+			r.Unchanged++
+		}
+	}
+}
+
+// ruleError is a rule file that fails validation
+type ruleError struct {
+	error
+}
+
+// parseAlertRules decodes a YAML or JSON rule file. JSON is valid YAML, so both go through
+// the YAML decoder and then the JSON field names of AlertRule.
+func parseAlertRules(data []byte) ([]AlertRule, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, fmt.Errorf("rule file is empty")
+	}
+
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var file AlertRuleFile
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+	return file.Alerts, nil
+}
+
+// marshalAlertRules encodes a rule file as YAML or JSON. Empty fields are left out.
+func marshalAlertRules(file *AlertRuleFile, format string) ([]byte, error) {
+	encoded, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the JSON as YAML to keep the field order of AlertRule
+	var document yaml.Node
+	if err := yaml.Unmarshal(encoded, &document); err != nil {
+		return nil, err
+	}
+	pruneRuleNode(&document)
+
+	if format == "json" {
+		var value interface{}
+		if err := document.Decode(&value); err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(value, "", "  ")
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// pruneRuleNode drops empty and null fields from the mappings of a YAML node tree and
+// switches it from the flow style of JSON to block style
+func pruneRuleNode(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		pruneRuleNode(child)
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	var content []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isEmptyRuleNode(node.Content[i+1]) {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+	node.Content = content
+}
+
+// isEmptyRuleNode reports whether a node is null, an empty string or an empty collection
+func isEmptyRuleNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Tag == "!!null" || (node.Tag == "!!str" && node.Value == "")
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+// ruleFromAlert returns the rule describing an alert
+func ruleFromAlert(alert *models.Alert) AlertRule {
+	isActive := alert.IsActive
+	return AlertRule{
+		CreateAlertRequest: CreateAlertRequest{
+			Name:               alert.Name,
+			MetricType:         alert.MetricType,
+			Condition:          alert.Condition,
+			Threshold:          alert.Threshold,
+			ClearThreshold:     alert.ClearThreshold,
+			Duration:           alert.Duration,
+			Severity:           alert.Severity,
+			Levels:             alert.Levels,
+			HostSelector:       alert.HostSelector,
+			HostOverrides:      alert.HostOverrides,
+			Description:        alert.Description,
+			Labels:             alert.Labels,
+			Expression:         alert.Expression,
+			Mountpoint:         alert.Mountpoint,
+			AbsentMetric:       alert.AbsentMetric,
+			AnomalyField:       alert.AnomalyField,
+			AnomalyMethod:      alert.AnomalyMethod,
+			Seasonality:        alert.Seasonality,
+			AnomalyDirection:   alert.AnomalyDirection,
+			Aggregation:        alert.Aggregation,
+			EmailEnabled:       alert.EmailEnabled,
+			EmailRecipients:    alert.EmailRecipients,
+			WebhookEnabled:     alert.WebhookEnabled,
+			WebhookURL:         alert.WebhookURL,
+			SkipResolved:       alert.SkipResolved,
+			Templates:          alert.Templates,
+			ProbeID:            alert.ProbeID,
+			EscalationPolicyID: alert.EscalationPolicyID,
+			CertificateFilter:  alert.CertificateFilter,
+			ProcessPattern:     alert.ProcessPattern,
+			ProcessRegex:       alert.ProcessRegex,
+			Window:             alert.Window,
+		},
+		IsActive: &isActive,
+	}
+}
+
+// ruleFields returns the fields of a rule by their JSON name, without empty ones, for diffs
+func ruleFields(rule AlertRule) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	pruneRuleFields(fields)
+	return fields, nil
+}
+
+// pruneRuleFields drops null, empty string and empty collection fields, like pruneRuleNode
+func pruneRuleFields(fields map[string]interface{}) {
+	for name, value := range fields {
+		switch value := value.(type) {
+		case nil:
+			delete(fields, name)
+		case string:
+			if value == "" {
+				delete(fields, name)
+			}
+		case []interface{}:
+			if len(value) == 0 {
+				delete(fields, name)
+			}
+		case map[string]interface{}:
+			pruneRuleFields(value)
+			if len(value) == 0 {
+				delete(fields, name)
+			}
+		}
+	}
+}
+
+// diffRules returns the fields that differ between two rules
+func diffRules(from, to AlertRule) (map[string]AlertRuleFieldChange, error) {
+	fromFields, err := ruleFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := ruleFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]AlertRuleFieldChange)
+	for name, value := range toFields {
+		if !reflect.DeepEqual(fromFields[name], value) {
+			changes[name] = AlertRuleFieldChange{From: fromFields[name], To: value}
+		}
+	}
+	for name, value := range fromFields {
+		if _, exists := toFields[name]; !exists {
+			changes[name] = AlertRuleFieldChange{From: value}
+		}
+	}
+	return changes, nil
+}
+
+// planAlertRules validates rules and works out the changes that make the alerts match them.
+// Validation failures are returned as ruleError.
+func (h *AlertHandler) planAlertRules(rules []AlertRule, options alertRuleSync) ([]AlertRuleChange, error) {
+	existing, err := h.alertRepo.GetAlerts()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*models.Alert, len(existing))
+	for _, alert := range existing {
+		byName[alert.Name] = alert
+	}
+
+	var changes []AlertRuleChange
+	seen := make(map[string]bool, len(rules))
+	for i := range rules {
+		rule := rules[i]
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			return nil, ruleError{fmt.Errorf("alert %d: name is required", i+1)}
+		}
+		if seen[rule.Name] {
+			return nil, ruleError{fmt.Errorf("alert %q: defined more than once", rule.Name)}
+		}
+		seen[rule.Name] = true
+
+		alert, err := h.alertFromRequest(&rule.CreateAlertRequest)
+		if err != nil {
+			return nil, ruleError{fmt.Errorf("alert %q: %w", rule.Name, err)}
+		}
+		if rule.IsActive != nil {
+			alert.IsActive = *rule.IsActive
+		}
+		alert.ManagedBy = options.managedBy
+
+		current, exists := byName[rule.Name]
+		if !exists {
+			changes = append(changes, AlertRuleChange{Action: RuleActionCreate, Name: rule.Name, alert: alert})
+			continue
+		}
+
+		// Keep the identity and statistics of the alert being updated
+		alert.BaseModel = current.BaseModel
+		alert.TriggeredCount = current.TriggeredCount
+		alert.LastTriggered = current.LastTriggered
+		if options.managedBy == "" {
+			alert.ManagedBy = current.ManagedBy
+		}
+
+		fields, err := diffRules(ruleFromAlert(current), ruleFromAlert(alert))
+		if err != nil {
+			return nil, err
+		}
+		if alert.ManagedBy != current.ManagedBy {
+			fields["managed_by"] = AlertRuleFieldChange{From: current.ManagedBy, To: alert.ManagedBy}
+		}
+
+		change := AlertRuleChange{Action: RuleActionUnchanged, Name: rule.Name, AlertID: current.ID, alert: alert}
+		if len(fields) > 0 {
+			change.Action = RuleActionUpdate
+			change.Fields = fields
+		}
+		changes = append(changes, change)
+	}
+
+	if options.prune {
+		for _, alert := range existing {
+			if seen[alert.Name] || (options.managedBy != "" && alert.ManagedBy != options.managedBy) {
+				continue
+			}
+			// Only the rules directory deletes its alerts
+			if options.managedBy == "" && alert.ManagedBy == ManagedByRulesDir {
+				continue
+			}
+			changes = append(changes, AlertRuleChange{Action: RuleActionDelete, Name: alert.Name, AlertID: alert.ID, alert: alert})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes, nil
+}
+
+// applyAlertRules carries out planned changes. Deletions go first so a renamed rule can take
+// over the name of a deleted alert.
+func (h *AlertHandler) applyAlertRules(changes []AlertRuleChange) error {
+	for i := range changes {
+		if change := &changes[i]; change.Action == RuleActionDelete {
+			if err := h.alertRepo.DeleteAlert(change.AlertID); err != nil {
+				return fmt.Errorf("alert %q: %w", change.Name, err)
+			}
+		}
+	}
+
+	for i := range changes {
+		change := &changes[i]
+		switch change.Action {
+		case RuleActionCreate:
+			if err := h.alertRepo.CreateAlert(change.alert); err != nil {
+				return fmt.Errorf("alert %q: %w", change.Name, err)
+			}
+			change.AlertID = change.alert.ID
+		case RuleActionUpdate:
+			if err := h.alertRepo.UpdateAlert(change.alert); err != nil {
+				return fmt.Errorf("alert %q: %w", change.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// ruleAlertRepo keeps alerts in memory for rule imports
+type ruleAlertRepo struct {
+	repository.AlertRepository
+	alerts map[uint]*models.Alert
+	nextID uint
+}
+
+func newRuleAlertRepo(alerts ...*models.Alert) *ruleAlertRepo {
+	repo := &ruleAlertRepo{alerts: make(map[uint]*models.Alert), nextID: 100}
+	for _, alert := range alerts {
+		repo.alerts[alert.ID] = alert
+	}
+	return repo
+}
+
+func (r *ruleAlertRepo) GetAlerts() ([]*models.Alert, error) {
+	var alerts []*models.Alert
+	for _, alert := range r.alerts {
+		copied := *alert
+		alerts = append(alerts, &copied)
+	}
+	return alerts, nil
+}
+
+func (r *ruleAlertRepo) GetAlertByID(id uint) (*models.Alert, error) {
+	alert, exists := r.alerts[id]
+	if !exists {
+		return nil, fmt.Errorf("alert not found")
+	}
+	copied := *alert
+	return &copied, nil
+}
+
+func (r *ruleAlertRepo) CreateAlert(alert *models.Alert) error {
+	r.nextID++
+	alert.ID = r.nextID
+	r.alerts[alert.ID] = alert
+	return nil
+}
+
+func (r *ruleAlertRepo) UpdateAlert(alert *models.Alert) error {
+	r.alerts[alert.ID] = alert
+	return nil
+}
+
+func (r *ruleAlertRepo) DeleteAlert(id uint) error {
+	delete(r.alerts, id)
+	return nil
+}
+
+func (r *ruleAlertRepo) byName(name string) *models.Alert {
+	for _, alert := range r.alerts {
+		if alert.Name == name {
+			return alert
+		}
+	}
+	return nil
+}
+
+func setupRuleRouter(repo *ruleAlertRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewAlertHandler(repo, nil, nil, nil, nil, nil)
+	router := gin.New()
+	router.GET("/api/v1/alerts/export", handler.ExportAlerts)
+	router.POST("/api/v1/alerts/import", handler.ImportAlerts)
+	return router
+}
+
+func TestAlertRules_ExportImportRoundTrip(t *testing.T) {
+	clearAt := 70.0
+	repo := newRuleAlertRepo(&models.Alert{
+		BaseModel:      models.BaseModel{ID: 1},
+		Name:           "High CPU",
+		MetricType:     "cpu",
+		Condition:      ">",
+		Threshold:      80,
+		ClearThreshold: &clearAt,
+		Duration:       60,
+		Severity:       "warning",
+		Levels:         []models.SeverityLevel{{Severity: "warning", Threshold: 80}, {Severity: "critical", Threshold: 95}},
+		HostSelector:   models.HostSelector{Exclude: []string{"test-*"}},
+		Labels:         map[string]string{"team": "infra"},
+		IsActive:       true,
+		TriggeredCount: 3,
+	})
+	router := setupRuleRouter(repo)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/alerts/export", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	exported := w.Body.String()
+	assert.Contains(t, exported, "name: High CPU")
+	assert.Contains(t, exported, "clear_threshold: 70")
+	assert.NotContains(t, exported, "expression") // Empty fields are left out
+
+	// Importing the export changes nothing
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/alerts/import", strings.NewReader(exported)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"unchanged":1`)
+
+	// JSON exports import as well
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/alerts/export?format=json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	rules, err := parseAlertRules(w.Body.Bytes())
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Len(t, rules[0].Levels, 2)
+}
+
+func TestAlertRules_ImportDryRunAndPrune(t *testing.T) {
+	repo := newRuleAlertRepo(
+		&models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "High CPU", MetricType: "cpu", Condition: ">", Threshold: 80, Severity: "warning", IsActive: true},
+		&models.Alert{BaseModel: models.BaseModel{ID: 2}, Name: "Old", MetricType: "memory", Condition: ">", Threshold: 90, Severity: "warning", IsActive: true},
+	)
+	router := setupRuleRouter(repo)
+
+	body := `
+alerts:
+  - name: High CPU
+    metric_type: cpu
+    condition: ">"
+    threshold: 90
+    severity: warning
+  - name: Disk
+    metric_type: disk
+    condition: ">"
+    threshold: 85
+    severity: critical
+`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/alerts/import?dry_run=true&prune=true", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"threshold":{"from":80,"to":90}`)
+	assert.Contains(t, w.Body.String(), `"created":1,"updated":1,"deleted":1`)
+	assert.Equal(t, 80.0, repo.byName("High CPU").Threshold) // Dry run
+	assert.NotNil(t, repo.byName("Old"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/alerts/import?prune=true", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 90.0, repo.byName("High CPU").Threshold)
+	assert.Equal(t, uint(1), repo.byName("High CPU").ID)
+	assert.NotNil(t, repo.byName("Disk"))
+	assert.Nil(t, repo.byName("Old"))
+
+	// Invalid rules and unknown fields are rejected
+	for _, invalid := range []string{
+		"alerts:\n  - name: Bad\n    metric_type: nope\n    condition: '>'\n    severity: warning\n",
+		"alerts:\n  - name: Typo\n    metric_typ: cpu\n",
+		"alerts:\n  - name: Twice\n    metric_type: cpu\n    condition: '>'\n    severity: info\n  - name: Twice\n    metric_type: cpu\n    condition: '>'\n    severity: info\n",
+	} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/alerts/import", strings.NewReader(invalid)))
+		assert.Equal(t, http.StatusBadRequest, w.Code, invalid)
+	}
+}
+
+func TestAlertRules_SyncRulesDirectory(t *testing.T) {
+	repo := newRuleAlertRepo(
+		&models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "Manual", MetricType: "cpu", Condition: ">", Threshold: 80, Severity: "warning", IsActive: true},
+		&models.Alert{BaseModel: models.BaseModel{ID: 2}, Name: "Removed", MetricType: "cpu", Condition: ">", Threshold: 80, Severity: "warning", IsActive: true, ManagedBy: ManagedByRulesDir},
+	)
+	handler := NewAlertHandler(repo, nil, nil, nil, nil, nil)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.yaml"), []byte(`
+alerts:
+  - name: Memory
+    metric_type: memory
+    condition: ">"
+    threshold: 90
+    severity: critical
+    is_active: false
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a rule file"), 0o644))
+
+	result, err := handler.SyncRulesDirectory(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Deleted)
+
+	memory := repo.byName("Memory")
+	require.NotNil(t, memory)
+	assert.Equal(t, ManagedByRulesDir, memory.ManagedBy)
+	assert.False(t, memory.IsActive)
+	assert.NotNil(t, repo.byName("Manual")) // Not managed by the directory
+	assert.Nil(t, repo.byName("Removed"))
+
+	// A second sync is a no-op
+	result, err = handler.SyncRulesDirectory(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Zero(t, result.Created+result.Updated+result.Deleted)
+}
+
+func TestAlertRules_RulesDirAlertsAreReadOnly(t *testing.T) {
+	repo := newRuleAlertRepo(
+		&models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "Manual", MetricType: "cpu", Condition: ">", Threshold: 80, Severity: "warning", IsActive: true},
+	)
+	handler := NewAlertHandler(repo, nil, nil, nil, nil, nil)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "memory.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+alerts:
+  - name: Memory
+    metric_type: memory
+    condition: ">"
+    threshold: 90
+    severity: critical
+`), 0o644))
+	_, err := handler.SyncRulesDirectory(dir)
+	require.NoError(t, err)
+	memory := repo.byName("Memory")
+	require.NotNil(t, memory)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/v1/alerts/:id", handler.UpdateAlert)
+	router.DELETE("/api/v1/alerts/:id", handler.DeleteAlert)
+	router.POST("/api/v1/alerts/import", handler.ImportAlerts)
+	path := fmt.Sprintf("/api/v1/alerts/%d", memory.ID)
+
+	// The API cannot edit or delete alerts of the rules directory
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"threshold": 50}`)))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), file)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 90.0, repo.byName("Memory").Threshold)
+
+	// Pruning imports leave them to the rules directory
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/alerts/import?prune=true", strings.NewReader("alerts: []\n")))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Nil(t, repo.byName("Manual"))
+	assert.NotNil(t, repo.byName("Memory"))
+
+	// Unknown alerts are still not found
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/alerts/999", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// policyRepo holds a fixed set of escalation policies
+type policyRepo struct {
+	repository.EscalationPolicyRepository
+	policies map[uint]*models.EscalationPolicy
+}
+
+func (r *policyRepo) GetEscalationPolicyByID(id uint) (*models.EscalationPolicy, error) {
+	policy, exists := r.policies[id]
+	if !exists {
+		return nil, fmt.Errorf("escalation policy not found")
+	}
+	return policy, nil
+}
+
+// probeRepo holds a fixed set of probes
+type probeRepo struct {
+	repository.ProbeRepository
+	probes map[uint]*models.Probe
+}
+
+func (r *probeRepo) GetProbeByID(id uint) (*models.Probe, error) {
+	probe, exists := r.probes[id]
+	if !exists {
+		return nil, fmt.Errorf("probe not found")
+	}
+	return probe, nil
+}
+
+func TestAlertHandler_EscalationPolicyMustExist(t *testing.T) {
+	repo := newRuleAlertRepo(&models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "CPU", MetricType: "cpu", Condition: ">", Threshold: 80, Severity: "warning", IsActive: true})
+	policies := &policyRepo{policies: map[uint]*models.EscalationPolicy{3: {BaseModel: models.BaseModel{ID: 3}, Name: "on-call"}}}
+	handler := NewAlertHandler(repo, nil, policies, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/v1/alerts/:id", handler.UpdateAlert)
+	router.POST("/api/v1/alerts/import", handler.ImportAlerts)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/alerts/1", strings.NewReader(`{"escalation_policy_id": 9}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "escalation policy 9 does not exist")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/alerts/1", strings.NewReader(`{"escalation_policy_id": 3}`)))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, uint(3), repo.alerts[1].EscalationPolicyID)
+
+	// Rule imports are checked too
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/alerts/import", strings.NewReader(
+		"alerts:\n  - name: Memory\n    metric_type: memory\n    condition: '>'\n    threshold: 90\n    severity: critical\n    escalation_policy_id: 9\n")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "escalation policy 9 does not exist")
+	assert.Nil(t, repo.byName("Memory"))
+}
+
+func TestAlertHandler_ProbeMustExist(t *testing.T) {
+	repo := newRuleAlertRepo(&models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "API down", MetricType: "probe_success", Condition: "==", Threshold: 0, Severity: "critical", IsActive: true})
+	probes := &probeRepo{probes: map[uint]*models.Probe{4: {BaseModel: models.BaseModel{ID: 4}, Name: "api"}}}
+	handler := NewAlertHandler(repo, probes, nil, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/v1/alerts/:id", handler.UpdateAlert)
+	router.POST("/api/v1/alerts/import", handler.ImportAlerts)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/alerts/1", strings.NewReader(`{"probe_id": 9}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "probe 9 does not exist")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/alerts/1", strings.NewReader(`{"probe_id": 4}`)))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, uint(4), repo.alerts[1].ProbeID)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/alerts/import", strings.NewReader(
+		"alerts:\n  - name: Web down\n    metric_type: probe_success\n    condition: '=='\n    threshold: 0\n    severity: critical\n    probe_id: 9\n")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "probe 9 does not exist")
+}
+
+func TestAlertHandler_UpdateAlert(t *testing.T) {
+	triggered := time.Now().Add(-time.Hour)
+	repo := newRuleAlertRepo(&models.Alert{
+		BaseModel: models.BaseModel{ID: 1}, Name: "CPU", MetricType: "cpu", Condition: ">", Threshold: 80, Severity: "warning",
+		Duration: 60, IsActive: true, Labels: map[string]string{"team": "infra"}, TriggeredCount: 7, LastTriggered: triggered,
+	})
+	handler := NewAlertHandler(repo, nil, nil, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/v1/alerts/:id", handler.UpdateAlert)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/alerts/1", strings.NewReader(`{"threshold": 90, "aggregation": " AVG ", "window": 300}`)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Fields missing from the update keep their value, identity and statistics are kept
+	alert := repo.alerts[1]
+	assert.Equal(t, uint(1), alert.ID)
+	assert.Equal(t, "CPU", alert.Name)
+	assert.Equal(t, 90.0, alert.Threshold)
+	assert.Equal(t, "avg", alert.Aggregation)
+	assert.Equal(t, 60, alert.Duration)
+	assert.Equal(t, map[string]string{"team": "infra"}, alert.Labels)
+	assert.Equal(t, 7, alert.TriggeredCount)
+	assert.True(t, alert.LastTriggered.Equal(triggered))
+	assert.True(t, alert.IsActive)
+
+	// Metric types are stored in the form the evaluator matches
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/alerts/1", strings.NewReader(`{"metric_type": " Memory "}`)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "memory", repo.alerts[1].MetricType)
+
+	// The updated alert is validated like a new one
+	for _, invalid := range []string{
+		`{"condition": "~"}`,
+		`{"levels": [{"severity": "critical", "threshold": 80}, {"severity": "warning", "threshold": 95}]}`,
+		`{"clear_threshold": 95}`,
+	} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/alerts/1", strings.NewReader(invalid)))
+		assert.Equal(t, http.StatusBadRequest, w.Code, invalid)
+	}
+	assert.Equal(t, 90.0, repo.alerts[1].Threshold)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/services"
+)
+
+// CertificateHandler handles HTTP requests for TLS certificate monitoring
+type CertificateHandler struct {
+	certificateService *services.CertificateService
+}
+
+// NewCertificateHandler creates a new certificate handler
+func NewCertificateHandler(certificateService *services.CertificateService) *CertificateHandler {
+	return &CertificateHandler{
+		certificateService: certificateService,
+	}
+}
+
+// GetCertificates lists inspected certificates
+// @Summary Get certificates
+// @Description List monitored TLS certificates from PEM files and endpoints, soonest expiry first
+// @Tags certificates
+// @Produce json
+// @Param refresh query bool false "Inspect certificates now instead of returning the last results"
+// @Success 200 {object} APIResponse{data=[]models.CertificateInfo}
+// @Failure 503 {object} APIResponse
+// @Router /api/v1/certificates [get]
+func (h *CertificateHandler) GetCertificates(c *gin.Context) {
+	if h.certificateService == nil {
+		c.JSON(http.StatusServiceUnavailable, APIResponse{
+			Success: false,
+			Error:   "Certificate monitoring unavailable",
+			Message: "Certificate service is not initialized",
+		})
+		return
+	}
+
+	var certificates []models.CertificateInfo
+	if c.Query("refresh") == "true" {
+		certificates = h.certificateService.Refresh()
+	} else {
+		certificates = h.certificateService.GetCertificates()
+	}
+
+	// Sort a copy so the cached slice is left untouched
+	sorted := make([]models.CertificateInfo, len(certificates))
+	copy(sorted, certificates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		// Certificates that could not be inspected go last
+		if (sorted[i].Error == "") != (sorted[j].Error == "") {
+			return sorted[i].Error == ""
+		}
+		return sorted[i].DaysUntilExpiry < sorted[j].DaysUntilExpiry
+	})
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    sorted,
+	})
+}
@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+	"github.com/eyzaun/godash/internal/services"
+)
+
+// EscalationPolicyHandler handles HTTP requests for escalation policies
+type EscalationPolicyHandler struct {
+	policyRepo   repository.EscalationPolicyRepository
+	alertService *services.AlertService
+}
+
+// NewEscalationPolicyHandler creates a new escalation policy handler
+func NewEscalationPolicyHandler(policyRepo repository.EscalationPolicyRepository, alertService *services.AlertService) *EscalationPolicyHandler {
+	return &EscalationPolicyHandler{
+		policyRepo:   policyRepo,
+		alertService: alertService,
+	}
+}
+
+// CreateEscalationPolicyRequest represents the request body for creating escalation policies
+type CreateEscalationPolicyRequest struct {
+	Name           string                  `json:"name" binding:"required"`
+	Description    string                  `json:"description"`
+	Steps          []models.EscalationStep `json:"steps" binding:"required"`
+	RepeatInterval int                     `json:"repeat_interval"`
+}
+
+// UpdateEscalationPolicyRequest represents the request body for updating escalation policies
+type UpdateEscalationPolicyRequest struct {
+	Name           *string                 `json:"name"`
+	Description    *string                 `json:"description"`
+	Steps          []models.EscalationStep `json:"steps"`
+	RepeatInterval *int                    `json:"repeat_interval"`
+}
+
+// CreateEscalationPolicy creates a new escalation policy
+// @Summary Create escalation policy
+// @Description Create an ordered list of notification steps for unacknowledged incidents
+// @Tags escalation-policies
+// @Accept json
+// @Produce json
+// @Param policy body CreateEscalationPolicyRequest true "Escalation policy"
+// @Success 201 {object} APIResponse{data=models.EscalationPolicy}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/escalation-policies [post]
+func (h *EscalationPolicyHandler) CreateEscalationPolicy(c *gin.Context) {
+	var req CreateEscalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	policy := &models.EscalationPolicy{
+		Name:           req.Name,
+		Description:    req.Description,
+		Steps:          req.Steps,
+		RepeatInterval: req.RepeatInterval,
+	}
+
+	if err := services.ValidateEscalationPolicy(policy); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.policyRepo.CreateEscalationPolicy(policy); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to create escalation policy",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadPolicies()
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Data:    policy,
+		Message: "Escalation policy created successfully",
+	})
+}
+
+// GetEscalationPolicies retrieves all escalation policies
+// @Summary Get escalation policies
+// @Description Retrieve all escalation policies
+// @Tags escalation-policies
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]models.EscalationPolicy}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/escalation-policies [get]
+func (h *EscalationPolicyHandler) GetEscalationPolicies(c *gin.Context) {
+	policies, err := h.policyRepo.GetEscalationPolicies()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve escalation policies",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    policies,
+	})
+}
+
+// GetEscalationPolicy retrieves a specific escalation policy by ID
+// @Summary Get escalation policy by ID
+// @Description Retrieve a specific escalation policy by ID
+// @Tags escalation-policies
+// @Produce json
+// @Param id path int true "Escalation Policy ID"
+// @Success 200 {object} APIResponse{data=models.EscalationPolicy}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/escalation-policies/{id} [get]
+func (h *EscalationPolicyHandler) GetEscalationPolicy(c *gin.Context) {
+	policy, ok := h.loadPolicy(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    policy,
+	})
+}
+
+// UpdateEscalationPolicy updates an existing escalation policy
+// @Summary Update escalation policy
+// @Description Update an existing escalation policy
+// @Tags escalation-policies
+// @Accept json
+// @Produce json
+// @Param id path int true "Escalation Policy ID"
+// @Param policy body UpdateEscalationPolicyRequest true "Escalation policy updates"
+// @Success 200 {object} APIResponse{data=models.EscalationPolicy}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/escalation-policies/{id} [put]
+func (h *EscalationPolicyHandler) UpdateEscalationPolicy(c *gin.Context) {
+	policy, ok := h.loadPolicy(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateEscalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Update fields
+	if req.Name != nil {
+		policy.Name = *req.Name
+	}
+	if req.Description != nil {
+		policy.Description = *req.Description
+	}
+	if req.Steps != nil {
+		policy.Steps = req.Steps
+	}
+	if req.RepeatInterval != nil {
+		policy.RepeatInterval = *req.RepeatInterval
+	}
+
+	if err := services.ValidateEscalationPolicy(policy); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.policyRepo.UpdateEscalationPolicy(policy); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to update escalation policy",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadPolicies()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    policy,
+		Message: "Escalation policy updated successfully",
+	})
+}
+
+// DeleteEscalationPolicy deletes an escalation policy
+// @Summary Delete escalation policy
+// @Description Delete an escalation policy; its alerts fall back to their own notification settings
+// @Tags escalation-policies
+// @Produce json
+// @Param id path int true "Escalation Policy ID"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/escalation-policies/{id} [delete]
+func (h *EscalationPolicyHandler) DeleteEscalationPolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid escalation policy ID",
+			Message: "Escalation policy ID must be a valid number",
+		})
+		return
+	}
+
+	if err := h.policyRepo.DeleteEscalationPolicy(uint(id)); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "escalation policy not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to delete escalation policy",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadPolicies()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Escalation policy deleted successfully",
+	})
+}
+
+// loadPolicy parses the policy ID parameter and loads the policy, writing an error response on failure
+func (h *EscalationPolicyHandler) loadPolicy(c *gin.Context) (*models.EscalationPolicy, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid escalation policy ID",
+			Message: "Escalation policy ID must be a valid number",
+		})
+		return nil, false
+	}
+
+	policy, err := h.policyRepo.GetEscalationPolicyByID(uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "escalation policy not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve escalation policy",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	return policy, true
+}
+
+// reloadPolicies tells the alert service to pick up escalation policy changes
+func (h *EscalationPolicyHandler) reloadPolicies() {
+	if h.alertService != nil {
+		h.alertService.ReloadEscalationPolicies()
+	}
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+	"github.com/eyzaun/godash/internal/services"
+)
+
+// HostHandler handles HTTP requests for host metadata
+type HostHandler struct {
+	hostRepo     repository.HostRepository
+	alertService *services.AlertService
+}
+
+// NewHostHandler creates a new host handler
+func NewHostHandler(hostRepo repository.HostRepository, alertService *services.AlertService) *HostHandler {
+	return &HostHandler{
+		hostRepo:     hostRepo,
+		alertService: alertService,
+	}
+}
+
+// HostMetadataRequest represents the request body for setting host metadata
+type HostMetadataRequest struct {
+	Labels      map[string]string `json:"labels"`
+	Description string            `json:"description"`
+}
+
+// GetHostMetadataList retrieves the metadata of all hosts
+// @Summary Get host metadata
+// @Description Retrieve the labels of all hosts, matched by alert host selectors
+// @Tags hosts
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]models.HostMetadata}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/hosts [get]
+func (h *HostHandler) GetHostMetadataList(c *gin.Context) {
+	metadata, err := h.hostRepo.GetAllHostMetadata()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve host metadata",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    metadata,
+	})
+}
+
+// GetHostMetadata retrieves the metadata of a host
+// @Summary Get host metadata by hostname
+// @Description Retrieve the labels of a host
+// @Tags hosts
+// @Produce json
+// @Param hostname path string true "Hostname"
+// @Success 200 {object} APIResponse{data=models.HostMetadata}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/hosts/{hostname} [get]
+func (h *HostHandler) GetHostMetadata(c *gin.Context) {
+	metadata, err := h.hostRepo.GetHostMetadata(c.Param("hostname"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "host metadata not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve host metadata",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    metadata,
+	})
+}
+
+// SetHostMetadata creates or replaces the metadata of a host
+// @Summary Set host metadata
+// @Description Set the labels of a host, e.g. role=db or group=web
+// @Tags hosts
+// @Accept json
+// @Produce json
+// @Param hostname path string true "Hostname"
+// @Param metadata body HostMetadataRequest true "Host metadata"
+// @Success 200 {object} APIResponse{data=models.HostMetadata}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/hosts/{hostname} [put]
+func (h *HostHandler) SetHostMetadata(c *gin.Context) {
+	var req HostMetadataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	labels := make(map[string]string, len(req.Labels))
+	for name, value := range req.Labels {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			c.JSON(http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   "Validation failed",
+				Message: "label names cannot be empty",
+			})
+			return
+		}
+		labels[name] = strings.TrimSpace(value)
+	}
+
+	metadata := &models.HostMetadata{
+		Hostname:    c.Param("hostname"),
+		Labels:      labels,
+		Description: req.Description,
+	}
+	if err := h.hostRepo.SaveHostMetadata(metadata); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to save host metadata",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadHostMetadata()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    metadata,
+		Message: "Host metadata saved successfully",
+	})
+}
+
+// DeleteHostMetadata deletes the metadata of a host
+// @Summary Delete host metadata
+// @Description Delete the labels of a host
+// @Tags hosts
+// @Produce json
+// @Param hostname path string true "Hostname"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/hosts/{hostname} [delete]
+func (h *HostHandler) DeleteHostMetadata(c *gin.Context) {
+	if err := h.hostRepo.DeleteHostMetadata(c.Param("hostname")); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "host metadata not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to delete host metadata",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadHostMetadata()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Host metadata deleted successfully",
+	})
+}
+
+// reloadHostMetadata refreshes the host labels cached by the alert service
+func (h *HostHandler) reloadHostMetadata() {
+	if h.alertService != nil {
+		h.alertService.ReloadHostMetadata()
+	}
+}
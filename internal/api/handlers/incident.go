@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// IncidentActionRequest represents the request body for acknowledging, unacknowledging,
+// commenting on and resolving incidents
+type IncidentActionRequest struct {
+	User    string `json:"user"`
+	Comment string `json:"comment"`
+}
+
+// AssignIncidentRequest represents the request body for assigning incidents
+type AssignIncidentRequest struct {
+	User     string `json:"user"`
+	Assignee string `json:"assignee"` // Empty to unassign
+	Comment  string `json:"comment"`
+}
+
+// IncidentResponse is an alert history entry with its timeline
+type IncidentResponse struct {
+	Incident *models.AlertHistory    `json:"incident"`
+	Timeline []*models.IncidentEvent `json:"timeline"`
+}
+
+// GetIncident retrieves an incident with its timeline
+// @Summary Get incident
+// @Description Retrieve an alert history entry with its acknowledgement, assignee and timeline
+// @Tags alerts
+// @Produce json
+// @Param id path int true "Alert History ID"
+// @Success 200 {object} APIResponse{data=IncidentResponse}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/history/{id} [get]
+func (h *AlertHandler) GetIncident(c *gin.Context) {
+	id, ok := parseHistoryID(c)
+	if !ok {
+		return
+	}
+
+	incident, err := h.alertRepo.GetIncident(id)
+	if err != nil {
+		respondIncidentError(c, "Failed to retrieve incident", err)
+		return
+	}
+
+	timeline, err := h.alertRepo.GetIncidentEvents(id)
+	if err != nil {
+		respondIncidentError(c, "Failed to retrieve incident timeline", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    IncidentResponse{Incident: incident, Timeline: timeline},
+	})
+}
+
+// GetIncidentTimeline retrieves the timeline of an incident
+// @Summary Get incident timeline
+// @Description Retrieve the ack, unack, assign, comment, escalate and resolve entries of an incident, oldest first
+// @Tags alerts
+// @Produce json
+// @Param id path int true "Alert History ID"
+// @Success 200 {object} APIResponse{data=[]models.IncidentEvent}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/history/{id}/timeline [get]
+func (h *AlertHandler) GetIncidentTimeline(c *gin.Context) {
+	id, ok := parseHistoryID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.alertRepo.GetIncident(id); err != nil {
+		respondIncidentError(c, "Failed to retrieve incident timeline", err)
+		return
+	}
+
+	timeline, err := h.alertRepo.GetIncidentEvents(id)
+	if err != nil {
+		respondIncidentError(c, "Failed to retrieve incident timeline", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    timeline,
+	})
+}
+
+// AcknowledgeIncident acknowledges a firing incident
+// @Summary Acknowledge incident
+// @Description Acknowledge a firing incident, stopping repeat notifications without resolving it
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param id path int true "Alert History ID"
+// @Param request body IncidentActionRequest false "User and comment"
+// @Success 200 {object} APIResponse{data=models.AlertHistory}
+// @Failure 404 {object} APIResponse
+// @Failure 409 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/history/{id}/ack [post]
+func (h *AlertHandler) AcknowledgeIncident(c *gin.Context) {
+	id, req, ok := h.bindIncidentAction(c)
+	if !ok {
+		return
+	}
+
+	incident, err := h.alertService.AcknowledgeIncident(id, req.User, req.Comment)
+	if err != nil {
+		respondIncidentError(c, "Failed to acknowledge incident", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    incident,
+		Message: "Incident acknowledged successfully",
+	})
+}
+
+// UnacknowledgeIncident withdraws the acknowledgement of an incident
+// @Summary Unacknowledge incident
+// @Description Withdraw the acknowledgement of an incident so repeat notifications resume
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param id path int true "Alert History ID"
+// @Param request body IncidentActionRequest false "User and comment"
+// @Success 200 {object} APIResponse{data=models.AlertHistory}
+// @Failure 404 {object} APIResponse
+// @Failure 409 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/history/{id}/unack [post]
+func (h *AlertHandler) UnacknowledgeIncident(c *gin.Context) {
+	id, req, ok := h.bindIncidentAction(c)
+	if !ok {
+		return
+	}
+
+	incident, err := h.alertService.UnacknowledgeIncident(id, req.User, req.Comment)
+	if err != nil {
+		respondIncidentError(c, "Failed to unacknowledge incident", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    incident,
+		Message: "Incident unacknowledged successfully",
+	})
+}
+
+// AssignIncident assigns an incident
+// @Summary Assign incident
+// @Description Set or clear the assignee of an incident
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param id path int true "Alert History ID"
+// @Param request body AssignIncidentRequest true "Assignee"
+// @Success 200 {object} APIResponse{data=models.AlertHistory}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/history/{id}/assign [post]
+func (h *AlertHandler) AssignIncident(c *gin.Context) {
+	id, ok := parseHistoryID(c)
+	if !ok {
+		return
+	}
+
+	var req AssignIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if h.alertService == nil {
+		respondAlertServiceUnavailable(c)
+		return
+	}
+
+	incident, err := h.alertService.AssignIncident(id, req.User, req.Assignee, req.Comment)
+	if err != nil {
+		respondIncidentError(c, "Failed to assign incident", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    incident,
+		Message: "Incident assigned successfully",
+	})
+}
+
+// CommentIncident adds a comment to an incident
+// @Summary Comment on incident
+// @Description Add a comment to the timeline of an incident
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param id path int true "Alert History ID"
+// @Param request body IncidentActionRequest true "User and comment"
+// @Success 201 {object} APIResponse{data=models.IncidentEvent}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/alerts/history/{id}/comments [post]
+func (h *AlertHandler) CommentIncident(c *gin.Context) {
+	id, req, ok := h.bindIncidentAction(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.alertService.CommentIncident(id, req.User, req.Comment)
+	if err != nil {
+		respondIncidentError(c, "Failed to comment on incident", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Data:    event,
+		Message: "Comment added successfully",
+	})
+}
+
+// bindIncidentAction parses the history ID and the optional action body, writing an error
+// response on failure
+func (h *AlertHandler) bindIncidentAction(c *gin.Context) (uint, IncidentActionRequest, bool) {
+	var req IncidentActionRequest
+
+	id, ok := parseHistoryID(c)
+	if !ok {
+		return 0, req, false
+	}
+
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   "Invalid request format",
+				Message: err.Error(),
+			})
+			return 0, req, false
+		}
+	}
+
+	if h.alertService == nil {
+		respondAlertServiceUnavailable(c)
+		return 0, req, false
+	}
+
+	return id, req, true
+}
+
+// parseHistoryID parses the alert history ID parameter, writing an error response on failure
+func parseHistoryID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid alert history ID",
+			Message: "Alert history ID must be a valid number",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondIncidentError writes the response for a failed incident operation
+func respondIncidentError(c *gin.Context, message string, err error) {
+	statusCode := http.StatusInternalServerError
+	switch err.Error() {
+	case "alert history not found":
+		statusCode = http.StatusNotFound
+	case "incident is already resolved", "incident is already acknowledged", "incident is not acknowledged":
+		statusCode = http.StatusConflict
+	case "comment is required":
+		statusCode = http.StatusBadRequest
+	}
+
+	c.JSON(statusCode, APIResponse{
+		Success: false,
+		Error:   message,
+		Message: err.Error(),
+	})
+}
+
+// respondAlertServiceUnavailable writes the response for requests that need the alert service
+func respondAlertServiceUnavailable(c *gin.Context) {
+	c.JSON(http.StatusServiceUnavailable, APIResponse{
+		Success: false,
+		Error:   "Alert service unavailable",
+		Message: "Alert service is not initialized",
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -881,6 +882,94 @@ func (h *MetricsHandler) GetTopProcesses(c *gin.Context) {
 	})
 }
 
+// GetProcessDetails gets detailed information about a single process
+// @Summary Get process details
+// @Description Get parent/children, command line, user, start time, open files, connections, threads, cgroup, environment size and I/O counters of a process
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param pid path int true "Process ID"
+// @Success 200 {object} APIResponse{data=models.ProcessDetails}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/processes/{pid} [get]
+func (h *MetricsHandler) GetProcessDetails(c *gin.Context) {
+	if h.systemCollector == nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "System collector not available",
+			Message: "System collector is not initialized",
+		})
+		return
+	}
+
+	pid, err := strconv.ParseInt(c.Param("pid"), 10, 32)
+	if err != nil || pid < 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid process ID",
+			Message: "Process ID must be a valid number",
+		})
+		return
+	}
+
+	details, err := h.systemCollector.GetProcessDetails(int32(pid))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, collector.ErrProcessNotFound) {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to get process details",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    details,
+	})
+}
+
+// GetProcessTree gets the process hierarchy with aggregated usage per subtree
+// @Summary Get process tree
+// @Description Get the process hierarchy with aggregated CPU and memory usage per subtree
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]models.ProcessTreeNode}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/processes/tree [get]
+func (h *MetricsHandler) GetProcessTree(c *gin.Context) {
+	if h.systemCollector == nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "System collector not available",
+			Message: "System collector is not initialized",
+		})
+		return
+	}
+
+	tree, err := h.systemCollector.GetProcessTree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to get process tree",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    tree,
+	})
+}
+
 // CreateMetric creates a new metric entry (for manual insertion)
 // @Summary Create metric
 // @Description Create a new metric entry
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/eyzaun/godash/internal/collector"
 	"github.com/eyzaun/godash/internal/models"
 )
 
@@ -165,6 +166,22 @@ func (m *MockSystemCollector) GetTopProcesses(count int, sortBy string) ([]model
 	return args.Get(0).([]models.ProcessInfo), args.Error(1)
 }
 
+func (m *MockSystemCollector) GetProcessDetails(pid int32) (*models.ProcessDetails, error) {
+	args := m.Called(pid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProcessDetails), args.Error(1)
+}
+
+func (m *MockSystemCollector) GetProcessTree() ([]*models.ProcessTreeNode, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ProcessTreeNode), args.Error(1)
+}
+
 func (m *MockSystemCollector) IsHealthy() (bool, []string, error) {
 	args := m.Called()
 	return args.Get(0).(bool), args.Get(1).([]string), args.Error(2)
@@ -191,6 +208,8 @@ func setupTestRouter() (*gin.Engine, *MockMetricsRepository, *MockSystemCollecto
 		v1.GET("/metrics/trends/:hostname", handler.GetUsageTrends)
 		v1.GET("/metrics/top/:type", handler.GetTopHostsByUsage)
 		v1.POST("/metrics", handler.CreateMetric)
+		v1.GET("/processes/tree", handler.GetProcessTree)
+		v1.GET("/processes/:pid", handler.GetProcessDetails)
 		v1.GET("/system/status", handler.GetSystemStatus)
 		v1.GET("/system/hosts", handler.GetHosts)
 		v1.GET("/system/stats", handler.GetStats)
@@ -440,3 +459,94 @@ func TestGetStats_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockCollector.AssertExpectations(t)
 }
+
+func TestGetProcessDetails_Success(t *testing.T) {
+	router, mockRepo, mockCollector := setupTestRouter()
+
+	expectedDetails := &models.ProcessDetails{
+		PID:      1234,
+		PPID:     1,
+		Name:     "test-process",
+		Cmdline:  "/usr/bin/test-process --flag",
+		Username: "root",
+		Parent:   &models.ProcessInfo{PID: 1, Name: "init"},
+	}
+	mockCollector.On("GetProcessDetails", int32(1234)).Return(expectedDetails, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/processes/1234", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+
+	mockRepo.AssertExpectations(t)
+	mockCollector.AssertExpectations(t)
+}
+
+func TestGetProcessDetails_NotFound(t *testing.T) {
+	router, mockRepo, mockCollector := setupTestRouter()
+
+	mockCollector.On("GetProcessDetails", int32(99999)).Return(nil, collector.ErrProcessNotFound)
+
+	req, _ := http.NewRequest("GET", "/api/v1/processes/99999", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockRepo.AssertExpectations(t)
+	mockCollector.AssertExpectations(t)
+}
+
+func TestGetProcessDetails_Error(t *testing.T) {
+	router, mockRepo, mockCollector := setupTestRouter()
+
+	// Other failures, even mentioning a missing process, are server errors
+	mockCollector.On("GetProcessDetails", int32(1234)).Return(nil, fmt.Errorf("failed to open process 1234: process not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/processes/1234", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	mockRepo.AssertExpectations(t)
+	mockCollector.AssertExpectations(t)
+}
+
+func TestGetProcessTree_Success(t *testing.T) {
+	router, mockRepo, mockCollector := setupTestRouter()
+
+	tree := []*models.ProcessTreeNode{
+		{
+			PID:              1,
+			Name:             "init",
+			TotalCPUPercent:  3.5,
+			TotalMemoryBytes: 2048,
+			ProcessCount:     2,
+			Children: []*models.ProcessTreeNode{
+				{PID: 1234, PPID: 1, Name: "test-process", CPUPercent: 3.5, MemoryBytes: 1024},
+			},
+		},
+	}
+	mockCollector.On("GetProcessTree").Return(tree, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/processes/tree", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+
+	mockRepo.AssertExpectations(t)
+	mockCollector.AssertExpectations(t)
+}
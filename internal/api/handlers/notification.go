@@ -0,0 +1,838 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+	"github.com/eyzaun/godash/internal/services"
+)
+
+// RedactedSecret replaces the signing secret and credentials of notification channels in
+// responses. Updates sending it back, or leaving them empty, keep the stored values.
+const RedactedSecret = "********"
+
+// NotificationHandler handles HTTP requests for notification channels and routes
+type NotificationHandler struct {
+	notificationRepo repository.NotificationRepository
+	alertService     *services.AlertService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationRepo repository.NotificationRepository, alertService *services.AlertService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationRepo: notificationRepo,
+		alertService:     alertService,
+	}
+}
+
+// CreateChannelRequest represents the request body for creating notification channels
+type CreateChannelRequest struct {
+	Name    string                           `json:"name" binding:"required"`
+	Type    string                           `json:"type" binding:"required"`
+	Config  models.NotificationChannelConfig `json:"config"`
+	Enabled *bool                            `json:"enabled"`
+}
+
+// UpdateChannelRequest represents the request body for updating notification channels
+type UpdateChannelRequest struct {
+	Name    *string                           `json:"name"`
+	Type    *string                           `json:"type"`
+	Config  *models.NotificationChannelConfig `json:"config"`
+	Enabled *bool                             `json:"enabled"`
+}
+
+// PreviewTemplateRequest represents the request body for previewing message templates
+type PreviewTemplateRequest struct {
+	ChannelType string                   `json:"channel_type" binding:"required"`
+	Templates   *models.MessageTemplates `json:"templates"` // Omit to preview the saved templates
+	AlertID     uint                     `json:"alert_id"`  // Alert of the sample incident (0 = a sample CPU alert)
+	Hostname    string                   `json:"hostname"`  // Host of the sample incident (empty = sample-host)
+	Resolved    bool                     `json:"resolved"`  // Preview the resolution notification
+}
+
+// CreateRouteRequest represents the request body for creating notification routes
+type CreateRouteRequest struct {
+	Name       string                `json:"name"`
+	Position   int                   `json:"position"`
+	Matchers   []models.AlertMatcher `json:"matchers"`
+	ChannelIDs []uint                `json:"channel_ids" binding:"required"`
+	Continue   bool                  `json:"continue"`
+	Enabled    *bool                 `json:"enabled"`
+
+	GroupBy       []string `json:"group_by"`
+	GroupWait     int      `json:"group_wait"`
+	GroupInterval int      `json:"group_interval"`
+}
+
+// UpdateRouteRequest represents the request body for updating notification routes
+type UpdateRouteRequest struct {
+	Name       *string               `json:"name"`
+	Position   *int                  `json:"position"`
+	Matchers   []models.AlertMatcher `json:"matchers"`
+	ChannelIDs []uint                `json:"channel_ids"`
+	Continue   *bool                 `json:"continue"`
+	Enabled    *bool                 `json:"enabled"`
+
+	GroupBy       []string `json:"group_by"`
+	GroupWait     *int     `json:"group_wait"`
+	GroupInterval *int     `json:"group_interval"`
+}
+
+// CreateChannel creates a new notification channel
+// @Summary Create notification channel
+// @Description Create an email, webhook, Slack or Discord notification channel
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param channel body CreateChannelRequest true "Notification channel"
+// @Success 201 {object} APIResponse{data=models.NotificationChannel}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-channels [post]
+func (h *NotificationHandler) CreateChannel(c *gin.Context) {
+	var req CreateChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	channel := &models.NotificationChannel{
+		Name:    req.Name,
+		Type:    req.Type,
+		Config:  req.Config,
+		Enabled: true,
+	}
+	if req.Enabled != nil {
+		channel.Enabled = *req.Enabled
+	}
+
+	if err := services.ValidateNotificationChannel(channel); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.notificationRepo.CreateChannel(channel); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to create notification channel",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Data:    redactChannel(channel),
+		Message: "Notification channel created successfully",
+	})
+}
+
+// GetChannels retrieves all notification channels
+// @Summary Get notification channels
+// @Description Retrieve all notification channels
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]models.NotificationChannel}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-channels [get]
+func (h *NotificationHandler) GetChannels(c *gin.Context) {
+	channels, err := h.notificationRepo.GetChannels()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve notification channels",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	redacted := make([]*models.NotificationChannel, len(channels))
+	for i, channel := range channels {
+		redacted[i] = redactChannel(channel)
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    redacted,
+	})
+}
+
+// GetChannel retrieves a specific notification channel by ID
+// @Summary Get notification channel by ID
+// @Description Retrieve a specific notification channel by ID
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification Channel ID"
+// @Success 200 {object} APIResponse{data=models.NotificationChannel}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-channels/{id} [get]
+func (h *NotificationHandler) GetChannel(c *gin.Context) {
+	channel, ok := h.loadChannel(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    redactChannel(channel),
+	})
+}
+
+// UpdateChannel updates an existing notification channel
+// @Summary Update notification channel
+// @Description Update an existing notification channel; every route using it picks up the change. A secret, password or token left empty or redacted keeps its stored value.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Notification Channel ID"
+// @Param channel body UpdateChannelRequest true "Notification channel updates"
+// @Success 200 {object} APIResponse{data=models.NotificationChannel}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-channels/{id} [put]
+func (h *NotificationHandler) UpdateChannel(c *gin.Context) {
+	channel, ok := h.loadChannel(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Update fields
+	if req.Name != nil {
+		channel.Name = *req.Name
+	}
+	if req.Type != nil {
+		channel.Type = *req.Type
+	}
+	if req.Config != nil {
+		keepChannelSecrets(req.Config, channel.Config)
+		channel.Config = *req.Config
+	}
+	if req.Enabled != nil {
+		channel.Enabled = *req.Enabled
+	}
+
+	if err := services.ValidateNotificationChannel(channel); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.notificationRepo.UpdateChannel(channel); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to update notification channel",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    redactChannel(channel),
+		Message: "Notification channel updated successfully",
+	})
+}
+
+// DeleteChannel deletes a notification channel
+// @Summary Delete notification channel
+// @Description Delete a notification channel
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification Channel ID"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-channels/{id} [delete]
+func (h *NotificationHandler) DeleteChannel(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid notification channel ID",
+			Message: "Notification channel ID must be a valid number",
+		})
+		return
+	}
+
+	if err := h.notificationRepo.DeleteChannel(uint(id)); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "notification channel not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to delete notification channel",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Notification channel deleted successfully",
+	})
+}
+
+// TestChannel sends a test notification to a channel
+// @Summary Test notification channel
+// @Description Send a sample alert to a notification channel
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification Channel ID"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Failure 503 {object} APIResponse
+// @Router /api/v1/notification-channels/{id}/test [post]
+func (h *NotificationHandler) TestChannel(c *gin.Context) {
+	channel, ok := h.loadChannel(c)
+	if !ok {
+		return
+	}
+
+	if h.alertService == nil {
+		respondAlertServiceUnavailable(c)
+		return
+	}
+
+	if err := h.alertService.SendTestNotification(channel); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to send test notification",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Test notification sent successfully",
+	})
+}
+
+// CreateRoute creates a new notification route
+// @Summary Create notification route
+// @Description Route alerts matching severity, hostname, alert name or label matchers to channels
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param route body CreateRouteRequest true "Notification route"
+// @Success 201 {object} APIResponse{data=models.NotificationRoute}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-routes [post]
+func (h *NotificationHandler) CreateRoute(c *gin.Context) {
+	var req CreateRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	route := &models.NotificationRoute{
+		Name:       req.Name,
+		Position:   req.Position,
+		Matchers:   req.Matchers,
+		ChannelIDs: req.ChannelIDs,
+		Continue:   req.Continue,
+		Enabled:    true,
+
+		GroupBy:       req.GroupBy,
+		GroupWait:     req.GroupWait,
+		GroupInterval: req.GroupInterval,
+	}
+	if req.Enabled != nil {
+		route.Enabled = *req.Enabled
+	}
+
+	if !h.validateRoute(c, route) {
+		return
+	}
+
+	if err := h.notificationRepo.CreateRoute(route); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to create notification route",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Data:    route,
+		Message: "Notification route created successfully",
+	})
+}
+
+// GetRoutes retrieves all notification routes
+// @Summary Get notification routes
+// @Description Retrieve all notification routes in evaluation order
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]models.NotificationRoute}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-routes [get]
+func (h *NotificationHandler) GetRoutes(c *gin.Context) {
+	routes, err := h.notificationRepo.GetRoutes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve notification routes",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    routes,
+	})
+}
+
+// UpdateRoute updates an existing notification route
+// @Summary Update notification route
+// @Description Update an existing notification route
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Notification Route ID"
+// @Param route body UpdateRouteRequest true "Notification route updates"
+// @Success 200 {object} APIResponse{data=models.NotificationRoute}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-routes/{id} [put]
+func (h *NotificationHandler) UpdateRoute(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid notification route ID",
+			Message: "Notification route ID must be a valid number",
+		})
+		return
+	}
+
+	route, err := h.notificationRepo.GetRouteByID(uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "notification route not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve notification route",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req UpdateRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Update fields
+	if req.Name != nil {
+		route.Name = *req.Name
+	}
+	if req.Position != nil {
+		route.Position = *req.Position
+	}
+	if req.Matchers != nil {
+		route.Matchers = req.Matchers
+	}
+	if req.ChannelIDs != nil {
+		route.ChannelIDs = req.ChannelIDs
+	}
+	if req.Continue != nil {
+		route.Continue = *req.Continue
+	}
+	if req.Enabled != nil {
+		route.Enabled = *req.Enabled
+	}
+	if req.GroupBy != nil {
+		route.GroupBy = req.GroupBy
+	}
+	if req.GroupWait != nil {
+		route.GroupWait = *req.GroupWait
+	}
+	if req.GroupInterval != nil {
+		route.GroupInterval = *req.GroupInterval
+	}
+
+	if !h.validateRoute(c, route) {
+		return
+	}
+
+	if err := h.notificationRepo.UpdateRoute(route); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to update notification route",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    route,
+		Message: "Notification route updated successfully",
+	})
+}
+
+// DeleteRoute deletes a notification route
+// @Summary Delete notification route
+// @Description Delete a notification route
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification Route ID"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-routes/{id} [delete]
+func (h *NotificationHandler) DeleteRoute(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid notification route ID",
+			Message: "Notification route ID must be a valid number",
+		})
+		return
+	}
+
+	if err := h.notificationRepo.DeleteRoute(uint(id)); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "notification route not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to delete notification route",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Notification route deleted successfully",
+	})
+}
+
+// GetTemplates retrieves the message templates of all channel types
+// @Summary Get notification templates
+// @Description Retrieve the message templates of every channel type that has any
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]models.NotificationTemplate}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-templates [get]
+func (h *NotificationHandler) GetTemplates(c *gin.Context) {
+	templates, err := h.notificationRepo.GetTemplates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve notification templates",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    templates,
+	})
+}
+
+// SaveTemplate creates or replaces the message templates of a channel type
+// @Summary Save notification template
+// @Description Set the Go templates of the subject, text, HTML and JSON body of a channel type (email, webhook, slack or discord); empty templates keep the built-in layout
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param type path string true "Channel type"
+// @Param template body models.MessageTemplates true "Message templates"
+// @Success 200 {object} APIResponse{data=models.NotificationTemplate}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-templates/{type} [put]
+func (h *NotificationHandler) SaveTemplate(c *gin.Context) {
+	var templates models.MessageTemplates
+	if err := c.ShouldBindJSON(&templates); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	channelType := strings.ToLower(c.Param("type"))
+	if err := services.ValidateMessageTemplates(channelType, &templates); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	template := &models.NotificationTemplate{ChannelType: channelType, MessageTemplates: templates}
+	if err := h.notificationRepo.SaveTemplate(template); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to save notification template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    template,
+		Message: "Notification template saved successfully",
+	})
+}
+
+// DeleteTemplate deletes the message templates of a channel type
+// @Summary Delete notification template
+// @Description Delete the message templates of a channel type, restoring the built-in layout
+// @Tags notifications
+// @Produce json
+// @Param type path string true "Channel type"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/notification-templates/{type} [delete]
+func (h *NotificationHandler) DeleteTemplate(c *gin.Context) {
+	if err := h.notificationRepo.DeleteTemplate(strings.ToLower(c.Param("type"))); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "notification template not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to delete notification template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadRouting()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Notification template deleted successfully",
+	})
+}
+
+// PreviewTemplate renders message templates against a sample incident
+// @Summary Preview notification template
+// @Description Render message templates of a channel type against a sample incident of an alert, or of a sample CPU alert. Without templates, the saved templates of the channel type and alert are rendered.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param preview body PreviewTemplateRequest true "Templates to preview"
+// @Success 200 {object} APIResponse{data=services.NotificationMessage}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 503 {object} APIResponse
+// @Router /api/v1/notification-templates/preview [post]
+func (h *NotificationHandler) PreviewTemplate(c *gin.Context) {
+	var req PreviewTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if h.alertService == nil {
+		respondAlertServiceUnavailable(c)
+		return
+	}
+
+	message, err := h.alertService.PreviewNotification(strings.ToLower(req.ChannelType), req.Templates, req.AlertID, req.Hostname, req.Resolved)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if err.Error() == "alert not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to preview notification template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    message,
+	})
+}
+
+// validateRoute validates a route and checks that its channels exist, writing an error
+// response on failure
+func (h *NotificationHandler) validateRoute(c *gin.Context, route *models.NotificationRoute) bool {
+	if err := services.ValidateNotificationRoute(route); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return false
+	}
+
+	for _, id := range route.ChannelIDs {
+		if _, err := h.notificationRepo.GetChannelByID(id); err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   "Validation failed",
+				Message: "channel " + strconv.FormatUint(uint64(id), 10) + ": " + err.Error(),
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// loadChannel parses the channel ID parameter and loads the channel, writing an error response on failure
+func (h *NotificationHandler) loadChannel(c *gin.Context) (*models.NotificationChannel, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid notification channel ID",
+			Message: "Notification channel ID must be a valid number",
+		})
+		return nil, false
+	}
+
+	channel, err := h.notificationRepo.GetChannelByID(uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "notification channel not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve notification channel",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	return channel, true
+}
+
+// redactChannel returns a copy of a channel with its signing secret and credentials replaced
+// by RedactedSecret
+func redactChannel(channel *models.NotificationChannel) *models.NotificationChannel {
+	redacted := *channel
+	if redacted.Config.Secret != "" {
+		redacted.Config.Secret = RedactedSecret
+	}
+	if channel.Config.Auth != nil {
+		auth := *channel.Config.Auth
+		if auth.Password != "" {
+			auth.Password = RedactedSecret
+		}
+		if auth.Token != "" {
+			auth.Token = RedactedSecret
+		}
+		redacted.Config.Auth = &auth
+	}
+	return &redacted
+}
+
+// keepChannelSecrets fills the signing secret and credentials an update left empty or
+// redacted with the stored ones. Removing auth drops its credentials.
+func keepChannelSecrets(config *models.NotificationChannelConfig, stored models.NotificationChannelConfig) {
+	config.Secret = keepSecret(config.Secret, stored.Secret)
+	if config.Auth == nil {
+		return
+	}
+	storedAuth := models.WebhookAuth{}
+	if stored.Auth != nil {
+		storedAuth = *stored.Auth
+	}
+	config.Auth.Password = keepSecret(config.Auth.Password, storedAuth.Password)
+	config.Auth.Token = keepSecret(config.Auth.Token, storedAuth.Token)
+}
+
+// keepSecret returns the stored value of a secret the request left empty or redacted
+func keepSecret(value, stored string) string {
+	if value == "" || value == RedactedSecret {
+		return stored
+	}
+	return value
+}
+
+// reloadRouting tells the alert service to pick up channel and route changes
+func (h *NotificationHandler) reloadRouting() {
+	if h.alertService != nil {
+		h.alertService.ReloadNotificationRouting()
+	}
+}
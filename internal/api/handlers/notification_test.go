@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// channelRepo keeps notification channels in memory
+type channelRepo struct {
+	repository.NotificationRepository
+	channels map[uint]*models.NotificationChannel
+	nextID   uint
+}
+
+func (r *channelRepo) CreateChannel(channel *models.NotificationChannel) error {
+	r.nextID++
+	channel.ID = r.nextID
+	stored := *channel
+	r.channels[channel.ID] = &stored
+	return nil
+}
+
+func (r *channelRepo) GetChannelByID(id uint) (*models.NotificationChannel, error) {
+	channel, exists := r.channels[id]
+	if !exists {
+		return nil, fmt.Errorf("notification channel not found")
+	}
+	copied := *channel
+	return &copied, nil
+}
+
+func (r *channelRepo) GetChannels() ([]*models.NotificationChannel, error) {
+	var channels []*models.NotificationChannel
+	for _, channel := range r.channels {
+		copied := *channel
+		channels = append(channels, &copied)
+	}
+	return channels, nil
+}
+
+func (r *channelRepo) UpdateChannel(channel *models.NotificationChannel) error {
+	stored := *channel
+	r.channels[channel.ID] = &stored
+	return nil
+}
+
+func setupChannelRouter(repo *channelRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewNotificationHandler(repo, nil)
+	router := gin.New()
+	router.POST("/api/v1/notification-channels", handler.CreateChannel)
+	router.GET("/api/v1/notification-channels", handler.GetChannels)
+	router.GET("/api/v1/notification-channels/:id", handler.GetChannel)
+	router.PUT("/api/v1/notification-channels/:id", handler.UpdateChannel)
+	return router
+}
+
+func requestChannel(t *testing.T, router *gin.Engine, method, path, body string) models.NotificationChannelConfig {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	require.Less(t, w.Code, 300, w.Body.String())
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if strings.HasPrefix(string(response.Data), "[") {
+		var channels []models.NotificationChannel
+		require.NoError(t, json.Unmarshal(response.Data, &channels))
+		require.Len(t, channels, 1)
+		return channels[0].Config
+	}
+	var channel models.NotificationChannel
+	require.NoError(t, json.Unmarshal(response.Data, &channel))
+	return channel.Config
+}
+
+func TestNotificationHandler_RedactsChannelSecrets(t *testing.T) {
+	repo := &channelRepo{channels: make(map[uint]*models.NotificationChannel)}
+	router := setupChannelRouter(repo)
+
+	configs := []models.NotificationChannelConfig{
+		requestChannel(t, router, http.MethodPost, "/api/v1/notification-channels",
+			`{"name": "hook", "type": "webhook", "config": {"url": "https://example.com/hook", "secret": "s3cret", "auth": {"type": "basic", "username": "ops", "password": "hunter2"}}}`),
+		requestChannel(t, router, http.MethodGet, "/api/v1/notification-channels", ""),
+		requestChannel(t, router, http.MethodGet, "/api/v1/notification-channels/1", ""),
+		requestChannel(t, router, http.MethodPut, "/api/v1/notification-channels/1", `{"name": "renamed"}`),
+	}
+	for _, config := range configs {
+		assert.Equal(t, RedactedSecret, config.Secret)
+		require.NotNil(t, config.Auth)
+		assert.Equal(t, "ops", config.Auth.Username)
+		assert.Equal(t, RedactedSecret, config.Auth.Password)
+	}
+
+	// The stored channel keeps the real values
+	assert.Equal(t, "s3cret", repo.channels[1].Config.Secret)
+	assert.Equal(t, "hunter2", repo.channels[1].Config.Auth.Password)
+}
+
+func TestNotificationHandler_UpdateChannelKeepsSecrets(t *testing.T) {
+	repo := &channelRepo{channels: map[uint]*models.NotificationChannel{
+		1: {BaseModel: models.BaseModel{ID: 1}, Name: "hook", Type: "webhook", Enabled: true, Config: models.NotificationChannelConfig{
+			URL:    "https://example.com/hook",
+			Secret: "s3cret",
+			Auth:   &models.WebhookAuth{Type: "bearer", Token: "t0ken"},
+		}},
+	}}
+	router := setupChannelRouter(repo)
+
+	// Sending back the redacted config, or leaving the secrets empty, keeps them
+	requestChannel(t, router, http.MethodPut, "/api/v1/notification-channels/1",
+		`{"config": {"url": "https://example.com/new", "secret": "********", "auth": {"type": "bearer", "token": "********"}}}`)
+	stored := repo.channels[1].Config
+	assert.Equal(t, "https://example.com/new", stored.URL)
+	assert.Equal(t, "s3cret", stored.Secret)
+	assert.Equal(t, "t0ken", stored.Auth.Token)
+
+	requestChannel(t, router, http.MethodPut, "/api/v1/notification-channels/1",
+		`{"config": {"url": "https://example.com/new", "auth": {"type": "bearer"}}}`)
+	stored = repo.channels[1].Config
+	assert.Equal(t, "s3cret", stored.Secret)
+	assert.Equal(t, "t0ken", stored.Auth.Token)
+
+	// New values replace them
+	requestChannel(t, router, http.MethodPut, "/api/v1/notification-channels/1",
+		`{"config": {"url": "https://example.com/new", "secret": "rotated", "auth": {"type": "bearer", "token": "new-token"}}}`)
+	stored = repo.channels[1].Config
+	assert.Equal(t, "rotated", stored.Secret)
+	assert.Equal(t, "new-token", stored.Auth.Token)
+
+	// A redacted value with nothing stored is not saved as a credential
+	requestChannel(t, router, http.MethodPut, "/api/v1/notification-channels/1",
+		`{"config": {"url": "https://example.com/new", "auth": {"type": "basic", "username": "ops", "password": "********"}}}`)
+	stored = repo.channels[1].Config
+	assert.Equal(t, "ops", stored.Auth.Username)
+	assert.Empty(t, stored.Auth.Password)
+	assert.Empty(t, stored.Auth.Token)
+}
@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+	"github.com/eyzaun/godash/internal/services"
+)
+
+// ProbeHandler handles HTTP requests for synthetic probes
+type ProbeHandler struct {
+	probeRepo    repository.ProbeRepository
+	probeService *services.ProbeService
+}
+
+// NewProbeHandler creates a new probe handler
+func NewProbeHandler(probeRepo repository.ProbeRepository, probeService *services.ProbeService) *ProbeHandler {
+	return &ProbeHandler{
+		probeRepo:    probeRepo,
+		probeService: probeService,
+	}
+}
+
+// CreateProbeRequest represents the request body for creating probes
+type CreateProbeRequest struct {
+	Name           string `json:"name" binding:"required"`
+	Type           string `json:"type" binding:"required"`
+	Target         string `json:"target" binding:"required"`
+	Interval       int    `json:"interval"`
+	Timeout        int    `json:"timeout"`
+	Enabled        *bool  `json:"enabled"`
+	ExpectedStatus int    `json:"expected_status"`
+	BodyMatch      string `json:"body_match"`
+	TLSExpiryDays  int    `json:"tls_expiry_days"`
+	SkipTLSVerify  bool   `json:"skip_tls_verify"`
+}
+
+// UpdateProbeRequest represents the request body for updating probes
+type UpdateProbeRequest struct {
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	Target         string  `json:"target"`
+	Interval       int     `json:"interval"`
+	Timeout        int     `json:"timeout"`
+	Enabled        *bool   `json:"enabled"`
+	ExpectedStatus *int    `json:"expected_status"`
+	BodyMatch      *string `json:"body_match"`
+	TLSExpiryDays  *int    `json:"tls_expiry_days"`
+	SkipTLSVerify  *bool   `json:"skip_tls_verify"`
+}
+
+// ProbeResultsSummary summarizes probe results over a time range
+type ProbeResultsSummary struct {
+	Count        int     `json:"count"`
+	SuccessRate  float64 `json:"success_rate"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+}
+
+// CreateProbe creates a new probe
+// @Summary Create probe
+// @Description Create a new synthetic HTTP, TCP or DNS probe
+// @Tags probes
+// @Accept json
+// @Produce json
+// @Param probe body CreateProbeRequest true "Probe configuration"
+// @Success 201 {object} APIResponse{data=models.Probe}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/probes [post]
+func (h *ProbeHandler) CreateProbe(c *gin.Context) {
+	var req CreateProbeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	probe := &models.Probe{
+		Name:           req.Name,
+		Type:           strings.ToLower(req.Type),
+		Target:         strings.TrimSpace(req.Target),
+		Interval:       req.Interval,
+		Timeout:        req.Timeout,
+		Enabled:        true,
+		ExpectedStatus: req.ExpectedStatus,
+		BodyMatch:      req.BodyMatch,
+		TLSExpiryDays:  req.TLSExpiryDays,
+		SkipTLSVerify:  req.SkipTLSVerify,
+	}
+	if req.Enabled != nil {
+		probe.Enabled = *req.Enabled
+	}
+
+	if err := services.ValidateProbe(probe); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.probeRepo.CreateProbe(probe); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to create probe",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadProbes()
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Data:    probe,
+		Message: "Probe created successfully",
+	})
+}
+
+// GetProbes retrieves all probes
+// @Summary Get probes
+// @Description Retrieve all probes with their last check status
+// @Tags probes
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]models.Probe}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/probes [get]
+func (h *ProbeHandler) GetProbes(c *gin.Context) {
+	probes, err := h.probeRepo.GetProbes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve probes",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    probes,
+	})
+}
+
+// GetProbe retrieves a specific probe by ID
+// @Summary Get probe by ID
+// @Description Retrieve a specific probe by ID
+// @Tags probes
+// @Produce json
+// @Param id path int true "Probe ID"
+// @Success 200 {object} APIResponse{data=models.Probe}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/probes/{id} [get]
+func (h *ProbeHandler) GetProbe(c *gin.Context) {
+	probe, ok := h.loadProbe(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    probe,
+	})
+}
+
+// UpdateProbe updates an existing probe
+// @Summary Update probe
+// @Description Update an existing probe configuration
+// @Tags probes
+// @Accept json
+// @Produce json
+// @Param id path int true "Probe ID"
+// @Param probe body UpdateProbeRequest true "Probe configuration updates"
+// @Success 200 {object} APIResponse{data=models.Probe}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/probes/{id} [put]
+func (h *ProbeHandler) UpdateProbe(c *gin.Context) {
+	probe, ok := h.loadProbe(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateProbeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Update fields
+	if req.Name != "" {
+		probe.Name = req.Name
+	}
+	if req.Type != "" {
+		probe.Type = strings.ToLower(req.Type)
+	}
+	if req.Target != "" {
+		probe.Target = strings.TrimSpace(req.Target)
+	}
+	if req.Interval != 0 {
+		probe.Interval = req.Interval
+	}
+	if req.Timeout != 0 {
+		probe.Timeout = req.Timeout
+	}
+	if req.Enabled != nil {
+		probe.Enabled = *req.Enabled
+	}
+	if req.ExpectedStatus != nil {
+		probe.ExpectedStatus = *req.ExpectedStatus
+	}
+	if req.BodyMatch != nil {
+		probe.BodyMatch = *req.BodyMatch
+	}
+	if req.TLSExpiryDays != nil {
+		probe.TLSExpiryDays = *req.TLSExpiryDays
+	}
+	if req.SkipTLSVerify != nil {
+		probe.SkipTLSVerify = *req.SkipTLSVerify
+	}
+
+	if err := services.ValidateProbe(probe); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.probeRepo.UpdateProbe(probe); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to update probe",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadProbes()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    probe,
+		Message: "Probe updated successfully",
+	})
+}
+
+// DeleteProbe deletes a probe and its results
+// @Summary Delete probe
+// @Description Delete a probe and its recorded results
+// @Tags probes
+// @Produce json
+// @Param id path int true "Probe ID"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/probes/{id} [delete]
+func (h *ProbeHandler) DeleteProbe(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid probe ID",
+			Message: "Probe ID must be a valid number",
+		})
+		return
+	}
+
+	if err := h.probeRepo.DeleteProbe(uint(id)); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "probe not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to delete probe",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadProbes()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Probe deleted successfully",
+	})
+}
+
+// GetProbeResults retrieves recent results for a probe
+// @Summary Get probe results
+// @Description Retrieve latency and success history for a probe
+// @Tags probes
+// @Produce json
+// @Param id path int true "Probe ID"
+// @Param hours query int false "Hours of history" default(24)
+// @Param limit query int false "Maximum number of results" default(500)
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/probes/{id}/results [get]
+func (h *ProbeHandler) GetProbeResults(c *gin.Context) {
+	probe, ok := h.loadProbe(c)
+	if !ok {
+		return
+	}
+
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours <= 0 {
+		hours = 24
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if err != nil || limit <= 0 {
+		limit = 500
+	}
+
+	results, err := h.probeRepo.GetProbeResults(probe.ID, time.Now().Add(-time.Duration(hours)*time.Hour), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve probe results",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"probe":   probe,
+			"results": results,
+			"summary": summarizeProbeResults(results),
+		},
+	})
+}
+
+// RunProbe runs a probe immediately
+// @Summary Run probe
+// @Description Run a probe immediately and return the result
+// @Tags probes
+// @Produce json
+// @Param id path int true "Probe ID"
+// @Success 200 {object} APIResponse{data=models.ProbeResult}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/probes/{id}/run [post]
+func (h *ProbeHandler) RunProbe(c *gin.Context) {
+	probe, ok := h.loadProbe(c)
+	if !ok {
+		return
+	}
+
+	var result *models.ProbeResult
+	if h.probeService != nil {
+		result = h.probeService.RunNow(probe)
+	} else {
+		result = services.RunProbe(probe)
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// loadProbe parses the probe ID parameter and loads the probe, writing an error response on failure
+func (h *ProbeHandler) loadProbe(c *gin.Context) (*models.Probe, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid probe ID",
+			Message: "Probe ID must be a valid number",
+		})
+		return nil, false
+	}
+
+	probe, err := h.probeRepo.GetProbeByID(uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "probe not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve probe",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	return probe, true
+}
+
+// reloadProbes tells the probe scheduler to pick up configuration changes
+func (h *ProbeHandler) reloadProbes() {
+	if h.probeService != nil {
+		h.probeService.Reload()
+	}
+}
+
+// summarizeProbeResults calculates success rate and latency statistics
+func summarizeProbeResults(results []*models.ProbeResult) ProbeResultsSummary {
+	summary := ProbeResultsSummary{Count: len(results)}
+	if len(results) == 0 {
+		return summary
+	}
+
+	var successes int
+	var totalLatency float64
+	for _, result := range results {
+		if result.Success {
+			successes++
+		}
+		totalLatency += result.LatencyMs
+		if result.LatencyMs > summary.MaxLatencyMs {
+			summary.MaxLatencyMs = result.LatencyMs
+		}
+	}
+
+	summary.SuccessRate = float64(successes) / float64(len(results)) * 100
+	summary.AvgLatencyMs = totalLatency / float64(len(results))
+
+	return summary
+}
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+	"github.com/eyzaun/godash/internal/services"
+)
+
+// SilenceHandler handles HTTP requests for silences and maintenance windows
+type SilenceHandler struct {
+	silenceRepo  repository.SilenceRepository
+	alertService *services.AlertService
+}
+
+// NewSilenceHandler creates a new silence handler
+func NewSilenceHandler(silenceRepo repository.SilenceRepository, alertService *services.AlertService) *SilenceHandler {
+	return &SilenceHandler{
+		silenceRepo:  silenceRepo,
+		alertService: alertService,
+	}
+}
+
+// CreateSilenceRequest represents the request body for creating silences
+type CreateSilenceRequest struct {
+	Matchers  []models.AlertMatcher `json:"matchers" binding:"required"`
+	StartsAt  time.Time             `json:"starts_at"`
+	EndsAt    time.Time             `json:"ends_at"`
+	Schedule  string                `json:"schedule"`
+	Duration  int                   `json:"duration"`
+	Timezone  string                `json:"timezone"`
+	CreatedBy string                `json:"created_by"`
+	Comment   string                `json:"comment"`
+}
+
+// UpdateSilenceRequest represents the request body for updating silences
+type UpdateSilenceRequest struct {
+	Matchers  []models.AlertMatcher `json:"matchers"`
+	StartsAt  *time.Time            `json:"starts_at"`
+	EndsAt    *time.Time            `json:"ends_at"`
+	Schedule  *string               `json:"schedule"`
+	Duration  *int                  `json:"duration"`
+	Timezone  *string               `json:"timezone"`
+	CreatedBy *string               `json:"created_by"`
+	Comment   *string               `json:"comment"`
+}
+
+// SilenceResponse is a silence with its current status
+type SilenceResponse struct {
+	*models.Silence
+	Status     string     `json:"status"`                // pending, active, scheduled or expired
+	NextWindow *time.Time `json:"next_window,omitempty"` // Start of the next maintenance window
+}
+
+// newSilenceResponse adds the current status to a silence
+func newSilenceResponse(silence *models.Silence, now time.Time) SilenceResponse {
+	response := SilenceResponse{Silence: silence, Status: services.SilenceStatus(silence, now)}
+
+	if silence.Schedule != "" && response.Status != services.SilenceStatusExpired {
+		schedule, err := services.ParseCronSchedule(silence.Schedule)
+		location, locErr := time.LoadLocation(silence.Timezone)
+		if err == nil && locErr == nil {
+			from := now
+			if from.Before(silence.StartsAt) {
+				from = silence.StartsAt
+			}
+			if next, ok := schedule.Next(from.In(location)); ok && (silence.EndsAt.IsZero() || next.Before(silence.EndsAt)) {
+				response.NextWindow = &next
+			}
+		}
+	}
+
+	return response
+}
+
+// CreateSilence creates a new silence
+// @Summary Create silence
+// @Description Create a silence or a recurring maintenance window suppressing alert notifications
+// @Tags silences
+// @Accept json
+// @Produce json
+// @Param silence body CreateSilenceRequest true "Silence configuration"
+// @Success 201 {object} APIResponse{data=SilenceResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/silences [post]
+func (h *SilenceHandler) CreateSilence(c *gin.Context) {
+	var req CreateSilenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	silence := &models.Silence{
+		Matchers:  req.Matchers,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Schedule:  strings.TrimSpace(req.Schedule),
+		Duration:  req.Duration,
+		Timezone:  req.Timezone,
+		CreatedBy: req.CreatedBy,
+		Comment:   req.Comment,
+	}
+
+	if err := services.ValidateSilence(silence); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.silenceRepo.CreateSilence(silence); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to create silence",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadSilences()
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Data:    newSilenceResponse(silence, time.Now()),
+		Message: "Silence created successfully",
+	})
+}
+
+// GetSilences retrieves all silences
+// @Summary Get silences
+// @Description Retrieve silences and maintenance windows with their current status
+// @Tags silences
+// @Produce json
+// @Param status query string false "Only silences with this status (pending, active, scheduled, expired)"
+// @Success 200 {object} APIResponse{data=[]SilenceResponse}
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/silences [get]
+func (h *SilenceHandler) GetSilences(c *gin.Context) {
+	silences, err := h.silenceRepo.GetSilences()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve silences",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	status := strings.ToLower(c.Query("status"))
+	now := time.Now()
+	responses := make([]SilenceResponse, 0, len(silences))
+	for _, silence := range silences {
+		response := newSilenceResponse(silence, now)
+		if status == "" || response.Status == status {
+			responses = append(responses, response)
+		}
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// GetSilence retrieves a specific silence by ID
+// @Summary Get silence by ID
+// @Description Retrieve a specific silence by ID
+// @Tags silences
+// @Produce json
+// @Param id path int true "Silence ID"
+// @Success 200 {object} APIResponse{data=SilenceResponse}
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/silences/{id} [get]
+func (h *SilenceHandler) GetSilence(c *gin.Context) {
+	silence, ok := h.loadSilence(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    newSilenceResponse(silence, time.Now()),
+	})
+}
+
+// UpdateSilence updates an existing silence
+// @Summary Update silence
+// @Description Update an existing silence, e.g. set ends_at to now to expire it
+// @Tags silences
+// @Accept json
+// @Produce json
+// @Param id path int true "Silence ID"
+// @Param silence body UpdateSilenceRequest true "Silence updates"
+// @Success 200 {object} APIResponse{data=SilenceResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/silences/{id} [put]
+func (h *SilenceHandler) UpdateSilence(c *gin.Context) {
+	silence, ok := h.loadSilence(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateSilenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Update fields
+	if req.Matchers != nil {
+		silence.Matchers = req.Matchers
+	}
+	if req.StartsAt != nil {
+		silence.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		silence.EndsAt = *req.EndsAt
+	}
+	if req.Schedule != nil {
+		silence.Schedule = strings.TrimSpace(*req.Schedule)
+	}
+	if req.Duration != nil {
+		silence.Duration = *req.Duration
+	}
+	if req.Timezone != nil {
+		silence.Timezone = *req.Timezone
+	}
+	if req.CreatedBy != nil {
+		silence.CreatedBy = *req.CreatedBy
+	}
+	if req.Comment != nil {
+		silence.Comment = *req.Comment
+	}
+
+	if err := services.ValidateSilence(silence); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.silenceRepo.UpdateSilence(silence); err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to update silence",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadSilences()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    newSilenceResponse(silence, time.Now()),
+		Message: "Silence updated successfully",
+	})
+}
+
+// DeleteSilence deletes a silence
+// @Summary Delete silence
+// @Description Delete a silence or maintenance window
+// @Tags silences
+// @Produce json
+// @Param id path int true "Silence ID"
+// @Success 200 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /api/v1/silences/{id} [delete]
+func (h *SilenceHandler) DeleteSilence(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid silence ID",
+			Message: "Silence ID must be a valid number",
+		})
+		return
+	}
+
+	if err := h.silenceRepo.DeleteSilence(uint(id)); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "silence not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to delete silence",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.reloadSilences()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Silence deleted successfully",
+	})
+}
+
+// loadSilence parses the silence ID parameter and loads the silence, writing an error response on failure
+func (h *SilenceHandler) loadSilence(c *gin.Context) (*models.Silence, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid silence ID",
+			Message: "Silence ID must be a valid number",
+		})
+		return nil, false
+	}
+
+	silence, err := h.silenceRepo.GetSilenceByID(uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "silence not found" {
+			statusCode = http.StatusNotFound
+		}
+
+		c.JSON(statusCode, APIResponse{
+			Success: false,
+			Error:   "Failed to retrieve silence",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	return silence, true
+}
+
+// reloadSilences tells the alert service to pick up silence changes
+func (h *SilenceHandler) reloadSilences() {
+	if h.alertService != nil {
+		h.alertService.ReloadSilences()
+	}
+}
@@ -357,8 +357,14 @@ func (h *WebSocketHandler) BroadcastSystemStatus() {
 
 // BroadcastAlert broadcasts alert notifications to all connected clients
 func (h *WebSocketHandler) BroadcastAlert(alertData map[string]interface{}) {
+	h.BroadcastAlertEvent("alert_triggered", alertData)
+}
+
+// BroadcastAlertEvent broadcasts an alert lifecycle event (alert_triggered, alert_resolved)
+// to all connected clients
+func (h *WebSocketHandler) BroadcastAlertEvent(eventType string, alertData map[string]interface{}) {
 	message := WebSocketMessage{
-		Type:      "alert_triggered",
+		Type:      eventType,
 		Data:      alertData,
 		Timestamp: time.Now(),
 	}
@@ -371,7 +377,7 @@ func (h *WebSocketHandler) BroadcastAlert(alertData map[string]interface{}) {
 
 	select {
 	case h.hub.broadcast <- data:
-		log.Printf("🚨 Alert broadcasted to %d clients", h.GetConnectedClients())
+		log.Printf("🚨 Alert event %s broadcasted to %d clients", eventType, h.GetConnectedClients())
 	default:
 		log.Println("Broadcast channel full, dropping alert message")
 	}
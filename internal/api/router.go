@@ -20,18 +20,31 @@ import (
 
 // Router wraps gin router with dependencies
 type Router struct {
-	engine           *gin.Engine
-	config           *config.Config
-	metricsRepo      repository.MetricsRepository
-	alertRepo        repository.AlertRepository
-	collectorService *services.CollectorService
-	alertService     *services.AlertService
-	metricsHandler   *handlers.MetricsHandler
-	healthHandler    *handlers.HealthHandler
-	websocketHandler *handlers.WebSocketHandler
-	alertHandler     *handlers.AlertHandler
-	templateFS       fs.FS
-	staticFS         fs.FS
+	engine              *gin.Engine
+	config              *config.Config
+	metricsRepo         repository.MetricsRepository
+	alertRepo           repository.AlertRepository
+	probeRepo           repository.ProbeRepository
+	silenceRepo         repository.SilenceRepository
+	policyRepo          repository.EscalationPolicyRepository
+	notificationRepo    repository.NotificationRepository
+	hostRepo            repository.HostRepository
+	collectorService    *services.CollectorService
+	alertService        *services.AlertService
+	probeService        *services.ProbeService
+	certService         *services.CertificateService
+	metricsHandler      *handlers.MetricsHandler
+	healthHandler       *handlers.HealthHandler
+	websocketHandler    *handlers.WebSocketHandler
+	alertHandler        *handlers.AlertHandler
+	probeHandler        *handlers.ProbeHandler
+	silenceHandler      *handlers.SilenceHandler
+	policyHandler       *handlers.EscalationPolicyHandler
+	notificationHandler *handlers.NotificationHandler
+	hostHandler         *handlers.HostHandler
+	certHandler         *handlers.CertificateHandler
+	templateFS          fs.FS
+	staticFS            fs.FS
 }
 
 // New creates a new API router with alert system
@@ -39,8 +52,15 @@ func New(
 	cfg *config.Config,
 	metricsRepo repository.MetricsRepository,
 	alertRepo repository.AlertRepository,
+	probeRepo repository.ProbeRepository,
+	silenceRepo repository.SilenceRepository,
+	policyRepo repository.EscalationPolicyRepository,
+	notificationRepo repository.NotificationRepository,
+	hostRepo repository.HostRepository,
 	collectorService *services.CollectorService,
 	alertService *services.AlertService,
+	probeService *services.ProbeService,
+	certService *services.CertificateService,
 	emailSender services.EmailSender,
 	webhookSender services.WebhookSender,
 	templateFS fs.FS,
@@ -56,21 +76,40 @@ func New(
 	metricsHandler := handlers.NewMetricsHandler(metricsRepo, collectorService.GetSystemCollector())
 	healthHandler := handlers.NewHealthHandler(metricsRepo)
 	websocketHandler := handlers.NewWebSocketHandler(metricsRepo, collectorService.GetSystemCollector())
-	alertHandler := handlers.NewAlertHandler(alertRepo, alertService, emailSender, webhookSender)
+	alertHandler := handlers.NewAlertHandler(alertRepo, probeRepo, policyRepo, alertService, emailSender, webhookSender)
+	probeHandler := handlers.NewProbeHandler(probeRepo, probeService)
+	silenceHandler := handlers.NewSilenceHandler(silenceRepo, alertService)
+	policyHandler := handlers.NewEscalationPolicyHandler(policyRepo, alertService)
+	notificationHandler := handlers.NewNotificationHandler(notificationRepo, alertService)
+	hostHandler := handlers.NewHostHandler(hostRepo, alertService)
+	certHandler := handlers.NewCertificateHandler(certService)
 
 	router := &Router{
-		engine:           engine,
-		config:           cfg,
-		metricsRepo:      metricsRepo,
-		alertRepo:        alertRepo,
-		collectorService: collectorService,
-		alertService:     alertService,
-		metricsHandler:   metricsHandler,
-		healthHandler:    healthHandler,
-		websocketHandler: websocketHandler,
-		alertHandler:     alertHandler,
-		templateFS:       templateFS,
-		staticFS:         staticFS,
+		engine:              engine,
+		config:              cfg,
+		metricsRepo:         metricsRepo,
+		alertRepo:           alertRepo,
+		probeRepo:           probeRepo,
+		silenceRepo:         silenceRepo,
+		policyRepo:          policyRepo,
+		notificationRepo:    notificationRepo,
+		hostRepo:            hostRepo,
+		collectorService:    collectorService,
+		alertService:        alertService,
+		probeService:        probeService,
+		certService:         certService,
+		metricsHandler:      metricsHandler,
+		healthHandler:       healthHandler,
+		websocketHandler:    websocketHandler,
+		alertHandler:        alertHandler,
+		probeHandler:        probeHandler,
+		silenceHandler:      silenceHandler,
+		policyHandler:       policyHandler,
+		notificationHandler: notificationHandler,
+		hostHandler:         hostHandler,
+		certHandler:         certHandler,
+		templateFS:          templateFS,
+		staticFS:            staticFS,
 	}
 
 	// Setup middleware
@@ -180,6 +219,13 @@ func (r *Router) setupRoutes() {
 			metricsGroup.POST("", r.metricsHandler.CreateMetric) // For manual metric insertion
 		}
 
+		// Process routes
+		processGroup := v1.Group("/processes")
+		{
+			processGroup.GET("/tree", r.metricsHandler.GetProcessTree)
+			processGroup.GET("/:pid", r.metricsHandler.GetProcessDetails)
+		}
+
 		// NEW: Alert routes
 		alertGroup := v1.Group("/alerts")
 		{
@@ -193,10 +239,92 @@ func (r *Router) setupRoutes() {
 			// Alert history and management
 			alertGroup.GET("/history", r.alertHandler.GetAlertHistory)
 			alertGroup.POST("/:id/test", r.alertHandler.TestAlert)
+			alertGroup.POST("/backtest", r.alertHandler.BacktestAlert)
+			alertGroup.GET("/export", r.alertHandler.ExportAlerts)
+			alertGroup.POST("/import", r.alertHandler.ImportAlerts)
+			alertGroup.GET("/:id/baseline", r.alertHandler.GetAlertBaseline)
 			alertGroup.GET("/stats", r.alertHandler.GetAlertStats)
+			alertGroup.GET("/instances", r.alertHandler.GetAlertInstances)
+			alertGroup.GET("/history/:id", r.alertHandler.GetIncident)
+			alertGroup.GET("/history/:id/timeline", r.alertHandler.GetIncidentTimeline)
+			alertGroup.POST("/history/:id/ack", r.alertHandler.AcknowledgeIncident)
+			alertGroup.POST("/history/:id/unack", r.alertHandler.UnacknowledgeIncident)
+			alertGroup.POST("/history/:id/assign", r.alertHandler.AssignIncident)
+			alertGroup.POST("/history/:id/comments", r.alertHandler.CommentIncident)
 			alertGroup.POST("/history/:id/resolve", r.alertHandler.ResolveAlert)
 		}
 
+		// Probe routes
+		probeGroup := v1.Group("/probes")
+		{
+			probeGroup.POST("", r.probeHandler.CreateProbe)
+			probeGroup.GET("", r.probeHandler.GetProbes)
+			probeGroup.GET("/:id", r.probeHandler.GetProbe)
+			probeGroup.PUT("/:id", r.probeHandler.UpdateProbe)
+			probeGroup.DELETE("/:id", r.probeHandler.DeleteProbe)
+			probeGroup.GET("/:id/results", r.probeHandler.GetProbeResults)
+			probeGroup.POST("/:id/run", r.probeHandler.RunProbe)
+		}
+
+		// Silence and maintenance window routes
+		silenceGroup := v1.Group("/silences")
+		{
+			silenceGroup.POST("", r.silenceHandler.CreateSilence)
+			silenceGroup.GET("", r.silenceHandler.GetSilences)
+			silenceGroup.GET("/:id", r.silenceHandler.GetSilence)
+			silenceGroup.PUT("/:id", r.silenceHandler.UpdateSilence)
+			silenceGroup.DELETE("/:id", r.silenceHandler.DeleteSilence)
+		}
+
+		// Escalation policy routes
+		policyGroup := v1.Group("/escalation-policies")
+		{
+			policyGroup.POST("", r.policyHandler.CreateEscalationPolicy)
+			policyGroup.GET("", r.policyHandler.GetEscalationPolicies)
+			policyGroup.GET("/:id", r.policyHandler.GetEscalationPolicy)
+			policyGroup.PUT("/:id", r.policyHandler.UpdateEscalationPolicy)
+			policyGroup.DELETE("/:id", r.policyHandler.DeleteEscalationPolicy)
+		}
+
+		// Notification channel and routing routes
+		channelGroup := v1.Group("/notification-channels")
+		{
+			channelGroup.POST("", r.notificationHandler.CreateChannel)
+			channelGroup.GET("", r.notificationHandler.GetChannels)
+			channelGroup.GET("/:id", r.notificationHandler.GetChannel)
+			channelGroup.PUT("/:id", r.notificationHandler.UpdateChannel)
+			channelGroup.DELETE("/:id", r.notificationHandler.DeleteChannel)
+			channelGroup.POST("/:id/test", r.notificationHandler.TestChannel)
+		}
+
+		routeGroup := v1.Group("/notification-routes")
+		{
+			routeGroup.POST("", r.notificationHandler.CreateRoute)
+			routeGroup.GET("", r.notificationHandler.GetRoutes)
+			routeGroup.PUT("/:id", r.notificationHandler.UpdateRoute)
+			routeGroup.DELETE("/:id", r.notificationHandler.DeleteRoute)
+		}
+
+		templateGroup := v1.Group("/notification-templates")
+		{
+			templateGroup.GET("", r.notificationHandler.GetTemplates)
+			templateGroup.POST("/preview", r.notificationHandler.PreviewTemplate)
+			templateGroup.PUT("/:type", r.notificationHandler.SaveTemplate)
+			templateGroup.DELETE("/:type", r.notificationHandler.DeleteTemplate)
+		}
+
+		// Host metadata routes
+		hostGroup := v1.Group("/hosts")
+		{
+			hostGroup.GET("", r.hostHandler.GetHostMetadataList)
+			hostGroup.GET("/:hostname", r.hostHandler.GetHostMetadata)
+			hostGroup.PUT("/:hostname", r.hostHandler.SetHostMetadata)
+			hostGroup.DELETE("/:hostname", r.hostHandler.DeleteHostMetadata)
+		}
+
+		// Certificate routes
+		v1.GET("/certificates", r.certHandler.GetCertificates)
+
 		// System routes
 		systemGroup := v1.Group("/system")
 		{
@@ -341,6 +469,11 @@ func (r *Router) GetEngine() *gin.Engine {
 	return r.engine
 }
 
+// GetAlertHandler returns the alert handler
+func (r *Router) GetAlertHandler() *handlers.AlertHandler {
+	return r.alertHandler
+}
+
 // GetWebSocketHandler returns the WebSocket handler
 func (r *Router) GetWebSocketHandler() *handlers.WebSocketHandler {
 	return r.websocketHandler
@@ -0,0 +1,169 @@
+package collector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// Certificate source types
+const (
+	CertificateSourceFile     = "file"
+	CertificateSourceEndpoint = "endpoint"
+)
+
+// CertificateCollector inspects TLS certificates from PEM files and TLS endpoints
+type CertificateCollector struct {
+	files     []string
+	endpoints []string
+	timeout   time.Duration
+}
+
+// NewCertificateCollector creates a new certificate collector
+func NewCertificateCollector(files, endpoints []string, timeout time.Duration) *CertificateCollector {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &CertificateCollector{
+		files:     files,
+		endpoints: endpoints,
+		timeout:   timeout,
+	}
+}
+
+// GetCertificates inspects all configured certificates. Sources that cannot be
+// read are reported with their Error field set rather than failing the collection.
+func (cc *CertificateCollector) GetCertificates() []models.CertificateInfo {
+	certificates := make([]models.CertificateInfo, 0, len(cc.files)+len(cc.endpoints))
+
+	for _, path := range cc.files {
+		certificates = append(certificates, cc.inspectFile(path)...)
+	}
+
+	for _, endpoint := range cc.endpoints {
+		certificates = append(certificates, cc.inspectEndpoint(endpoint))
+	}
+
+	return certificates
+}
+
+// inspectFile reads every certificate in a PEM file
+func (cc *CertificateCollector) inspectFile(path string) []models.CertificateInfo {
+	now := time.Now()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []models.CertificateInfo{certificateError(path, CertificateSourceFile, fmt.Errorf("failed to read file: %w", err), now)}
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return []models.CertificateInfo{certificateError(path, CertificateSourceFile, fmt.Errorf("failed to parse certificate: %w", err), now)}
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return []models.CertificateInfo{certificateError(path, CertificateSourceFile, fmt.Errorf("no certificates found"), now)}
+	}
+
+	infos := make([]models.CertificateInfo, 0, len(certs))
+	for i, cert := range certs {
+		info := buildCertificateInfo(cert, path, CertificateSourceFile, now)
+		if len(certs) > 1 {
+			info.Name = fmt.Sprintf("%s#%d", path, i+1)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// inspectEndpoint connects to a TLS endpoint and reads its leaf certificate.
+// Verification is skipped so that untrusted and expired certificates can still be reported.
+func (cc *CertificateCollector) inspectEndpoint(endpoint string) models.CertificateInfo {
+	now := time.Now()
+
+	address := endpoint
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "443")
+	}
+	host, _, _ := net.SplitHostPort(address)
+
+	dialer := &net.Dialer{Timeout: cc.timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return certificateError(address, CertificateSourceEndpoint, fmt.Errorf("TLS handshake failed: %w", err), now)
+	}
+	defer conn.Close()
+
+	peerCerts := conn.ConnectionState().PeerCertificates
+	if len(peerCerts) == 0 {
+		return certificateError(address, CertificateSourceEndpoint, fmt.Errorf("no certificates presented"), now)
+	}
+
+	return buildCertificateInfo(peerCerts[0], address, CertificateSourceEndpoint, now)
+}
+
+// buildCertificateInfo converts an x509 certificate into a CertificateInfo
+func buildCertificateInfo(cert *x509.Certificate, source, sourceType string, now time.Time) models.CertificateInfo {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.EmailAddresses)+len(cert.URIs))
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	daysUntilExpiry := cert.NotAfter.Sub(now).Hours() / 24
+
+	return models.CertificateInfo{
+		Name:            source,
+		Source:          source,
+		SourceType:      sourceType,
+		Subject:         cert.Subject.String(),
+		CommonName:      cert.Subject.CommonName,
+		SANs:            sans,
+		Issuer:          cert.Issuer.String(),
+		SerialNumber:    strings.ToUpper(cert.SerialNumber.Text(16)),
+		NotBefore:       cert.NotBefore,
+		NotAfter:        cert.NotAfter,
+		DaysUntilExpiry: daysUntilExpiry,
+		Expired:         daysUntilExpiry < 0,
+		CheckedAt:       now,
+	}
+}
+
+// certificateError builds a CertificateInfo for a source that could not be inspected
+func certificateError(source, sourceType string, err error, now time.Time) models.CertificateInfo {
+	return models.CertificateInfo{
+		Name:       source,
+		Source:     source,
+		SourceType: sourceType,
+		SANs:       []string{},
+		Error:      err.Error(),
+		CheckedAt:  now,
+	}
+}
@@ -0,0 +1,128 @@
+package collector
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCertificate appends a self-signed PEM certificate to path
+func writeTestCertificate(t *testing.T, path, commonName string, notAfter time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"GoDash Test"}},
+		Issuer:       pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{commonName, "www." + commonName},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	if err := pem.Encode(file, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+}
+
+func TestCertificateCollector_Files(t *testing.T) {
+	dir := t.TempDir()
+
+	single := filepath.Join(dir, "single.pem")
+	writeTestCertificate(t, single, "internal.example.com", time.Now().Add(30*24*time.Hour))
+
+	bundle := filepath.Join(dir, "bundle.pem")
+	writeTestCertificate(t, bundle, "a.example.com", time.Now().Add(10*24*time.Hour))
+	writeTestCertificate(t, bundle, "b.example.com", time.Now().Add(-2*24*time.Hour))
+
+	missing := filepath.Join(dir, "missing.pem")
+
+	cc := NewCertificateCollector([]string{single, bundle, missing}, nil, time.Second)
+	certs := cc.GetCertificates()
+	if len(certs) != 4 {
+		t.Fatalf("Expected 4 certificates, got %d", len(certs))
+	}
+
+	first := certs[0]
+	if first.Name != single || first.SourceType != CertificateSourceFile || first.CommonName != "internal.example.com" {
+		t.Errorf("Unexpected certificate: %+v", first)
+	}
+	if first.DaysUntilExpiry < 29.9 || first.DaysUntilExpiry > 30.1 || first.Expired {
+		t.Errorf("Expected ~30 days until expiry, got %.2f", first.DaysUntilExpiry)
+	}
+	if len(first.SANs) != 3 || first.SANs[0] != "internal.example.com" || first.SANs[2] != "127.0.0.1" {
+		t.Errorf("Unexpected SANs: %v", first.SANs)
+	}
+	if first.Issuer == "" || first.SerialNumber == "" {
+		t.Errorf("Expected issuer and serial number, got %+v", first)
+	}
+
+	// Bundles get one entry per certificate
+	if certs[1].Name != bundle+"#1" || certs[2].Name != bundle+"#2" {
+		t.Errorf("Unexpected bundle names: %s, %s", certs[1].Name, certs[2].Name)
+	}
+	if !certs[2].Expired || certs[2].DaysUntilExpiry >= 0 {
+		t.Errorf("Expected expired certificate, got %+v", certs[2])
+	}
+
+	if certs[3].Error == "" {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestCertificateCollector_Endpoint(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	address := server.Listener.Addr().String()
+	cc := NewCertificateCollector(nil, []string{address}, 5*time.Second)
+	certs := cc.GetCertificates()
+	if len(certs) != 1 {
+		t.Fatalf("Expected 1 certificate, got %d", len(certs))
+	}
+
+	cert := certs[0]
+	if cert.Error != "" {
+		t.Fatalf("Unexpected error: %s", cert.Error)
+	}
+	if cert.SourceType != CertificateSourceEndpoint || cert.Source != address {
+		t.Errorf("Unexpected source: %+v", cert)
+	}
+	if cert.DaysUntilExpiry <= 0 || cert.NotAfter.IsZero() {
+		t.Errorf("Expected a valid expiry, got %+v", cert)
+	}
+
+	// Nothing listens on a closed server's address
+	server.Close()
+	certs = cc.GetCertificates()
+	if len(certs) != 1 || certs[0].Error == "" {
+		t.Errorf("Expected a handshake error, got %+v", certs)
+	}
+}
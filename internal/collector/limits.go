@@ -0,0 +1,200 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// topFDProcessCount is the number of processes reported in TopFDProcesses
+const topFDProcessCount = 10
+
+// LimitsCollector handles kernel limit usage collection (file handles, PIDs, threads, conntrack)
+type LimitsCollector struct {
+	procRoot string // Root of the proc filesystem, overridable for tests
+}
+
+// NewLimitsCollector creates a new limits collector
+func NewLimitsCollector() *LimitsCollector {
+	return &LimitsCollector{procRoot: "/proc"}
+}
+
+// GetLimitMetrics collects current kernel limit usage
+func (lc *LimitsCollector) GetLimitMetrics() (*models.LimitMetrics, error) {
+	if runtime.GOOS != "linux" {
+		return lc.getGenericLimitMetrics()
+	}
+
+	metrics := &models.LimitMetrics{}
+
+	// fs.file-nr: allocated, unused, max
+	fileNr, err := lc.readProcFields("sys/fs/file-nr")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file handle usage: %w", err)
+	}
+	if len(fileNr) >= 3 {
+		allocated := parseUint(fileNr[0])
+		unused := parseUint(fileNr[1])
+		if unused < allocated {
+			metrics.FileHandlesUsed = allocated - unused
+		}
+		metrics.FileHandlesMax = parseUint(fileNr[2])
+	}
+	metrics.FileHandlesPercent = usagePercent(metrics.FileHandlesUsed, metrics.FileHandlesMax)
+
+	// Every thread consumes a PID, so the scheduling entity count from
+	// loadavg ("running/total") covers both PID and thread usage
+	if loadavg, err := lc.readProcFields("loadavg"); err == nil && len(loadavg) >= 4 {
+		if parts := strings.SplitN(loadavg[3], "/", 2); len(parts) == 2 {
+			metrics.Threads = parseUint(parts[1])
+		}
+	}
+	metrics.PIDsUsed = metrics.Threads
+	metrics.PIDMax = lc.readProcUint("sys/kernel/pid_max")
+	metrics.PIDPercent = usagePercent(metrics.PIDsUsed, metrics.PIDMax)
+	metrics.ThreadsMax = lc.readProcUint("sys/kernel/threads-max")
+
+	// Conntrack is only available when the netfilter module is loaded
+	metrics.ConntrackCount = lc.readProcUint("sys/net/netfilter/nf_conntrack_count")
+	metrics.ConntrackMax = lc.readProcUint("sys/net/netfilter/nf_conntrack_max")
+	metrics.ConntrackPercent = usagePercent(metrics.ConntrackCount, metrics.ConntrackMax)
+
+	metrics.TopFDProcesses = lc.getProcessFDUsage()
+	if len(metrics.TopFDProcesses) > 0 {
+		metrics.MaxProcessFDPercent = metrics.TopFDProcesses[0].Percent
+	}
+
+	return metrics, nil
+}
+
+// getGenericLimitMetrics collects what is available without a proc filesystem
+func (lc *LimitsCollector) getGenericLimitMetrics() (*models.LimitMetrics, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get processes: %w", err)
+	}
+
+	metrics := &models.LimitMetrics{PIDsUsed: uint64(len(procs))}
+	for _, proc := range procs {
+		if threads, err := proc.NumThreads(); err == nil && threads > 0 {
+			metrics.Threads += uint64(threads)
+		}
+	}
+
+	return metrics, nil
+}
+
+// getProcessFDUsage returns the processes closest to their RLIMIT_NOFILE soft limit.
+// Processes whose fd directory cannot be read (e.g. owned by another user) are skipped.
+func (lc *LimitsCollector) getProcessFDUsage() []models.ProcessFDInfo {
+	entries, err := os.ReadDir(lc.procRoot)
+	if err != nil {
+		return []models.ProcessFDInfo{}
+	}
+
+	usage := make([]models.ProcessFDInfo, 0)
+	for _, entry := range entries {
+		pid, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		pidDir := filepath.Join(lc.procRoot, entry.Name())
+		fds, err := os.ReadDir(filepath.Join(pidDir, "fd"))
+		if err != nil {
+			continue
+		}
+
+		limits, err := os.ReadFile(filepath.Join(pidDir, "limits"))
+		if err != nil {
+			continue
+		}
+		softLimit := parseNoFileSoftLimit(string(limits))
+		if softLimit == 0 {
+			continue
+		}
+
+		name := ""
+		if comm, err := os.ReadFile(filepath.Join(pidDir, "comm")); err == nil {
+			name = strings.TrimSpace(string(comm))
+		}
+
+		usage = append(usage, models.ProcessFDInfo{
+			PID:       int32(pid),
+			Name:      name,
+			OpenFDs:   uint64(len(fds)),
+			SoftLimit: softLimit,
+			Percent:   usagePercent(uint64(len(fds)), softLimit),
+		})
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Percent == usage[j].Percent {
+			return usage[i].OpenFDs > usage[j].OpenFDs
+		}
+		return usage[i].Percent > usage[j].Percent
+	})
+
+	if len(usage) > topFDProcessCount {
+		usage = usage[:topFDProcessCount]
+	}
+	return usage
+}
+
+// readProcFields reads a proc file and splits it into whitespace-separated fields
+func (lc *LimitsCollector) readProcFields(name string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(lc.procRoot, name))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// readProcUint reads a proc file holding a single number, returning 0 if unavailable
+func (lc *LimitsCollector) readProcUint(name string) uint64 {
+	fields, err := lc.readProcFields(name)
+	if err != nil || len(fields) == 0 {
+		return 0
+	}
+	return parseUint(fields[0])
+}
+
+// parseNoFileSoftLimit extracts the "Max open files" soft limit from /proc/<pid>/limits.
+// Returns 0 when the limit is missing or unlimited.
+func parseNoFileSoftLimit(limits string) uint64 {
+	for _, line := range strings.Split(limits, "\n") {
+		if !strings.HasPrefix(line, "Max open files") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "Max open files"))
+		if len(fields) == 0 {
+			return 0
+		}
+		return parseUint(fields[0])
+	}
+	return 0
+}
+
+// parseUint parses an unsigned integer, returning 0 on failure
+func parseUint(s string) uint64 {
+	value, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// usagePercent calculates used/max as a percentage, returning 0 when max is unknown
+func usagePercent(used, max uint64) float64 {
+	if max == 0 {
+		return 0
+	}
+	return float64(used) / float64(max) * 100
+}
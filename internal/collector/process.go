@@ -1,13 +1,21 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/eyzaun/godash/internal/models"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/process"
 )
 
+// ErrProcessNotFound is returned for PIDs without a running process
+var ErrProcessNotFound = errors.New("process not found")
+
 // ProcessCollector handles process-related metrics collection
 type ProcessCollector struct{}
 
@@ -30,6 +38,9 @@ func (p *ProcessCollector) GetProcessActivity() (*models.ProcessActivity, error)
 	stoppedProcesses := 0
 	zombieProcesses := 0
 
+	cpuCount := logicalCPUCount()
+	processes := make([]models.ProcessInfo, 0, len(pids))
+
 	// Count processes by status - Windows-optimized approach
 	for _, pid := range pids {
 		proc, err := process.NewProcess(pid)
@@ -48,15 +59,19 @@ func (p *ProcessCollector) GetProcessActivity() (*models.ProcessActivity, error)
 			// Eğer process artık running değilse düzelt
 			runningProcesses--
 			stoppedProcesses++
+			continue
+		}
+
+		// Keep per-PID details for process watch alerts
+		info := p.buildProcessInfo(proc, cpuCount)
+		if createTime, err := proc.CreateTime(); err == nil {
+			info.StartTime = time.UnixMilli(createTime)
 		}
+		processes = append(processes, info)
 	}
 
 	// Get top processes by CPU usage
-	topProcesses, err := p.getTopProcesses(10)
-	if err != nil {
-		// Continue with empty top processes if this fails
-		topProcesses = []models.ProcessInfo{}
-	}
+	topProcesses := p.getTopProcesses(processes, 10)
 
 	return &models.ProcessActivity{
 		TotalProcesses:   totalProcesses,
@@ -64,30 +79,151 @@ func (p *ProcessCollector) GetProcessActivity() (*models.ProcessActivity, error)
 		StoppedProcesses: stoppedProcesses,
 		ZombieProcesses:  zombieProcesses,
 		TopProcesses:     topProcesses,
+		Processes:        processes,
 	}, nil
 }
 
 // getTopProcesses gets top processes by CPU usage (internal method)
-func (p *ProcessCollector) getTopProcesses(limit int) ([]models.ProcessInfo, error) {
-	pids, err := process.Pids()
+func (p *ProcessCollector) getTopProcesses(all []models.ProcessInfo, limit int) []models.ProcessInfo {
+	// Group processes by name to avoid duplicates and sum CPU usage
+	processGroups := make(map[string]*models.ProcessInfo)
+
+	for _, info := range all {
+		// Group by process name
+		if existing, exists := processGroups[info.Name]; exists {
+			// Add to existing group
+			existing.CPUPercent += info.CPUPercent
+			existing.MemoryBytes += info.MemoryBytes
+			// Keep the lowest PID as representative
+			if info.PID < existing.PID {
+				existing.PID = info.PID
+			}
+		} else {
+			// Create new group
+			group := info
+			group.StartTime = time.Time{}
+			processGroups[info.Name] = &group
+		}
+	}
+
+	// Convert map to slice for sorting
+	processes := make([]models.ProcessInfo, 0, len(processGroups))
+	for _, proc := range processGroups {
+		processes = append(processes, *proc)
+	}
+
+	// Sort by CPU usage and return top processes
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].CPUPercent > processes[j].CPUPercent
+	})
+
+	if len(processes) > limit {
+		processes = processes[:limit]
+	}
+
+	return processes
+}
+
+// GetProcessDetails collects detailed information about a single process
+func (p *ProcessCollector) GetProcessDetails(pid int32) (*models.ProcessDetails, error) {
+	exists, err := process.PidExists(pid)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get process IDs: %w", err)
+		return nil, fmt.Errorf("failed to check process %d: %w", pid, err)
+	}
+	if !exists {
+		return nil, ErrProcessNotFound
 	}
 
-	// Get CPU count to normalize CPU percentages (gopsutil returns per-core values)
-	cpuCount, err := cpu.Counts(true)
+	proc, err := process.NewProcess(pid)
 	if err != nil {
-		// Fallback to logical cores if physical cores fail
-		if cpuCountLogical, errLogical := cpu.Counts(false); errLogical == nil {
-			cpuCount = cpuCountLogical
-		} else {
-			// Last resort fallback to 1
-			cpuCount = 1
+		// The process may have exited since the check
+		if errors.Is(err, process.ErrorProcessNotRunning) {
+			return nil, ErrProcessNotFound
 		}
+		return nil, fmt.Errorf("failed to open process %d: %w", pid, err)
 	}
 
-	// Group processes by name to avoid duplicates and sum CPU usage
-	processGroups := make(map[string]*models.ProcessInfo)
+	cpuCount := logicalCPUCount()
+	info := p.buildProcessInfo(proc, cpuCount)
+
+	details := &models.ProcessDetails{
+		PID:         info.PID,
+		Name:        info.Name,
+		Status:      info.Status,
+		CPUPercent:  info.CPUPercent,
+		MemoryBytes: info.MemoryBytes,
+		Children:    []models.ProcessInfo{},
+	}
+
+	// Most of these calls can fail for processes owned by other users,
+	// so each field is filled on a best-effort basis.
+	if ppid, err := proc.Ppid(); err == nil {
+		details.PPID = ppid
+	}
+	if exe, err := proc.Exe(); err == nil {
+		details.Exe = exe
+	}
+	if cmdline, err := proc.Cmdline(); err == nil {
+		details.Cmdline = cmdline
+	}
+	if username, err := proc.Username(); err == nil {
+		details.Username = username
+	}
+	if createTime, err := proc.CreateTime(); err == nil {
+		details.StartTime = time.UnixMilli(createTime)
+	}
+	if threads, err := proc.NumThreads(); err == nil {
+		details.NumThreads = threads
+	}
+	if files, err := proc.OpenFiles(); err == nil {
+		details.OpenFiles = len(files)
+	}
+	if conns, err := proc.Connections(); err == nil {
+		details.Connections = len(conns)
+	}
+	if environ, err := proc.Environ(); err == nil {
+		details.EnvironCount = len(environ)
+		for _, env := range environ {
+			details.EnvironBytes += len(env) + 1 // include separator
+		}
+	}
+	if io, err := proc.IOCounters(); err == nil && io != nil {
+		details.IOCounters = models.ProcessIOStats{
+			ReadCount:  io.ReadCount,
+			WriteCount: io.WriteCount,
+			ReadBytes:  io.ReadBytes,
+			WriteBytes: io.WriteBytes,
+		}
+	}
+	details.Cgroup = readProcessCgroup(pid)
+
+	// Parent process
+	if details.PPID > 0 && details.PPID != pid {
+		if parent, err := process.NewProcess(details.PPID); err == nil {
+			parentInfo := p.buildProcessInfo(parent, cpuCount)
+			details.Parent = &parentInfo
+		}
+	}
+
+	// Direct children
+	if children, err := proc.Children(); err == nil {
+		for _, child := range children {
+			details.Children = append(details.Children, p.buildProcessInfo(child, cpuCount))
+		}
+	}
+
+	return details, nil
+}
+
+// GetProcessTree builds the process hierarchy with aggregated usage per subtree
+func (p *ProcessCollector) GetProcessTree() ([]*models.ProcessTreeNode, error) {
+	pids, err := process.Pids()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get process IDs: %w", err)
+	}
+
+	cpuCount := logicalCPUCount()
+	nodes := make(map[int32]*models.ProcessTreeNode, len(pids))
 
 	for _, pid := range pids {
 		proc, err := process.NewProcess(pid)
@@ -95,77 +231,155 @@ func (p *ProcessCollector) getTopProcesses(limit int) ([]models.ProcessInfo, err
 			continue
 		}
 
-		name, err := proc.Name()
+		info := p.buildProcessInfo(proc, cpuCount)
+		ppid, err := proc.Ppid()
 		if err != nil {
-			name = "unknown"
+			ppid = 0
 		}
 
-		cpuPercent, err := proc.CPUPercent()
-		if err != nil {
-			cpuPercent = 0
-		}
-		// Normalize CPU percentage by dividing by number of cores
-		// gopsutil.CPUPercent() returns per-core values
-		if cpuCount > 0 {
-			cpuPercent = cpuPercent / float64(cpuCount)
+		nodes[pid] = &models.ProcessTreeNode{
+			PID:         pid,
+			PPID:        ppid,
+			Name:        info.Name,
+			Status:      info.Status,
+			CPUPercent:  info.CPUPercent,
+			MemoryBytes: info.MemoryBytes,
+			Children:    []*models.ProcessTreeNode{},
 		}
+	}
 
-		memInfo, err := proc.MemoryInfo()
-		var memoryBytes uint64
-		if err == nil && memInfo != nil {
-			memoryBytes = memInfo.RSS
-		}
+	roots := linkProcessTree(nodes)
+	for _, root := range roots {
+		aggregateProcessTree(root)
+	}
+	sortProcessTree(roots)
 
-		statuses, err := proc.Status()
-		var status string
-		if err != nil {
-			status = "unknown"
-		} else if len(statuses) > 0 {
-			status = statuses[0]
-		} else {
-			status = "unknown"
+	return roots, nil
+}
+
+// linkProcessTree links children to their parents and returns the roots. Processes whose
+// parent is unknown become roots, and so does any process reached twice while walking up a
+// PPID chain, which breaks cycles left behind by PID reuse
+func linkProcessTree(nodes map[int32]*models.ProcessTreeNode) []*models.ProcessTreeNode {
+	isRoot := make(map[int32]bool, len(nodes))
+	for pid, node := range nodes {
+		if _, exists := nodes[node.PPID]; !exists || node.PPID == pid {
+			isRoot[pid] = true
 		}
+	}
 
-		// Group by process name
-		if existing, exists := processGroups[name]; exists {
-			// Add to existing group
-			existing.CPUPercent += cpuPercent
-			existing.MemoryBytes += memoryBytes
-			// Keep the lowest PID as representative
-			if int32(pid) < existing.PID {
-				existing.PID = int32(pid)
-			}
-		} else {
-			// Create new group
-			processGroups[name] = &models.ProcessInfo{
-				PID:         int32(pid),
-				Name:        name,
-				CPUPercent:  cpuPercent,
-				MemoryBytes: memoryBytes,
-				Status:      status,
+	walked := make(map[int32]bool, len(nodes))
+	for pid := range nodes {
+		visited := make(map[int32]bool)
+		for current := pid; !isRoot[current] && !walked[current]; current = nodes[current].PPID {
+			if visited[current] {
+				isRoot[current] = true
+				break
 			}
+			visited[current] = true
+		}
+		for seen := range visited {
+			walked[seen] = true
 		}
 	}
 
-	// Convert map to slice for sorting
-	var processes []models.ProcessInfo
-	for _, proc := range processGroups {
-		processes = append(processes, *proc)
+	var roots []*models.ProcessTreeNode
+	for pid, node := range nodes {
+		if isRoot[pid] {
+			roots = append(roots, node)
+			continue
+		}
+		nodes[node.PPID].Children = append(nodes[node.PPID].Children, node)
 	}
+	return roots
+}
 
-	// Sort by CPU usage and return top processes
-	// Simple bubble sort for top processes
-	for i := 0; i < len(processes)-1; i++ {
-		for j := 0; j < len(processes)-i-1; j++ {
-			if processes[j].CPUPercent < processes[j+1].CPUPercent {
-				processes[j], processes[j+1] = processes[j+1], processes[j]
-			}
-		}
+// buildProcessInfo collects the basic ProcessInfo fields for a process
+func (p *ProcessCollector) buildProcessInfo(proc *process.Process, cpuCount int) models.ProcessInfo {
+	name, err := proc.Name()
+	if err != nil {
+		name = "unknown"
 	}
 
-	if len(processes) > limit {
-		processes = processes[:limit]
+	cpuPercent, err := proc.CPUPercent()
+	if err != nil {
+		cpuPercent = 0
+	}
+	if cpuCount > 0 {
+		cpuPercent = cpuPercent / float64(cpuCount)
+	}
+
+	var memoryBytes uint64
+	if memInfo, err := proc.MemoryInfo(); err == nil && memInfo != nil {
+		memoryBytes = memInfo.RSS
 	}
 
-	return processes, nil
+	status := "unknown"
+	if statuses, err := proc.Status(); err == nil && len(statuses) > 0 {
+		status = statuses[0]
+	}
+
+	return models.ProcessInfo{
+		PID:         proc.Pid,
+		Name:        name,
+		CPUPercent:  cpuPercent,
+		MemoryBytes: memoryBytes,
+		Status:      status,
+	}
+}
+
+// aggregateProcessTree fills the subtree totals of a node and its descendants
+func aggregateProcessTree(node *models.ProcessTreeNode) {
+	node.TotalCPUPercent = node.CPUPercent
+	node.TotalMemoryBytes = node.MemoryBytes
+	node.ProcessCount = 1
+
+	for _, child := range node.Children {
+		aggregateProcessTree(child)
+		node.TotalCPUPercent += child.TotalCPUPercent
+		node.TotalMemoryBytes += child.TotalMemoryBytes
+		node.ProcessCount += child.ProcessCount
+	}
+}
+
+// sortProcessTree orders each level of the tree by PID for stable output
+func sortProcessTree(nodes []*models.ProcessTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].PID < nodes[j].PID
+	})
+	for _, node := range nodes {
+		sortProcessTree(node.Children)
+	}
+}
+
+// readProcessCgroup returns the cgroup path of a process (Linux only)
+func readProcessCgroup(pid int32) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return ""
+	}
+
+	// Prefer the unified (cgroup v2) hierarchy entry: "0::/path"
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	for _, line := range lines {
+		if strings.HasPrefix(line, "0::") {
+			return strings.TrimPrefix(line, "0::")
+		}
+	}
+	if len(lines) > 0 {
+		parts := strings.SplitN(lines[0], ":", 3)
+		if len(parts) == 3 {
+			return parts[2]
+		}
+	}
+	return ""
+}
+
+// logicalCPUCount returns the CPU count used to normalize per-process CPU percentages
+func logicalCPUCount() int {
+	cpuCount, err := cpu.Counts(true)
+	if err != nil || cpuCount <= 0 {
+		return 1
+	}
+	return cpuCount
 }
@@ -31,6 +31,12 @@ type Collector interface {
 	// GetTopProcesses gets top processes by CPU or memory usage
 	GetTopProcesses(count int, sortBy string) ([]models.ProcessInfo, error)
 
+	// GetProcessDetails gets detailed information about a single process
+	GetProcessDetails(pid int32) (*models.ProcessDetails, error)
+
+	// GetProcessTree gets the process hierarchy with aggregated usage per subtree
+	GetProcessTree() ([]*models.ProcessTreeNode, error)
+
 	// IsHealthy checks if the system is healthy
 	IsHealthy() (bool, []string, error)
 }
@@ -41,6 +47,7 @@ type SystemCollector struct {
 	memoryCollector  *MemoryCollector
 	diskCollector    *DiskCollector
 	processCollector *ProcessCollector
+	limitsCollector  *LimitsCollector
 
 	// Configuration
 	collectInterval time.Duration
@@ -69,6 +76,7 @@ type CollectorConfig struct {
 	EnableDisk      bool          `json:"enable_disk"`
 	EnableNetwork   bool          `json:"enable_network"`
 	EnableProcesses bool          `json:"enable_processes"`
+	EnableLimits    bool          `json:"enable_limits"`
 }
 
 // DefaultCollectorConfig returns default collector configuration
@@ -80,6 +88,7 @@ func DefaultCollectorConfig() *CollectorConfig {
 		EnableDisk:      true,
 		EnableNetwork:   true,
 		EnableProcesses: true,
+		EnableLimits:    true,
 	}
 }
 
@@ -94,6 +103,7 @@ func NewSystemCollector(config *CollectorConfig) *SystemCollector {
 		memoryCollector:  NewMemoryCollector(),
 		diskCollector:    NewDiskCollector(),
 		processCollector: NewProcessCollector(),
+		limitsCollector:  NewLimitsCollector(),
 		collectInterval:  config.CollectInterval,
 		enabledMetrics: map[string]bool{
 			"cpu":       config.EnableCPU,
@@ -101,6 +111,7 @@ func NewSystemCollector(config *CollectorConfig) *SystemCollector {
 			"disk":      config.EnableDisk,
 			"network":   config.EnableNetwork,
 			"processes": config.EnableProcesses,
+			"limits":    config.EnableLimits,
 		},
 		lastCollection:   time.Now(),
 		errors:           make([]error, 0),
@@ -363,6 +374,17 @@ func (sc *SystemCollector) GetSystemMetrics() (*models.SystemMetrics, error) {
 		}
 	}
 
+	// Collect kernel limit usage
+	if sc.enabledMetrics["limits"] && sc.limitsCollector != nil {
+		limitMetrics, err := sc.limitsCollector.GetLimitMetrics()
+		if err != nil {
+			collectErrors = append(collectErrors, fmt.Errorf("failed to collect limit metrics: %w", err))
+			metrics.Limits = models.LimitMetrics{TopFDProcesses: []models.ProcessFDInfo{}}
+		} else if limitMetrics != nil {
+			metrics.Limits = *limitMetrics
+		}
+	}
+
 	// Update collection stats
 	sc.lastCollection = currentTime
 	sc.collectionCount++
@@ -667,6 +689,22 @@ func (sc *SystemCollector) GetTopProcesses(count int, sortBy string) ([]models.P
 	return processes, nil
 }
 
+// GetProcessDetails gets detailed information about a single process
+func (sc *SystemCollector) GetProcessDetails(pid int32) (*models.ProcessDetails, error) {
+	if sc.processCollector == nil {
+		return nil, fmt.Errorf("process collector is not initialized")
+	}
+	return sc.processCollector.GetProcessDetails(pid)
+}
+
+// GetProcessTree gets the process hierarchy with aggregated usage per subtree
+func (sc *SystemCollector) GetProcessTree() ([]*models.ProcessTreeNode, error) {
+	if sc.processCollector == nil {
+		return nil, fmt.Errorf("process collector is not initialized")
+	}
+	return sc.processCollector.GetProcessTree()
+}
+
 // sortProcesses sorts processes by the specified criteria
 func (sc *SystemCollector) sortProcesses(processes []models.ProcessInfo, sortBy string) {
 	switch sortBy {
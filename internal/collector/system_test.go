@@ -2,8 +2,15 @@ package collector
 
 import (
 	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
+
+	"github.com/eyzaun/godash/internal/models"
 )
 
 func TestNewSystemCollector(t *testing.T) {
@@ -272,6 +279,165 @@ func TestSystemCollector_GetTopProcesses(t *testing.T) {
 	}
 }
 
+func TestSystemCollector_GetProcessDetails(t *testing.T) {
+	collector := NewSystemCollector(nil)
+
+	pid := int32(os.Getpid())
+	details, err := collector.GetProcessDetails(pid)
+	if err != nil {
+		t.Fatalf("GetProcessDetails() failed: %v", err)
+	}
+
+	if details.PID != pid {
+		t.Errorf("Expected PID %d, got %d", pid, details.PID)
+	}
+
+	if details.Name == "" {
+		t.Error("Process name should not be empty")
+	}
+
+	if details.NumThreads <= 0 {
+		t.Error("Process should have at least one thread")
+	}
+
+	// Unknown PIDs should be reported as not found
+	if _, err := collector.GetProcessDetails(math.MaxInt32); !errors.Is(err, ErrProcessNotFound) {
+		t.Errorf("Expected ErrProcessNotFound for unknown PID, got %v", err)
+	}
+	if _, err := collector.GetProcessDetails(-1); err == nil {
+		t.Error("Expected error for invalid PID")
+	}
+}
+
+func TestSystemCollector_GetProcessTree(t *testing.T) {
+	collector := NewSystemCollector(nil)
+
+	roots, err := collector.GetProcessTree()
+	if err != nil {
+		t.Fatalf("GetProcessTree() failed: %v", err)
+	}
+
+	if len(roots) == 0 {
+		t.Skip("No processes returned, possibly running in restricted environment")
+	}
+
+	// Subtree totals must include every descendant
+	var check func(node *models.ProcessTreeNode)
+	check = func(node *models.ProcessTreeNode) {
+		count := 1
+		memory := node.MemoryBytes
+		for _, child := range node.Children {
+			if child.PPID != node.PID {
+				t.Errorf("Child %d has PPID %d, expected %d", child.PID, child.PPID, node.PID)
+			}
+			check(child)
+			count += child.ProcessCount
+			memory += child.TotalMemoryBytes
+		}
+		if node.ProcessCount != count {
+			t.Errorf("Process %d: expected subtree count %d, got %d", node.PID, count, node.ProcessCount)
+		}
+		if node.TotalMemoryBytes != memory {
+			t.Errorf("Process %d: expected subtree memory %d, got %d", node.PID, memory, node.TotalMemoryBytes)
+		}
+	}
+	for _, root := range roots {
+		check(root)
+	}
+}
+
+func TestLinkProcessTree_PPIDCycle(t *testing.T) {
+	// 10 and 11 name each other as parent, 12 hangs off the cycle
+	nodes := map[int32]*models.ProcessTreeNode{}
+	for pid, ppid := range map[int32]int32{1: 0, 10: 11, 11: 10, 12: 11} {
+		nodes[pid] = &models.ProcessTreeNode{PID: pid, PPID: ppid, Children: []*models.ProcessTreeNode{}}
+	}
+
+	roots := linkProcessTree(nodes)
+	if len(roots) != 2 {
+		t.Fatalf("Expected 2 roots, got %d", len(roots))
+	}
+
+	total := 0
+	for _, root := range roots {
+		aggregateProcessTree(root)
+		total += root.ProcessCount
+	}
+	if total != len(nodes) {
+		t.Errorf("Expected every process in the tree, got %d of %d", total, len(nodes))
+	}
+}
+
+func TestLimitsCollector_GetLimitMetrics(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("limit metrics are read from /proc on Linux only")
+	}
+
+	// Build a fake proc filesystem
+	root := t.TempDir()
+	files := map[string]string{
+		"sys/fs/file-nr":                       "2000\t0\t10000\n",
+		"sys/kernel/pid_max":                   "4000\n",
+		"sys/kernel/threads-max":               "8000\n",
+		"sys/net/netfilter/nf_conntrack_count": "300\n",
+		"sys/net/netfilter/nf_conntrack_max":   "1000\n",
+		"loadavg":                              "0.10 0.20 0.30 2/400 1234\n",
+		"42/comm":                              "nginx\n",
+		"42/limits":                            "Limit                     Soft Limit           Hard Limit           Units\nMax open files            4                    4096                 files\n",
+		"43/comm":                              "unlimited\n",
+		"43/limits":                            "Max open files            unlimited            unlimited            files\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, fd := range []string{"0", "1", "2"} {
+		path := filepath.Join(root, "42", "fd", fd)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(root, "43", "fd"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	lc := &LimitsCollector{procRoot: root}
+	metrics, err := lc.GetLimitMetrics()
+	if err != nil {
+		t.Fatalf("GetLimitMetrics() failed: %v", err)
+	}
+
+	if metrics.FileHandlesUsed != 2000 || metrics.FileHandlesMax != 10000 || metrics.FileHandlesPercent != 20 {
+		t.Errorf("Unexpected file handle usage: %+v", metrics)
+	}
+	if metrics.Threads != 400 || metrics.PIDMax != 4000 || metrics.PIDPercent != 10 || metrics.ThreadsMax != 8000 {
+		t.Errorf("Unexpected PID/thread usage: %+v", metrics)
+	}
+	if metrics.ConntrackPercent != 30 {
+		t.Errorf("Expected conntrack usage 30%%, got %.2f", metrics.ConntrackPercent)
+	}
+
+	// Processes with an unlimited soft limit are skipped
+	if len(metrics.TopFDProcesses) != 1 {
+		t.Fatalf("Expected 1 FD process, got %d", len(metrics.TopFDProcesses))
+	}
+	fd := metrics.TopFDProcesses[0]
+	if fd.PID != 42 || fd.Name != "nginx" || fd.OpenFDs != 3 || fd.SoftLimit != 4 || fd.Percent != 75 {
+		t.Errorf("Unexpected FD usage: %+v", fd)
+	}
+	if metrics.MaxProcessFDPercent != 75 {
+		t.Errorf("Expected max process FD usage 75%%, got %.2f", metrics.MaxProcessFDPercent)
+	}
+}
+
 func TestSystemCollector_IsHealthy(t *testing.T) {
 	collector := NewSystemCollector(nil)
 
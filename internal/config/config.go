@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,6 +16,8 @@ type Config struct {
 	Alerts   *AlertConfig   `json:"alerts" yaml:"alerts"`
 	Email    *EmailConfig   `json:"email" yaml:"email"`
 	Webhook  *WebhookConfig `json:"webhook" yaml:"webhook"`
+
+	Certificates *CertificateConfig `json:"certificates" yaml:"certificates"`
 }
 
 // ServerConfig holds HTTP server configuration
@@ -55,6 +58,7 @@ type MetricsConfig struct {
 	EnableDisk         bool          `json:"enable_disk" yaml:"enable_disk"`
 	EnableNetwork      bool          `json:"enable_network" yaml:"enable_network"`
 	EnableProcesses    bool          `json:"enable_processes" yaml:"enable_processes"`
+	EnableLimits       bool          `json:"enable_limits" yaml:"enable_limits"`
 	BufferSize         int           `json:"buffer_size" yaml:"buffer_size"`
 }
 
@@ -63,6 +67,18 @@ type AlertConfig struct {
 	EnableAlerts   bool          `json:"enable_alerts" yaml:"enable_alerts"`
 	CheckInterval  time.Duration `json:"check_interval" yaml:"check_interval"`
 	CooldownPeriod time.Duration `json:"cooldown_period" yaml:"cooldown_period"`
+
+	// Notification grouping; GroupBy applies to alerts no notification route matches
+	GroupBy       []string      `json:"group_by" yaml:"group_by"`
+	GroupWait     time.Duration `json:"group_wait" yaml:"group_wait"`
+	GroupInterval time.Duration `json:"group_interval" yaml:"group_interval"`
+
+	// Flap detection: alerts changing state FlapThreshold times within FlapWindow are flapping
+	FlapThreshold int           `json:"flap_threshold" yaml:"flap_threshold"` // 0 disables flap detection
+	FlapWindow    time.Duration `json:"flap_window" yaml:"flap_window"`
+
+	// Directory of YAML/JSON alert rule files reconciled with the alerts at startup (empty = disabled)
+	RulesDir string `json:"rules_dir" yaml:"rules_dir"`
 }
 
 // EmailConfig holds email notification configuration
@@ -84,6 +100,14 @@ type WebhookConfig struct {
 	RetryDelay     time.Duration `json:"retry_delay" yaml:"retry_delay"`
 }
 
+// CertificateConfig holds TLS certificate monitoring configuration
+type CertificateConfig struct {
+	Files         []string      `json:"files" yaml:"files"`         // PEM files to inspect
+	Endpoints     []string      `json:"endpoints" yaml:"endpoints"` // TLS endpoints (host:port) to inspect
+	CheckInterval time.Duration `json:"check_interval" yaml:"check_interval"`
+	Timeout       time.Duration `json:"timeout" yaml:"timeout"`
+}
+
 // Load loads configuration from environment variables with defaults
 func Load() (*Config, error) {
 	config := &Config{
@@ -93,6 +117,8 @@ func Load() (*Config, error) {
 		Alerts:   loadAlertConfig(),
 		Email:    loadEmailConfig(),
 		Webhook:  loadWebhookConfig(),
+
+		Certificates: loadCertificateConfig(),
 	}
 
 	// Validate configuration
@@ -144,6 +170,7 @@ func loadMetricsConfig() MetricsConfig {
 		EnableDisk:         getEnvBool("METRICS_ENABLE_DISK", true),
 		EnableNetwork:      getEnvBool("METRICS_ENABLE_NETWORK", true),
 		EnableProcesses:    getEnvBool("METRICS_ENABLE_PROCESSES", true),
+		EnableLimits:       getEnvBool("METRICS_ENABLE_LIMITS", true),
 		BufferSize:         getEnvInt("METRICS_BUFFER_SIZE", 100),
 	}
 }
@@ -154,6 +181,12 @@ func loadAlertConfig() *AlertConfig {
 		EnableAlerts:   getEnvBool("ALERTS_ENABLE", true),
 		CheckInterval:  getEnvDuration("ALERTS_CHECK_INTERVAL", 30*time.Second),
 		CooldownPeriod: getEnvDuration("ALERTS_COOLDOWN_PERIOD", 5*time.Minute),
+		GroupBy:        getEnvList("ALERTS_GROUP_BY"),
+		GroupWait:      getEnvDuration("ALERTS_GROUP_WAIT", 30*time.Second),
+		GroupInterval:  getEnvDuration("ALERTS_GROUP_INTERVAL", 5*time.Minute),
+		FlapThreshold:  getEnvInt("ALERTS_FLAP_THRESHOLD", 6),
+		FlapWindow:     getEnvDuration("ALERTS_FLAP_WINDOW", 30*time.Minute),
+		RulesDir:       getEnvString("ALERTS_RULES_DIR", ""),
 	}
 }
 
@@ -180,6 +213,16 @@ func loadWebhookConfig() *WebhookConfig {
 	}
 }
 
+// loadCertificateConfig loads certificate monitoring configuration from environment variables
+func loadCertificateConfig() *CertificateConfig {
+	return &CertificateConfig{
+		Files:         getEnvList("CERT_FILES"),
+		Endpoints:     getEnvList("CERT_ENDPOINTS"),
+		CheckInterval: getEnvDuration("CERT_CHECK_INTERVAL", time.Hour),
+		Timeout:       getEnvDuration("CERT_TIMEOUT", 10*time.Second),
+	}
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	// Validate server configuration
@@ -229,6 +272,18 @@ func (c *Config) Validate() error {
 		if c.Alerts.CooldownPeriod < 0 {
 			return fmt.Errorf("alert cooldown period cannot be negative")
 		}
+
+		if c.Alerts.GroupWait < 0 || c.Alerts.GroupInterval < 0 {
+			return fmt.Errorf("alert group wait and interval cannot be negative")
+		}
+
+		if c.Alerts.FlapThreshold < 0 {
+			return fmt.Errorf("alert flap threshold cannot be negative")
+		}
+
+		if c.Alerts.FlapThreshold > 0 && c.Alerts.FlapWindow <= 0 {
+			return fmt.Errorf("alert flap window must be positive when flap detection is enabled")
+		}
 	}
 
 	// Validate email configuration
@@ -246,6 +301,17 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate certificate configuration
+	if c.Certificates != nil {
+		if c.Certificates.CheckInterval < time.Minute {
+			return fmt.Errorf("certificate check interval must be at least 1 minute")
+		}
+
+		if c.Certificates.Timeout <= 0 {
+			return fmt.Errorf("certificate timeout must be positive")
+		}
+	}
+
 	// Validate webhook configuration
 	if c.Webhook != nil {
 		if c.Webhook.DefaultTimeout <= 0 {
@@ -324,3 +390,14 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getEnvList parses a comma-separated environment variable, skipping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
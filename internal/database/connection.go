@@ -126,6 +126,56 @@ func (d *Database) AutoMigrate() error {
 		return fmt.Errorf("failed to migrate AlertHistory model: %w", err)
 	}
 
+	log.Println("Migrating IncidentEvent model...")
+	if err := d.DB.AutoMigrate(&models.IncidentEvent{}); err != nil {
+		return fmt.Errorf("failed to migrate IncidentEvent model: %w", err)
+	}
+
+	log.Println("Migrating AlertInstance model...")
+	if err := d.DB.AutoMigrate(&models.AlertInstance{}); err != nil {
+		return fmt.Errorf("failed to migrate AlertInstance model: %w", err)
+	}
+
+	log.Println("Migrating NotificationChannel model...")
+	if err := d.DB.AutoMigrate(&models.NotificationChannel{}); err != nil {
+		return fmt.Errorf("failed to migrate NotificationChannel model: %w", err)
+	}
+
+	log.Println("Migrating NotificationRoute model...")
+	if err := d.DB.AutoMigrate(&models.NotificationRoute{}); err != nil {
+		return fmt.Errorf("failed to migrate NotificationRoute model: %w", err)
+	}
+
+	log.Println("Migrating NotificationTemplate model...")
+	if err := d.DB.AutoMigrate(&models.NotificationTemplate{}); err != nil {
+		return fmt.Errorf("failed to migrate NotificationTemplate model: %w", err)
+	}
+
+	log.Println("Migrating HostMetadata model...")
+	if err := d.DB.AutoMigrate(&models.HostMetadata{}); err != nil {
+		return fmt.Errorf("failed to migrate HostMetadata model: %w", err)
+	}
+
+	log.Println("Migrating EscalationPolicy model...")
+	if err := d.DB.AutoMigrate(&models.EscalationPolicy{}); err != nil {
+		return fmt.Errorf("failed to migrate EscalationPolicy model: %w", err)
+	}
+
+	log.Println("Migrating Silence model...")
+	if err := d.DB.AutoMigrate(&models.Silence{}); err != nil {
+		return fmt.Errorf("failed to migrate Silence model: %w", err)
+	}
+
+	log.Println("Migrating Probe model...")
+	if err := d.DB.AutoMigrate(&models.Probe{}); err != nil {
+		return fmt.Errorf("failed to migrate Probe model: %w", err)
+	}
+
+	log.Println("Migrating ProbeResult model...")
+	if err := d.DB.AutoMigrate(&models.ProbeResult{}); err != nil {
+		return fmt.Errorf("failed to migrate ProbeResult model: %w", err)
+	}
+
 	// Add missing speed columns manually if they don't exist (both drivers)
 	log.Println("Adding missing speed columns if needed...")
 	if err := d.addMissingSpeedColumns(); err != nil {
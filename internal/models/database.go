@@ -63,6 +63,13 @@ type Metric struct {
 	NetworkUploadSpeed   float64 `json:"network_upload_speed_mbps" gorm:"column:network_upload_speed_mbps"`     // Mbps
 	NetworkDownloadSpeed float64 `json:"network_download_speed_mbps" gorm:"column:network_download_speed_mbps"` // Mbps
 
+	// Kernel limit metrics
+	FileHandlesPercent  float64 `json:"file_handles_percent"`
+	PIDPercent          float64 `json:"pid_percent"`
+	Threads             uint64  `json:"threads"`
+	ConntrackPercent    float64 `json:"conntrack_percent"`
+	MaxProcessFDPercent float64 `json:"max_process_fd_percent"`
+
 	// System info
 	Platform        string        `json:"platform"`
 	PlatformVersion string        `json:"platform_version"`
@@ -119,6 +126,13 @@ func ConvertSystemMetricsToDBMetric(sm *SystemMetrics) *Metric {
 		NetworkUploadSpeed:   sm.Network.UploadSpeed,
 		NetworkDownloadSpeed: sm.Network.DownloadSpeed,
 
+		// Kernel limit metrics
+		FileHandlesPercent:  sm.Limits.FileHandlesPercent,
+		PIDPercent:          sm.Limits.PIDPercent,
+		Threads:             sm.Limits.Threads,
+		ConntrackPercent:    sm.Limits.ConntrackPercent,
+		MaxProcessFDPercent: sm.Limits.MaxProcessFDPercent,
+
 		// System info
 		Platform: "Unknown", // Will be filled by system info
 		Uptime:   sm.Uptime,
@@ -147,6 +161,65 @@ func ConvertSystemMetricsToDBMetric(sm *SystemMetrics) *Metric {
 	return metric
 }
 
+// ConvertDBMetricToSystemMetrics converts a stored Metric back to SystemMetrics.
+// Only fields persisted in the metrics table are filled in.
+func ConvertDBMetricToSystemMetrics(m *Metric) *SystemMetrics {
+	return &SystemMetrics{
+		Hostname:  m.Hostname,
+		Timestamp: m.Timestamp,
+		Uptime:    m.Uptime,
+		CPU: CPUMetrics{
+			Usage:     m.CPUUsage,
+			Cores:     m.CPUCores,
+			Frequency: m.CPUFrequency,
+			LoadAvg:   []float64{m.CPULoadAvg1, m.CPULoadAvg5, m.CPULoadAvg15},
+		},
+		Memory: MemoryMetrics{
+			Total:       m.MemoryTotal,
+			Used:        m.MemoryUsed,
+			Available:   m.MemoryAvailable,
+			Free:        m.MemoryFree,
+			Cached:      m.MemoryCached,
+			Buffers:     m.MemoryBuffers,
+			Percent:     m.MemoryPercent,
+			SwapTotal:   m.MemorySwapTotal,
+			SwapUsed:    m.MemorySwapUsed,
+			SwapPercent: m.MemorySwapPercent,
+		},
+		Disk: DiskMetrics{
+			Total:      m.DiskTotal,
+			Used:       m.DiskUsed,
+			Free:       m.DiskFree,
+			Percent:    m.DiskPercent,
+			ReadSpeed:  m.DiskReadSpeed,
+			WriteSpeed: m.DiskWriteSpeed,
+		},
+		Network: NetworkMetrics{
+			Interfaces: []NetworkInterface{{
+				Name:        "total",
+				PacketsSent: m.NetworkPacketsSent,
+				PacketsRecv: m.NetworkPacketsRecv,
+				Errors:      m.NetworkErrors,
+				Drops:       m.NetworkDrops,
+			}},
+			TotalSent:     m.NetworkTotalSent,
+			TotalReceived: m.NetworkTotalReceived,
+			UploadSpeed:   m.NetworkUploadSpeed,
+			DownloadSpeed: m.NetworkDownloadSpeed,
+		},
+		Processes: ProcessActivity{
+			TotalProcesses: int(m.ProcessCount),
+		},
+		Limits: LimitMetrics{
+			FileHandlesPercent:  m.FileHandlesPercent,
+			PIDPercent:          m.PIDPercent,
+			Threads:             m.Threads,
+			ConntrackPercent:    m.ConntrackPercent,
+			MaxProcessFDPercent: m.MaxProcessFDPercent,
+		},
+	}
+}
+
 // DBSystemInfo represents system information in the database
 type DBSystemInfo struct {
 	BaseModel
@@ -181,12 +254,72 @@ type Alert struct {
 	IsActive    bool    `json:"is_active" gorm:"default:true;index"`
 	Description string  `json:"description"`
 
+	// Severity levels, ordered from least to most severe. The first level is Threshold and
+	// Severity; firing instances take the severity of the highest level the value meets.
+	Levels []SeverityLevel `json:"levels" gorm:"serializer:json;type:text"`
+
+	// Host scoping for alerts evaluated per host: which hosts the alert applies to, and
+	// thresholds replacing Threshold and Levels on some of them
+	HostSelector  HostSelector   `json:"host_selector" gorm:"serializer:json;type:text"`
+	HostOverrides []HostOverride `json:"host_overrides" gorm:"serializer:json;type:text"`
+
+	// Hysteresis: a firing alert only resolves once the value no longer meets the condition
+	// against ClearThreshold, e.g. fire above 90 and clear below 80 (nil = Threshold)
+	ClearThreshold *float64 `json:"clear_threshold"`
+
+	// Free-form key/value labels, matched by silences
+	Labels map[string]string `json:"labels" gorm:"serializer:json;type:text"`
+
+	// Window aggregation (avg, max, p95, rate, ...) applied to the metric over Window seconds; empty = instantaneous
+	Aggregation string `json:"aggregation"`
+
+	// Expression settings (expression metric type)
+	Expression string `json:"expression" gorm:"type:text"` // Boolean rule such as "cpu > 90 and load_avg_5 > cores * 2"
+
+	// Anomaly detection settings (anomaly metric type); Threshold is the allowed deviation in standard deviations
+	AnomalyField     string `json:"anomaly_field"`     // Metric field to baseline, e.g. cpu
+	AnomalyMethod    string `json:"anomaly_method"`    // ewma (z-score) or mad
+	Seasonality      string `json:"seasonality"`       // none, daily or weekly
+	AnomalyDirection string `json:"anomaly_direction"` // both, above or below
+
+	// No-data settings (no_data metric type); Threshold is the allowed silence in seconds
+	AbsentMetric string `json:"absent_metric"` // Metric group that must keep reporting (empty = any sample)
+
+	// Disk forecast settings (disk_forecast metric type)
+	Mountpoint string `json:"mountpoint"` // Partition to forecast (empty = all partitions)
+
+	// Probe settings (probe_* metric types)
+	ProbeID uint `json:"probe_id" gorm:"index"` // Probe to evaluate (0 = all probes)
+
+	// Certificate settings (cert_expiry_days metric type)
+	CertificateFilter string `json:"certificate_filter"` // Substring of the certificate source, CN or SAN (empty = all)
+
+	// Process watch settings (process_* metric types)
+	ProcessPattern string `json:"process_pattern"`                    // Process name or regex to match
+	ProcessRegex   bool   `json:"process_regex" gorm:"default:false"` // Treat ProcessPattern as a regex
+	Window         int    `json:"window"`                             // Evaluation window in seconds (process restarts and aggregations)
+
 	// Notification settings
 	EmailEnabled    bool   `json:"email_enabled" gorm:"default:false"`
 	EmailRecipients string `json:"email_recipients"`
 	WebhookEnabled  bool   `json:"webhook_enabled" gorm:"default:false"`
 	WebhookURL      string `json:"webhook_url"`
 
+	// Escalation policy deciding who is notified while an incident stays unacknowledged
+	// (0 = notify the channels above once per cooldown)
+	EscalationPolicyID uint `json:"escalation_policy_id" gorm:"index"`
+
+	// Opt out of notifications about resolved incidents
+	SkipResolved bool `json:"skip_resolved" gorm:"default:false"`
+
+	// Message templates by channel type (email, webhook, slack or discord), overriding the
+	// templates of the channel type field by field
+	Templates map[string]MessageTemplates `json:"templates" gorm:"serializer:json;type:text"`
+
+	// Owner of an alert defined as code, e.g. rules_dir for the rules directory, which deletes
+	// the alert once its rule is removed (empty = managed through the API)
+	ManagedBy string `json:"managed_by" gorm:"index"`
+
 	// Alert statistics
 	TriggeredCount int       `json:"triggered_count" gorm:"default:0"`
 	LastTriggered  time.Time `json:"last_triggered"`
@@ -197,6 +330,42 @@ func (Alert) TableName() string {
 	return "alerts"
 }
 
+// SeverityLevel is a threshold of a multi-level alert and the severity it fires with
+type SeverityLevel struct {
+	Severity  string  `json:"severity"` // info, warning or critical
+	Threshold float64 `json:"threshold"`
+}
+
+// HostSelector limits an alert to some hosts. A host must match an include entry (when
+// there are any) and every label matcher, and no exclude entry. Entries are hostnames or
+// globs such as db-*.
+type HostSelector struct {
+	Include []string       `json:"include,omitempty"`
+	Exclude []string       `json:"exclude,omitempty"`
+	Labels  []AlertMatcher `json:"labels,omitempty"` // Matched against host metadata labels
+}
+
+// HostOverride replaces the thresholds of an alert on the hosts it matches
+type HostOverride struct {
+	Host      string          `json:"host"` // Hostname or glob
+	Threshold float64         `json:"threshold"`
+	Levels    []SeverityLevel `json:"levels,omitempty"` // Required for multi-level alerts
+}
+
+// HostMetadata holds the labels of a host, matched by alert host selectors
+type HostMetadata struct {
+	BaseModel
+
+	Hostname    string            `json:"hostname" gorm:"not null;uniqueIndex;size:255"`
+	Labels      map[string]string `json:"labels" gorm:"serializer:json;type:text"`
+	Description string            `json:"description"`
+}
+
+// TableName specifies the table name for HostMetadata model
+func (HostMetadata) TableName() string {
+	return "host_metadata"
+}
+
 // AlertHistory represents triggered alerts history
 type AlertHistory struct {
 	BaseModel
@@ -208,9 +377,16 @@ type AlertHistory struct {
 	Threshold   float64   `json:"threshold"`
 	Severity    string    `json:"severity" gorm:"index"`
 	Message     string    `json:"message"`
+	PeakValue   float64   `json:"peak_value"` // Value furthest past the threshold while firing
 	Resolved    bool      `json:"resolved" gorm:"default:false;index"`
 	ResolvedAt  time.Time `json:"resolved_at"`
 
+	// On-call workflow
+	Acknowledged   bool      `json:"acknowledged" gorm:"default:false;index"`
+	AcknowledgedBy string    `json:"acknowledged_by"`
+	AcknowledgedAt time.Time `json:"acknowledged_at"`
+	Assignee       string    `json:"assignee" gorm:"index"`
+
 	// Notification status
 	EmailSent   bool `json:"email_sent" gorm:"default:false"`
 	WebhookSent bool `json:"webhook_sent" gorm:"default:false"`
@@ -221,6 +397,241 @@ func (AlertHistory) TableName() string {
 	return "alert_history"
 }
 
+// IncidentEvent is one entry of an incident's timeline: an acknowledgement, comment,
+// assignment, escalation or resolution of an alert history entry
+type IncidentEvent struct {
+	BaseModel
+
+	HistoryID uint   `json:"history_id" gorm:"index"`
+	Type      string `json:"type" gorm:"size:20"` // ack, unack, assign, comment, escalate or resolve
+	User      string `json:"user"`
+	Comment   string `json:"comment"`
+}
+
+// TableName specifies the table name for IncidentEvent model
+func (IncidentEvent) TableName() string {
+	return "incident_events"
+}
+
+// AlertInstance is the evaluation state of an alert rule on one host.
+// It survives restarts so cooldowns and pending durations are not lost.
+type AlertInstance struct {
+	BaseModel
+
+	AlertID         uint      `json:"alert_id" gorm:"uniqueIndex:idx_alert_instance"`
+	Alert           Alert     `json:"alert" gorm:"foreignKey:AlertID"`
+	Hostname        string    `json:"hostname" gorm:"uniqueIndex:idx_alert_instance;size:255"`
+	State           string    `json:"state" gorm:"index;size:20"` // inactive, pending, firing or resolved
+	Since           time.Time `json:"since"`                      // When the instance entered its current state
+	HistoryID       uint      `json:"history_id"`                 // Alert history entry of the current or last incident
+	LastValue       float64   `json:"last_value"`
+	LastEvaluatedAt time.Time `json:"last_evaluated_at"`
+	LastNotifiedAt  time.Time `json:"last_notified_at"`
+	Acknowledged    bool      `json:"acknowledged"`     // Repeat notifications of the incident are stopped
+	EscalationLevel int       `json:"escalation_level"` // Escalation policy steps notified for the incident
+	LastEscalatedAt time.Time `json:"last_escalated_at"`
+	Severity        string    `json:"severity" gorm:"size:20"` // Severity of the firing incident, for multi-level alerts
+	PeakValue       float64   `json:"peak_value"`              // Value furthest past the threshold during the incident
+
+	// Severity level changes take effect once the new level held for the alert duration
+	PendingSeverity      string    `json:"pending_severity,omitempty" gorm:"size:20"`
+	PendingSeveritySince time.Time `json:"pending_severity_since"`
+
+	// Flap detection
+	StateChanges []time.Time `json:"-" gorm:"serializer:json;type:text"` // Recent firing and resolve transitions
+	Flapping     bool        `json:"flapping"`                           // Notifications are suppressed until the instance stabilizes
+}
+
+// TableName specifies the table name for AlertInstance model
+func (AlertInstance) TableName() string {
+	return "alert_instances"
+}
+
+// NotificationChannel is a reusable notification destination alerts are routed to
+type NotificationChannel struct {
+	BaseModel
+
+	Name    string                    `json:"name" gorm:"uniqueIndex;size:255"`
+	Type    string                    `json:"type" gorm:"size:20"` // email, webhook, slack or discord
+	Config  NotificationChannelConfig `json:"config" gorm:"serializer:json;type:text"`
+	Enabled bool                      `json:"enabled" gorm:"default:true"`
+}
+
+// NotificationChannelConfig holds the type specific settings of a notification channel
+type NotificationChannelConfig struct {
+	Recipients string `json:"recipients,omitempty"` // Comma separated email addresses (email)
+	URL        string `json:"url,omitempty"`        // Webhook URL (webhook, slack and discord)
+
+	// Request settings of webhook channels
+	Method  string            `json:"method,omitempty"`  // HTTP method: POST, PUT or PATCH (empty = POST)
+	Headers map[string]string `json:"headers,omitempty"` // Extra request headers
+	Body    string            `json:"body,omitempty"`    // Go template of the JSON body, overriding the webhook template
+	Auth    *WebhookAuth      `json:"auth,omitempty"`
+	Secret  string            `json:"secret,omitempty"` // Signs requests with HMAC-SHA256 when set
+}
+
+// WebhookAuth holds the credentials a webhook channel authenticates with
+type WebhookAuth struct {
+	Type     string `json:"type"`               // basic or bearer
+	Username string `json:"username,omitempty"` // basic
+	Password string `json:"password,omitempty"` // basic
+	Token    string `json:"token,omitempty"`    // bearer
+}
+
+// TableName specifies the table name for NotificationChannel model
+func (NotificationChannel) TableName() string {
+	return "notification_channels"
+}
+
+// NotificationRoute sends alerts matching all of its matchers to its channels. Routes are
+// evaluated by ascending position; the first match wins unless it sets Continue. Routes with
+// GroupBy keys batch alerts sharing the same key values into one summary notification.
+type NotificationRoute struct {
+	BaseModel
+
+	Name       string         `json:"name"`
+	Position   int            `json:"position" gorm:"index"`
+	Matchers   []AlertMatcher `json:"matchers" gorm:"serializer:json;type:text"` // No matchers = every alert
+	ChannelIDs []uint         `json:"channel_ids" gorm:"serializer:json;type:text"`
+	Continue   bool           `json:"continue"` // Keep evaluating later routes after this one matched
+	Enabled    bool           `json:"enabled" gorm:"default:true"`
+
+	// Notification grouping
+	GroupBy       []string `json:"group_by" gorm:"serializer:json;type:text"` // alertname, severity, hostname or alert labels, e.g. a host group label (empty = no grouping)
+	GroupWait     int      `json:"group_wait"`                                // Seconds to collect members before the first notification (0 = config default)
+	GroupInterval int      `json:"group_interval"`                            // Minimum seconds between updates of a group (0 = config default)
+}
+
+// TableName specifies the table name for NotificationRoute model
+func (NotificationRoute) TableName() string {
+	return "notification_routes"
+}
+
+// MessageTemplates holds the Go templates of a notification. Empty templates keep the
+// built-in layout.
+type MessageTemplates struct {
+	Subject string `json:"subject,omitempty" gorm:"type:text"` // Email subject
+	Text    string `json:"text,omitempty" gorm:"type:text"`    // Email text body, Slack text or Discord content
+	HTML    string `json:"html,omitempty" gorm:"type:text"`    // Email HTML body
+	Body    string `json:"body,omitempty" gorm:"type:text"`    // JSON request body of webhook, Slack and Discord channels
+}
+
+// NotificationTemplate holds the message templates of a notification channel type
+type NotificationTemplate struct {
+	BaseModel
+
+	ChannelType string `json:"channel_type" gorm:"uniqueIndex;size:20"`
+	MessageTemplates
+}
+
+// TableName specifies the table name for NotificationTemplate model
+func (NotificationTemplate) TableName() string {
+	return "notification_templates"
+}
+
+// EscalationPolicy is an ordered list of notification steps for incidents that stay
+// unacknowledged. Each step is notified once its delay since the incident opened has
+// passed; after the last step, the last step repeats every RepeatInterval seconds.
+type EscalationPolicy struct {
+	BaseModel
+
+	Name           string           `json:"name" gorm:"uniqueIndex;size:255"`
+	Description    string           `json:"description"`
+	Steps          []EscalationStep `json:"steps" gorm:"serializer:json;type:text"`
+	RepeatInterval int              `json:"repeat_interval"` // Seconds between repeats of the last step (0 = no repeats)
+}
+
+// EscalationStep notifies one channel of an escalation policy: a notification channel,
+// or the email or webhook settings of the alert
+type EscalationStep struct {
+	After     int    `json:"after"`      // Seconds after the incident opened
+	ChannelID uint   `json:"channel_id"` // Notification channel to notify (0 = use Channel)
+	Channel   string `json:"channel"`    // email or webhook
+	Target    string `json:"target"`     // Email recipients or webhook URL (empty = the alert's)
+}
+
+// TableName specifies the table name for EscalationPolicy model
+func (EscalationPolicy) TableName() string {
+	return "escalation_policies"
+}
+
+// Silence suppresses notifications of alerts matching all of its matchers. A silence
+// without a schedule applies once between StartsAt and EndsAt; with a schedule it is a
+// recurring maintenance window of Duration seconds starting whenever the cron schedule
+// matches, valid between StartsAt and EndsAt (zero EndsAt = indefinitely).
+type Silence struct {
+	BaseModel
+
+	Matchers  []AlertMatcher `json:"matchers" gorm:"serializer:json;type:text"`
+	StartsAt  time.Time      `json:"starts_at" gorm:"index"`
+	EndsAt    time.Time      `json:"ends_at" gorm:"index"`
+	Schedule  string         `json:"schedule"` // Cron schedule (minute hour day month weekday) of maintenance windows
+	Duration  int            `json:"duration"` // Maintenance window length in seconds
+	Timezone  string         `json:"timezone"` // Time zone of the schedule (empty = server local time)
+	CreatedBy string         `json:"created_by"`
+	Comment   string         `json:"comment"`
+}
+
+// TableName specifies the table name for Silence model
+func (Silence) TableName() string {
+	return "silences"
+}
+
+// AlertMatcher matches an alert name, severity, hostname or alert label against a value.
+// Silences and notification routes apply to alerts matching all of their matchers.
+type AlertMatcher struct {
+	Name    string `json:"name"` // alertname, severity, hostname or a label name
+	Value   string `json:"value"`
+	IsRegex bool   `json:"is_regex"`
+}
+
+// Probe represents a synthetic HTTP, TCP or DNS check
+type Probe struct {
+	BaseModel
+
+	Name     string `json:"name" gorm:"not null;uniqueIndex"`
+	Type     string `json:"type" gorm:"index"` // http, tcp or dns
+	Target   string `json:"target"`            // URL, host:port or hostname
+	Interval int    `json:"interval"`          // Seconds between checks
+	Timeout  int    `json:"timeout"`           // Seconds before a check fails
+	Enabled  bool   `json:"enabled" gorm:"default:true;index"`
+
+	// HTTP settings
+	ExpectedStatus int    `json:"expected_status"` // Expected status code (0 = any 2xx/3xx)
+	BodyMatch      string `json:"body_match"`      // Regex the response body must match
+	TLSExpiryDays  int    `json:"tls_expiry_days"` // Fail when the certificate expires within this many days (0 = disabled)
+	SkipTLSVerify  bool   `json:"skip_tls_verify"` // Accept self-signed or otherwise untrusted certificates
+
+	// Last check status
+	LastCheck     time.Time `json:"last_check"`
+	LastSuccess   bool      `json:"last_success"`
+	LastLatencyMs float64   `json:"last_latency_ms"`
+	LastError     string    `json:"last_error"`
+}
+
+// TableName specifies the table name for Probe model
+func (Probe) TableName() string {
+	return "probes"
+}
+
+// ProbeResult represents the outcome of a single probe check
+type ProbeResult struct {
+	BaseModel
+
+	ProbeID       uint      `json:"probe_id" gorm:"index"`
+	Timestamp     time.Time `json:"timestamp" gorm:"index"`
+	Success       bool      `json:"success"`
+	LatencyMs     float64   `json:"latency_ms"`
+	StatusCode    int       `json:"status_code"`     // HTTP status code (HTTP probes only)
+	TLSExpiryDays float64   `json:"tls_expiry_days"` // Days until the certificate expires (HTTPS probes only)
+	Error         string    `json:"error"`
+}
+
+// TableName specifies the table name for ProbeResult model
+func (ProbeResult) TableName() string {
+	return "probe_results"
+}
+
 // Additional models for repository responses
 
 // AverageMetrics represents average resource usage over time (SPEED SUPPORT ADDED)
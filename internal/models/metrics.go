@@ -11,6 +11,7 @@ type SystemMetrics struct {
 	Disk      DiskMetrics     `json:"disk"`
 	Network   NetworkMetrics  `json:"network"`
 	Processes ProcessActivity `json:"processes"`
+	Limits    LimitMetrics    `json:"limits"`
 	Timestamp time.Time       `json:"timestamp"`
 	Hostname  string          `json:"hostname"`
 	Uptime    time.Duration   `json:"uptime"`
@@ -33,6 +34,34 @@ type ProcessActivity struct {
 	StoppedProcesses int           `json:"stopped_processes"`
 	ZombieProcesses  int           `json:"zombie_processes"`
 	TopProcesses     []ProcessInfo `json:"top_processes"`
+	// All processes (per PID) for process watch alerts; not sent to clients
+	Processes []ProcessInfo `json:"-"`
+}
+
+// LimitMetrics represents usage of kernel resource limits
+type LimitMetrics struct {
+	FileHandlesUsed     uint64          `json:"file_handles_used"`      // Allocated file handles
+	FileHandlesMax      uint64          `json:"file_handles_max"`       // fs.file-max
+	FileHandlesPercent  float64         `json:"file_handles_percent"`   // File handle usage percentage
+	PIDsUsed            uint64          `json:"pids_used"`              // PIDs in use (processes and threads)
+	PIDMax              uint64          `json:"pid_max"`                // kernel.pid_max
+	PIDPercent          float64         `json:"pid_percent"`            // PID usage percentage
+	Threads             uint64          `json:"threads"`                // Total threads on the host
+	ThreadsMax          uint64          `json:"threads_max"`            // kernel.threads-max
+	ConntrackCount      uint64          `json:"conntrack_count"`        // Tracked connections
+	ConntrackMax        uint64          `json:"conntrack_max"`          // nf_conntrack_max
+	ConntrackPercent    float64         `json:"conntrack_percent"`      // Conntrack table fill percentage
+	MaxProcessFDPercent float64         `json:"max_process_fd_percent"` // Highest per-process FD usage versus its soft limit
+	TopFDProcesses      []ProcessFDInfo `json:"top_fd_processes"`       // Processes closest to their FD limit
+}
+
+// ProcessFDInfo represents a process's open file descriptors versus RLIMIT_NOFILE
+type ProcessFDInfo struct {
+	PID       int32   `json:"pid"`        // Process ID
+	Name      string  `json:"name"`       // Process name
+	OpenFDs   uint64  `json:"open_fds"`   // Open file descriptors
+	SoftLimit uint64  `json:"soft_limit"` // RLIMIT_NOFILE soft limit
+	Percent   float64 `json:"percent"`    // Usage percentage
 }
 
 // MemoryMetrics represents memory usage information
@@ -114,11 +143,75 @@ type NetworkInterface struct {
 
 // ProcessInfo represents individual process information
 type ProcessInfo struct {
-	PID         int32   `json:"pid"`          // Process ID
-	Name        string  `json:"name"`         // Process name
-	CPUPercent  float64 `json:"cpu_percent"`  // CPU usage percentage
-	MemoryBytes uint64  `json:"memory_bytes"` // Memory usage in bytes
-	Status      string  `json:"status"`       // Process status
+	PID         int32     `json:"pid"`          // Process ID
+	Name        string    `json:"name"`         // Process name
+	CPUPercent  float64   `json:"cpu_percent"`  // CPU usage percentage
+	MemoryBytes uint64    `json:"memory_bytes"` // Memory usage in bytes
+	Status      string    `json:"status"`       // Process status
+	StartTime   time.Time `json:"start_time"`   // Process start time
+}
+
+// ProcessDetails represents detailed information about a single process
+type ProcessDetails struct {
+	PID          int32          `json:"pid"`           // Process ID
+	PPID         int32          `json:"ppid"`          // Parent process ID
+	Name         string         `json:"name"`          // Process name
+	Exe          string         `json:"exe"`           // Executable path
+	Cmdline      string         `json:"cmdline"`       // Full command line
+	Username     string         `json:"username"`      // Owning user
+	Status       string         `json:"status"`        // Process status
+	StartTime    time.Time      `json:"start_time"`    // Process start time
+	CPUPercent   float64        `json:"cpu_percent"`   // CPU usage percentage
+	MemoryBytes  uint64         `json:"memory_bytes"`  // Resident memory in bytes
+	NumThreads   int32          `json:"num_threads"`   // Number of threads
+	OpenFiles    int            `json:"open_files"`    // Number of open files
+	Connections  int            `json:"connections"`   // Number of network connections
+	Cgroup       string         `json:"cgroup"`        // Control group (Linux only)
+	EnvironCount int            `json:"environ_count"` // Number of environment variables
+	EnvironBytes int            `json:"environ_bytes"` // Total size of the environment in bytes
+	IOCounters   ProcessIOStats `json:"io_counters"`   // Process I/O counters
+	Parent       *ProcessInfo   `json:"parent"`        // Parent process (nil for roots)
+	Children     []ProcessInfo  `json:"children"`      // Direct child processes
+}
+
+// ProcessIOStats represents per-process I/O counters
+type ProcessIOStats struct {
+	ReadCount  uint64 `json:"read_count"`  // Read operations
+	WriteCount uint64 `json:"write_count"` // Write operations
+	ReadBytes  uint64 `json:"read_bytes"`  // Bytes read
+	WriteBytes uint64 `json:"write_bytes"` // Bytes written
+}
+
+// ProcessTreeNode represents a process and its descendants with aggregated usage
+type ProcessTreeNode struct {
+	PID              int32              `json:"pid"`                // Process ID
+	PPID             int32              `json:"ppid"`               // Parent process ID
+	Name             string             `json:"name"`               // Process name
+	Status           string             `json:"status"`             // Process status
+	CPUPercent       float64            `json:"cpu_percent"`        // CPU usage of this process
+	MemoryBytes      uint64             `json:"memory_bytes"`       // Memory usage of this process
+	TotalCPUPercent  float64            `json:"total_cpu_percent"`  // CPU usage of the whole subtree
+	TotalMemoryBytes uint64             `json:"total_memory_bytes"` // Memory usage of the whole subtree
+	ProcessCount     int                `json:"process_count"`      // Number of processes in the subtree
+	Children         []*ProcessTreeNode `json:"children"`           // Child processes
+}
+
+// CertificateInfo represents an inspected TLS certificate
+type CertificateInfo struct {
+	Name            string    `json:"name"`              // Unique identifier (source, plus index for multi-certificate files)
+	Source          string    `json:"source"`            // File path or host:port
+	SourceType      string    `json:"source_type"`       // file or endpoint
+	Subject         string    `json:"subject"`           // Certificate subject
+	CommonName      string    `json:"common_name"`       // Subject common name
+	SANs            []string  `json:"sans"`              // Subject alternative names (DNS names, IPs, emails, URIs)
+	Issuer          string    `json:"issuer"`            // Certificate issuer
+	SerialNumber    string    `json:"serial_number"`     // Serial number (hex)
+	NotBefore       time.Time `json:"not_before"`        // Start of validity
+	NotAfter        time.Time `json:"not_after"`         // End of validity
+	DaysUntilExpiry float64   `json:"days_until_expiry"` // Days until NotAfter (negative once expired)
+	Expired         bool      `json:"expired"`           // Whether the certificate has expired
+	Error           string    `json:"error,omitempty"`   // Inspection error, if the certificate could not be read
+	CheckedAt       time.Time `json:"checked_at"`        // When the certificate was inspected
 }
 
 // SystemInfo represents basic system information
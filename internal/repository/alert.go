@@ -23,12 +23,25 @@ type AlertRepository interface {
 
 	// Alert history operations
 	CreateAlertHistory(history *models.AlertHistory) error
+	UpdateAlertHistory(history *models.AlertHistory) error
+	GetIncident(historyID uint) (*models.AlertHistory, error)
+	MarkNotificationSent(historyID uint, channel string) error
 	GetAlertHistory(limit, offset int) ([]*models.AlertHistory, error)
 	GetAlertHistoryByID(alertID uint, limit, offset int) ([]*models.AlertHistory, error)
 	GetUnresolvedAlerts() ([]*models.AlertHistory, error)
 	ResolveAlert(historyID uint) error
 	GetRecentAlerts(since time.Time) ([]*models.AlertHistory, error)
 
+	// Incident workflow operations
+	UpdateIncidentWorkflow(history *models.AlertHistory) error
+	CreateIncidentEvent(event *models.IncidentEvent) error
+	GetIncidentEvents(historyID uint) ([]*models.IncidentEvent, error)
+
+	// Alert instance state operations
+	SaveAlertInstance(instance *models.AlertInstance) error
+	GetAlertInstances(alertID uint, state string) ([]*models.AlertInstance, error)
+	DeleteAlertInstance(id uint) error
+
 	// Statistics operations
 	GetAlertStats() (map[string]interface{}, error)
 	GetTriggeredAlertsCount(since time.Time) (int64, error)
@@ -115,12 +128,23 @@ func (r *alertRepository) DeleteAlert(id uint) error {
 		}
 	}()
 
-	// First, delete all related alert history records
+	// First, delete all related incident events, alert history and instance records
+	if err := tx.Where("history_id IN (?)", tx.Model(&models.AlertHistory{}).Select("id").Where("alert_id = ?", id)).
+		Delete(&models.IncidentEvent{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete incident events: %w", err)
+	}
+
 	if err := tx.Where("alert_id = ?", id).Delete(&models.AlertHistory{}).Error; err != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to delete alert history: %w", err)
 	}
 
+	if err := tx.Where("alert_id = ?", id).Delete(&models.AlertInstance{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete alert instances: %w", err)
+	}
+
 	// Then delete the alert itself
 	result := tx.Delete(&models.Alert{}, id)
 	if result.Error != nil {
@@ -148,6 +172,48 @@ func (r *alertRepository) CreateAlertHistory(history *models.AlertHistory) error
 	return nil
 }
 
+// UpdateAlertHistory updates the incident fields of an existing alert history entry in place.
+// Notification flags are left alone, see MarkNotificationSent.
+func (r *alertRepository) UpdateAlertHistory(history *models.AlertHistory) error {
+	if err := r.db.Model(history).
+		Select("metric_value", "threshold", "severity", "message", "peak_value", "resolved", "resolved_at").
+		Updates(history).Error; err != nil {
+		return fmt.Errorf("failed to update alert history: %w", err)
+	}
+	return nil
+}
+
+// GetIncident retrieves a single alert history entry by its ID
+func (r *alertRepository) GetIncident(historyID uint) (*models.AlertHistory, error) {
+	var history models.AlertHistory
+	if err := r.db.Preload("Alert").First(&history, historyID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("alert history not found")
+		}
+		return nil, fmt.Errorf("failed to get alert history: %w", err)
+	}
+	return &history, nil
+}
+
+// MarkNotificationSent flags that an alert history entry was delivered to a channel
+// ("email" or "webhook") without touching the rest of the entry
+func (r *alertRepository) MarkNotificationSent(historyID uint, channel string) error {
+	var column string
+	switch channel {
+	case "email":
+		column = "email_sent"
+	case "webhook":
+		column = "webhook_sent"
+	default:
+		return fmt.Errorf("unknown notification channel: %s", channel)
+	}
+
+	if err := r.db.Model(&models.AlertHistory{}).Where("id = ?", historyID).Update(column, true).Error; err != nil {
+		return fmt.Errorf("failed to update notification status: %w", err)
+	}
+	return nil
+}
+
 // GetAlertHistory retrieves alert history with pagination
 func (r *alertRepository) GetAlertHistory(limit, offset int) ([]*models.AlertHistory, error) {
 	var history []*models.AlertHistory
@@ -224,6 +290,36 @@ func (r *alertRepository) ResolveAlert(historyID uint) error {
 	return nil
 }
 
+// UpdateIncidentWorkflow updates the acknowledgement and assignee of an alert history entry,
+// leaving the incident fields maintained by the alert service alone
+func (r *alertRepository) UpdateIncidentWorkflow(history *models.AlertHistory) error {
+	if err := r.db.Model(history).
+		Select("acknowledged", "acknowledged_by", "acknowledged_at", "assignee").
+		Updates(history).Error; err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+	return nil
+}
+
+// CreateIncidentEvent adds an entry to the timeline of an incident
+func (r *alertRepository) CreateIncidentEvent(event *models.IncidentEvent) error {
+	if err := r.db.Create(event).Error; err != nil {
+		return fmt.Errorf("failed to create incident event: %w", err)
+	}
+	return nil
+}
+
+// GetIncidentEvents retrieves the timeline of an incident, oldest first
+func (r *alertRepository) GetIncidentEvents(historyID uint) ([]*models.IncidentEvent, error) {
+	var events []*models.IncidentEvent
+	if err := r.db.Where("history_id = ?", historyID).
+		Order("created_at ASC, id ASC").
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident events: %w", err)
+	}
+	return events, nil
+}
+
 // GetRecentAlerts retrieves alerts triggered since a specific time
 func (r *alertRepository) GetRecentAlerts(since time.Time) ([]*models.AlertHistory, error) {
 	var history []*models.AlertHistory
@@ -236,6 +332,43 @@ func (r *alertRepository) GetRecentAlerts(since time.Time) ([]*models.AlertHisto
 	return history, nil
 }
 
+// SaveAlertInstance creates or updates the state of an alert on a host
+func (r *alertRepository) SaveAlertInstance(instance *models.AlertInstance) error {
+	if err := r.db.Omit("Alert").Save(instance).Error; err != nil {
+		return fmt.Errorf("failed to save alert instance: %w", err)
+	}
+	return nil
+}
+
+// GetAlertInstances retrieves alert instances, optionally filtered by alert and state
+func (r *alertRepository) GetAlertInstances(alertID uint, state string) ([]*models.AlertInstance, error) {
+	var instances []*models.AlertInstance
+
+	query := r.db.Preload("Alert").Order("alert_id ASC, hostname ASC")
+
+	if alertID > 0 {
+		query = query.Where("alert_id = ?", alertID)
+	}
+
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+
+	if err := query.Find(&instances).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alert instances: %w", err)
+	}
+
+	return instances, nil
+}
+
+// DeleteAlertInstance deletes the state of an alert on a host
+func (r *alertRepository) DeleteAlertInstance(id uint) error {
+	if err := r.db.Delete(&models.AlertInstance{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete alert instance: %w", err)
+	}
+	return nil
+}
+
 // GetAlertStats returns statistics about alerts
 func (r *alertRepository) GetAlertStats() (map[string]interface{}, error) {
 	var stats struct {
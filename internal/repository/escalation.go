@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// EscalationPolicyRepository interface defines methods for escalation policy data access
+type EscalationPolicyRepository interface {
+	CreateEscalationPolicy(policy *models.EscalationPolicy) error
+	GetEscalationPolicyByID(id uint) (*models.EscalationPolicy, error)
+	GetEscalationPolicies() ([]*models.EscalationPolicy, error)
+	UpdateEscalationPolicy(policy *models.EscalationPolicy) error
+	DeleteEscalationPolicy(id uint) error
+}
+
+// escalationPolicyRepository implements EscalationPolicyRepository interface
+type escalationPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewEscalationPolicyRepository creates a new escalation policy repository
+func NewEscalationPolicyRepository(db *gorm.DB) EscalationPolicyRepository {
+	return &escalationPolicyRepository{
+		db: db,
+	}
+}
+
+// CreateEscalationPolicy creates a new escalation policy
+func (r *escalationPolicyRepository) CreateEscalationPolicy(policy *models.EscalationPolicy) error {
+	if err := r.db.Create(policy).Error; err != nil {
+		return fmt.Errorf("failed to create escalation policy: %w", err)
+	}
+	return nil
+}
+
+// GetEscalationPolicyByID retrieves an escalation policy by its ID
+func (r *escalationPolicyRepository) GetEscalationPolicyByID(id uint) (*models.EscalationPolicy, error) {
+	var policy models.EscalationPolicy
+	if err := r.db.First(&policy, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("escalation policy not found")
+		}
+		return nil, fmt.Errorf("failed to get escalation policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// GetEscalationPolicies retrieves all escalation policies ordered by name
+func (r *escalationPolicyRepository) GetEscalationPolicies() ([]*models.EscalationPolicy, error) {
+	var policies []*models.EscalationPolicy
+	if err := r.db.Order("name ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get escalation policies: %w", err)
+	}
+	return policies, nil
+}
+
+// UpdateEscalationPolicy updates an existing escalation policy
+func (r *escalationPolicyRepository) UpdateEscalationPolicy(policy *models.EscalationPolicy) error {
+	if err := r.db.Save(policy).Error; err != nil {
+		return fmt.Errorf("failed to update escalation policy: %w", err)
+	}
+	return nil
+}
+
+// DeleteEscalationPolicy deletes an escalation policy and detaches it from its alerts
+func (r *escalationPolicyRepository) DeleteEscalationPolicy(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Alert{}).Where("escalation_policy_id = ?", id).
+			Update("escalation_policy_id", 0).Error; err != nil {
+			return fmt.Errorf("failed to detach escalation policy: %w", err)
+		}
+
+		result := tx.Delete(&models.EscalationPolicy{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete escalation policy: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("escalation policy not found")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// HostRepository interface defines methods for host metadata access
+type HostRepository interface {
+	SaveHostMetadata(metadata *models.HostMetadata) error
+	GetHostMetadata(hostname string) (*models.HostMetadata, error)
+	GetAllHostMetadata() ([]*models.HostMetadata, error)
+	DeleteHostMetadata(hostname string) error
+}
+
+// hostRepository implements HostRepository interface
+type hostRepository struct {
+	db *gorm.DB
+}
+
+// NewHostRepository creates a new host repository
+func NewHostRepository(db *gorm.DB) HostRepository {
+	return &hostRepository{
+		db: db,
+	}
+}
+
+// SaveHostMetadata creates or replaces the metadata of a host
+func (r *hostRepository) SaveHostMetadata(metadata *models.HostMetadata) error {
+	var existing models.HostMetadata
+	err := r.db.Where("hostname = ?", metadata.Hostname).First(&existing).Error
+	switch {
+	case err == nil:
+		metadata.ID = existing.ID
+		metadata.CreatedAt = existing.CreatedAt
+	case err != gorm.ErrRecordNotFound:
+		return fmt.Errorf("failed to get host metadata: %w", err)
+	}
+
+	if err := r.db.Save(metadata).Error; err != nil {
+		return fmt.Errorf("failed to save host metadata: %w", err)
+	}
+	return nil
+}
+
+// GetHostMetadata retrieves the metadata of a host
+func (r *hostRepository) GetHostMetadata(hostname string) (*models.HostMetadata, error) {
+	var metadata models.HostMetadata
+	if err := r.db.Where("hostname = ?", hostname).First(&metadata).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("host metadata not found")
+		}
+		return nil, fmt.Errorf("failed to get host metadata: %w", err)
+	}
+	return &metadata, nil
+}
+
+// GetAllHostMetadata retrieves the metadata of all hosts ordered by hostname
+func (r *hostRepository) GetAllHostMetadata() ([]*models.HostMetadata, error) {
+	var metadata []*models.HostMetadata
+	if err := r.db.Order("hostname ASC").Find(&metadata).Error; err != nil {
+		return nil, fmt.Errorf("failed to get host metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// DeleteHostMetadata deletes the metadata of a host
+func (r *hostRepository) DeleteHostMetadata(hostname string) error {
+	result := r.db.Where("hostname = ?", hostname).Delete(&models.HostMetadata{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete host metadata: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("host metadata not found")
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// NotificationRepository interface defines methods for notification channel and route data access
+type NotificationRepository interface {
+	// Notification channel operations
+	CreateChannel(channel *models.NotificationChannel) error
+	GetChannelByID(id uint) (*models.NotificationChannel, error)
+	GetChannels() ([]*models.NotificationChannel, error)
+	UpdateChannel(channel *models.NotificationChannel) error
+	DeleteChannel(id uint) error
+
+	// Notification route operations
+	CreateRoute(route *models.NotificationRoute) error
+	GetRouteByID(id uint) (*models.NotificationRoute, error)
+	GetRoutes() ([]*models.NotificationRoute, error)
+	UpdateRoute(route *models.NotificationRoute) error
+	DeleteRoute(id uint) error
+
+	// Notification template operations
+	SaveTemplate(template *models.NotificationTemplate) error
+	GetTemplates() ([]*models.NotificationTemplate, error)
+	DeleteTemplate(channelType string) error
+}
+
+// notificationRepository implements NotificationRepository interface
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+// CreateChannel creates a new notification channel
+func (r *notificationRepository) CreateChannel(channel *models.NotificationChannel) error {
+	if err := r.db.Create(channel).Error; err != nil {
+		return fmt.Errorf("failed to create notification channel: %w", err)
+	}
+	return nil
+}
+
+// GetChannelByID retrieves a notification channel by its ID
+func (r *notificationRepository) GetChannelByID(id uint) (*models.NotificationChannel, error) {
+	var channel models.NotificationChannel
+	if err := r.db.First(&channel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("notification channel not found")
+		}
+		return nil, fmt.Errorf("failed to get notification channel: %w", err)
+	}
+	return &channel, nil
+}
+
+// GetChannels retrieves all notification channels ordered by name
+func (r *notificationRepository) GetChannels() ([]*models.NotificationChannel, error) {
+	var channels []*models.NotificationChannel
+	if err := r.db.Order("name ASC").Find(&channels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification channels: %w", err)
+	}
+	return channels, nil
+}
+
+// UpdateChannel updates an existing notification channel
+func (r *notificationRepository) UpdateChannel(channel *models.NotificationChannel) error {
+	if err := r.db.Save(channel).Error; err != nil {
+		return fmt.Errorf("failed to update notification channel: %w", err)
+	}
+	return nil
+}
+
+// DeleteChannel deletes a notification channel. Routes keep the dangling ID, which is
+// skipped when dispatching.
+func (r *notificationRepository) DeleteChannel(id uint) error {
+	result := r.db.Delete(&models.NotificationChannel{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("notification channel not found")
+	}
+	return nil
+}
+
+// CreateRoute creates a new notification route
+func (r *notificationRepository) CreateRoute(route *models.NotificationRoute) error {
+	if err := r.db.Create(route).Error; err != nil {
+		return fmt.Errorf("failed to create notification route: %w", err)
+	}
+	return nil
+}
+
+// GetRouteByID retrieves a notification route by its ID
+func (r *notificationRepository) GetRouteByID(id uint) (*models.NotificationRoute, error) {
+	var route models.NotificationRoute
+	if err := r.db.First(&route, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("notification route not found")
+		}
+		return nil, fmt.Errorf("failed to get notification route: %w", err)
+	}
+	return &route, nil
+}
+
+// GetRoutes retrieves all notification routes in evaluation order
+func (r *notificationRepository) GetRoutes() ([]*models.NotificationRoute, error) {
+	var routes []*models.NotificationRoute
+	if err := r.db.Order("position ASC, id ASC").Find(&routes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification routes: %w", err)
+	}
+	return routes, nil
+}
+
+// UpdateRoute updates an existing notification route
+func (r *notificationRepository) UpdateRoute(route *models.NotificationRoute) error {
+	if err := r.db.Save(route).Error; err != nil {
+		return fmt.Errorf("failed to update notification route: %w", err)
+	}
+	return nil
+}
+
+// DeleteRoute deletes a notification route
+func (r *notificationRepository) DeleteRoute(id uint) error {
+	result := r.db.Delete(&models.NotificationRoute{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification route: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("notification route not found")
+	}
+	return nil
+}
+
+// SaveTemplate creates or replaces the message templates of a channel type
+func (r *notificationRepository) SaveTemplate(template *models.NotificationTemplate) error {
+	var existing models.NotificationTemplate
+	err := r.db.Where("channel_type = ?", template.ChannelType).First(&existing).Error
+	switch {
+	case err == nil:
+		template.ID = existing.ID
+		template.CreatedAt = existing.CreatedAt
+	case err != gorm.ErrRecordNotFound:
+		return fmt.Errorf("failed to get notification template: %w", err)
+	}
+
+	if err := r.db.Save(template).Error; err != nil {
+		return fmt.Errorf("failed to save notification template: %w", err)
+	}
+	return nil
+}
+
+// GetTemplates retrieves the message templates of all channel types
+func (r *notificationRepository) GetTemplates() ([]*models.NotificationTemplate, error) {
+	var templates []*models.NotificationTemplate
+	if err := r.db.Order("channel_type ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification templates: %w", err)
+	}
+	return templates, nil
+}
+
+// DeleteTemplate deletes the message templates of a channel type, restoring the built-in layout
+func (r *notificationRepository) DeleteTemplate(channelType string) error {
+	result := r.db.Where("channel_type = ?", channelType).Delete(&models.NotificationTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("notification template not found")
+	}
+	return nil
+}
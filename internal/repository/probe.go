@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// ProbeRepository interface defines methods for probe data access
+type ProbeRepository interface {
+	// Probe configuration operations
+	CreateProbe(probe *models.Probe) error
+	GetProbeByID(id uint) (*models.Probe, error)
+	GetProbes() ([]*models.Probe, error)
+	GetEnabledProbes() ([]*models.Probe, error)
+	UpdateProbe(probe *models.Probe) error
+	UpdateProbeStatus(probe *models.Probe) error
+	DeleteProbe(id uint) error
+
+	// Probe result operations
+	CreateProbeResult(result *models.ProbeResult) error
+	GetProbeResults(probeID uint, since time.Time, limit int) ([]*models.ProbeResult, error)
+	DeleteOldProbeResults(before time.Time) (int64, error)
+}
+
+// probeRepository implements ProbeRepository interface
+type probeRepository struct {
+	db *gorm.DB
+}
+
+// NewProbeRepository creates a new probe repository
+func NewProbeRepository(db *gorm.DB) ProbeRepository {
+	return &probeRepository{
+		db: db,
+	}
+}
+
+// CreateProbe creates a new probe configuration
+func (r *probeRepository) CreateProbe(probe *models.Probe) error {
+	if err := r.db.Create(probe).Error; err != nil {
+		return fmt.Errorf("failed to create probe: %w", err)
+	}
+	return nil
+}
+
+// GetProbeByID retrieves a probe by its ID
+func (r *probeRepository) GetProbeByID(id uint) (*models.Probe, error) {
+	var probe models.Probe
+	if err := r.db.First(&probe, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("probe not found")
+		}
+		return nil, fmt.Errorf("failed to get probe: %w", err)
+	}
+	return &probe, nil
+}
+
+// GetProbes retrieves all probe configurations
+func (r *probeRepository) GetProbes() ([]*models.Probe, error) {
+	var probes []*models.Probe
+	if err := r.db.Order("name ASC").Find(&probes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get probes: %w", err)
+	}
+	return probes, nil
+}
+
+// GetEnabledProbes retrieves all enabled probe configurations
+func (r *probeRepository) GetEnabledProbes() ([]*models.Probe, error) {
+	var probes []*models.Probe
+	if err := r.db.Where("enabled = ?", true).
+		Order("name ASC").
+		Find(&probes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enabled probes: %w", err)
+	}
+	return probes, nil
+}
+
+// UpdateProbe updates an existing probe configuration
+func (r *probeRepository) UpdateProbe(probe *models.Probe) error {
+	if err := r.db.Save(probe).Error; err != nil {
+		return fmt.Errorf("failed to update probe: %w", err)
+	}
+	return nil
+}
+
+// UpdateProbeStatus updates only the last check status of a probe
+func (r *probeRepository) UpdateProbeStatus(probe *models.Probe) error {
+	if err := r.db.Model(&models.Probe{}).
+		Where("id = ?", probe.ID).
+		Updates(map[string]interface{}{
+			"last_check":      probe.LastCheck,
+			"last_success":    probe.LastSuccess,
+			"last_latency_ms": probe.LastLatencyMs,
+			"last_error":      probe.LastError,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update probe status: %w", err)
+	}
+	return nil
+}
+
+// DeleteProbe deletes a probe configuration and its results
+func (r *probeRepository) DeleteProbe(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("probe_id = ?", id).Delete(&models.ProbeResult{}).Error; err != nil {
+			return fmt.Errorf("failed to delete probe results: %w", err)
+		}
+
+		result := tx.Delete(&models.Probe{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete probe: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("probe not found")
+		}
+		return nil
+	})
+}
+
+// CreateProbeResult stores a probe check result
+func (r *probeRepository) CreateProbeResult(result *models.ProbeResult) error {
+	if err := r.db.Create(result).Error; err != nil {
+		return fmt.Errorf("failed to create probe result: %w", err)
+	}
+	return nil
+}
+
+// GetProbeResults retrieves results for a probe since a point in time, newest first
+func (r *probeRepository) GetProbeResults(probeID uint, since time.Time, limit int) ([]*models.ProbeResult, error) {
+	var results []*models.ProbeResult
+
+	query := r.db.Where("probe_id = ? AND timestamp >= ?", probeID, since).
+		Order("timestamp DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to get probe results: %w", err)
+	}
+
+	return results, nil
+}
+
+// DeleteOldProbeResults deletes probe results older than the given time
+func (r *probeRepository) DeleteOldProbeResults(before time.Time) (int64, error) {
+	result := r.db.Where("timestamp < ?", before).Delete(&models.ProbeResult{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete old probe results: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
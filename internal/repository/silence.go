@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// SilenceRepository interface defines methods for silence data access
+type SilenceRepository interface {
+	CreateSilence(silence *models.Silence) error
+	GetSilenceByID(id uint) (*models.Silence, error)
+	GetSilences() ([]*models.Silence, error)
+	GetCurrentSilences(now time.Time) ([]*models.Silence, error)
+	UpdateSilence(silence *models.Silence) error
+	DeleteSilence(id uint) error
+}
+
+// silenceRepository implements SilenceRepository interface
+type silenceRepository struct {
+	db *gorm.DB
+}
+
+// NewSilenceRepository creates a new silence repository
+func NewSilenceRepository(db *gorm.DB) SilenceRepository {
+	return &silenceRepository{
+		db: db,
+	}
+}
+
+// CreateSilence creates a new silence
+func (r *silenceRepository) CreateSilence(silence *models.Silence) error {
+	if err := r.db.Create(silence).Error; err != nil {
+		return fmt.Errorf("failed to create silence: %w", err)
+	}
+	return nil
+}
+
+// GetSilenceByID retrieves a silence by its ID
+func (r *silenceRepository) GetSilenceByID(id uint) (*models.Silence, error) {
+	var silence models.Silence
+	if err := r.db.First(&silence, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("silence not found")
+		}
+		return nil, fmt.Errorf("failed to get silence: %w", err)
+	}
+	return &silence, nil
+}
+
+// GetSilences retrieves all silences, newest first
+func (r *silenceRepository) GetSilences() ([]*models.Silence, error) {
+	var silences []*models.Silence
+	if err := r.db.Order("created_at DESC").Find(&silences).Error; err != nil {
+		return nil, fmt.Errorf("failed to get silences: %w", err)
+	}
+	return silences, nil
+}
+
+// GetCurrentSilences retrieves silences that have started and not yet ended at now.
+// Recurring silences are included regardless of their end, which may be unset.
+func (r *silenceRepository) GetCurrentSilences(now time.Time) ([]*models.Silence, error) {
+	var silences []*models.Silence
+	if err := r.db.Where("starts_at <= ?", now).
+		Where("ends_at > ? OR schedule <> ''", now).
+		Find(&silences).Error; err != nil {
+		return nil, fmt.Errorf("failed to get current silences: %w", err)
+	}
+	return silences, nil
+}
+
+// UpdateSilence updates an existing silence
+func (r *silenceRepository) UpdateSilence(silence *models.Silence) error {
+	if err := r.db.Save(silence).Error; err != nil {
+		return fmt.Errorf("failed to update silence: %w", err)
+	}
+	return nil
+}
+
+// DeleteSilence deletes a silence
+func (r *silenceRepository) DeleteSilence(id uint) error {
+	result := r.db.Delete(&models.Silence{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete silence: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("silence not found")
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// MetricNoData is the alert metric type for hosts or metrics that stopped reporting.
+// The alert value is the number of seconds since the last sample.
+const MetricNoData = "no_data"
+
+// absentHostRetention is how long a silent host keeps being evaluated by no-data alerts
+const absentHostRetention = 7 * 24 * time.Hour
+
+// absentMetricPresence reports whether a metric group is present in a sample.
+// An empty AbsentMetric on an alert means any sample from the host counts.
+var absentMetricPresence = map[string]func(m *models.SystemMetrics) bool{
+	"cpu":       func(m *models.SystemMetrics) bool { return m.CPU.Cores > 0 },
+	"memory":    func(m *models.SystemMetrics) bool { return m.Memory.Total > 0 },
+	"disk":      func(m *models.SystemMetrics) bool { return m.Disk.Total > 0 || len(m.Disk.Partitions) > 0 },
+	"network":   func(m *models.SystemMetrics) bool { return len(m.Network.Interfaces) > 0 },
+	"processes": func(m *models.SystemMetrics) bool { return m.Processes.TotalProcesses > 0 },
+	"limits":    func(m *models.SystemMetrics) bool { return m.Limits.FileHandlesMax > 0 || m.Limits.PIDMax > 0 },
+}
+
+// AbsentMetrics returns the metric groups no-data alerts can watch
+func AbsentMetrics() []string {
+	names := make([]string, 0, len(absentMetricPresence))
+	for name := range absentMetricPresence {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsNoDataMetricType reports whether a metric type is a no-data rule
+func IsNoDataMetricType(metricType string) bool {
+	return strings.EqualFold(metricType, MetricNoData)
+}
+
+// heartbeatKey identifies when a host, or one of its metric groups, last reported
+func heartbeatKey(hostname, metric string) string {
+	if metric == "" {
+		return hostname
+	}
+	return hostname + "|" + strings.ToLower(metric)
+}
+
+// recordHeartbeat notes that a host, and each metric group present in the sample, reported now
+func (as *AlertService) recordHeartbeat(metrics *models.SystemMetrics) {
+	now := time.Now()
+
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	as.heartbeats[heartbeatKey(metrics.Hostname, "")] = now
+	for metric, present := range absentMetricPresence {
+		if present(metrics) {
+			as.heartbeats[heartbeatKey(metrics.Hostname, metric)] = now
+		}
+	}
+}
+
+// seedHeartbeats loads the last report time of known hosts from stored metrics,
+// so hosts that never report after a restart still trigger no-data alerts
+func (as *AlertService) seedHeartbeats() {
+	as.mutex.RLock()
+	metricsRepo := as.metricsRepo
+	as.mutex.RUnlock()
+	if metricsRepo == nil {
+		return
+	}
+
+	statuses, err := metricsRepo.GetSystemStatus()
+	if err != nil {
+		log.Printf("❌ Failed to load hosts for no-data alerts: %v", err)
+		return
+	}
+
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	seeded := 0
+	for _, status := range statuses {
+		if time.Since(status.Timestamp) > absentHostRetention {
+			continue
+		}
+		// Stored rows hold every metric group the host reported
+		keys := []string{heartbeatKey(status.Hostname, "")}
+		for metric := range absentMetricPresence {
+			keys = append(keys, heartbeatKey(status.Hostname, metric))
+		}
+		for _, key := range keys {
+			if last, exists := as.heartbeats[key]; !exists || status.Timestamp.After(last) {
+				as.heartbeats[key] = status.Timestamp
+			}
+		}
+		seeded++
+	}
+
+	if seeded > 0 {
+		log.Printf("💓 Loaded last report times of %d hosts for no-data alerts", seeded)
+	}
+}
+
+// checkAbsentAlerts evaluates no-data alerts for every known host. It runs on the
+// alert ticker because silent hosts never reach CheckMetrics.
+func (as *AlertService) checkAbsentAlerts() {
+	if !as.config.EnableAlerts {
+		return
+	}
+
+	alerts, err := as.alertRepo.GetActiveAlerts()
+	if err != nil {
+		log.Printf("❌ Failed to get active alerts: %v", err)
+		return
+	}
+
+	var absentAlerts []*models.Alert
+	for _, alert := range alerts {
+		if IsNoDataMetricType(alert.MetricType) {
+			absentAlerts = append(absentAlerts, alert)
+		}
+	}
+	if len(absentAlerts) == 0 {
+		return
+	}
+
+	now := time.Now()
+	as.mutex.RLock()
+	hosts := make(map[string]time.Time)
+	for key, last := range as.heartbeats {
+		if !strings.Contains(key, "|") {
+			hosts[key] = last
+		}
+	}
+	heartbeats := make(map[string]time.Time, len(as.heartbeats))
+	for key, last := range as.heartbeats {
+		heartbeats[key] = last
+	}
+	as.mutex.RUnlock()
+
+	for _, alert := range absentAlerts {
+		for hostname, hostLast := range hosts {
+			scoped := as.scopeAlertToHost(alert, hostname)
+			if scoped == nil {
+				continue
+			}
+
+			last, exists := heartbeats[heartbeatKey(hostname, alert.AbsentMetric)]
+			if !exists {
+				// The metric was never seen; count from the host's first report we know of
+				last = hostLast
+			}
+
+			as.mutex.Lock()
+			as.checkedCount++
+			as.mutex.Unlock()
+
+			silence := now.Sub(last)
+			extra := map[string]interface{}{
+				"last_seen": last,
+			}
+			message := as.generateNoDataAlertMessage(scoped, hostname, silence, last)
+			if err := as.evaluateAlertValue(scoped, hostname, silence.Seconds(), message, extra); err != nil {
+				log.Printf("❌ Error evaluating no-data alert %d on %s: %v", alert.ID, hostname, err)
+			}
+		}
+	}
+}
+
+// cleanupHeartbeats forgets hosts that have been silent longer than absentHostRetention.
+// Callers must hold as.mutex.
+func (as *AlertService) cleanupHeartbeats() {
+	for key, last := range as.heartbeats {
+		if time.Since(last) > absentHostRetention {
+			delete(as.heartbeats, key)
+		}
+	}
+}
+
+// generateNoDataAlertMessage generates a human-readable message for no-data alerts
+func (as *AlertService) generateNoDataAlertMessage(alert *models.Alert, hostname string, silence time.Duration, last time.Time) string {
+	subject := fmt.Sprintf("No data from %s", hostname)
+	if alert.AbsentMetric != "" {
+		subject = fmt.Sprintf("No %s data from %s", strings.ToLower(alert.AbsentMetric), hostname)
+	}
+
+	return fmt.Sprintf("%s for %s (last sample at %s, threshold: %s %.0fs)",
+		subject, silence.Round(time.Second), last.Format("2006-01-02 15:04:05"), alert.Condition, alert.Threshold)
+}
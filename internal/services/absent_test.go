@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func TestAlertService_RecordHeartbeat(t *testing.T) {
+	as := NewAlertService(nil, nil, nil, nil)
+	as.recordHeartbeat(&models.SystemMetrics{
+		Hostname: "web-1",
+		CPU:      models.CPUMetrics{Cores: 4},
+		Memory:   models.MemoryMetrics{Total: 8},
+	})
+
+	assert.Contains(t, as.heartbeats, heartbeatKey("web-1", ""))
+	assert.Contains(t, as.heartbeats, heartbeatKey("web-1", "cpu"))
+	assert.Contains(t, as.heartbeats, heartbeatKey("web-1", "Memory"))
+	assert.NotContains(t, as.heartbeats, heartbeatKey("web-1", "disk"))
+}
+
+func TestAlertService_CheckAbsentAlerts(t *testing.T) {
+	alert := &models.Alert{
+		BaseModel:  models.BaseModel{ID: 1},
+		Name:       "host down",
+		MetricType: MetricNoData,
+		Condition:  ">",
+		Threshold:  60,
+		IsActive:   true,
+	}
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+
+	now := time.Now()
+	as.heartbeats[heartbeatKey("web-1", "")] = now.Add(-5 * time.Minute)
+	as.heartbeats[heartbeatKey("web-2", "")] = now
+
+	as.checkAbsentAlerts()
+
+	history := repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.Equal(t, "web-1", history[0].Hostname)
+	assert.InDelta(t, 300, history[0].MetricValue, 5)
+	assert.Contains(t, history[0].Message, "No data from web-1 for 5m")
+
+	// The host reports again and the alert resolves
+	as.recordHeartbeat(&models.SystemMetrics{Hostname: "web-1"})
+	as.checkAbsentAlerts()
+
+	assert.Eventually(t, func() bool {
+		history := repo.historySnapshot()
+		return len(history) == 1 && history[0].Resolved
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAlertService_NoDataMessage(t *testing.T) {
+	as := NewAlertService(nil, nil, nil, nil)
+	alert := &models.Alert{AbsentMetric: "Disk", Condition: ">", Threshold: 120}
+	last := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	message := as.generateNoDataAlertMessage(alert, "web-1", 185*time.Second, last)
+	assert.Equal(t, "No disk data from web-1 for 3m5s (last sample at 2026-01-02 15:04:05, threshold: > 120s)", message)
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// Window aggregation limits
+const (
+	// MaxAggregationWindow is the longest window alerts can aggregate over; older samples are discarded
+	MaxAggregationWindow = time.Hour
+	// defaultAggregationWindow is used by aggregated rules without a window
+	defaultAggregationWindow = 5 * time.Minute
+)
+
+// aggregationFunctions lists the functions that aggregate a field over a time window
+var aggregationFunctions = []string{"avg", "min", "max", "sum", "count", "p50", "p90", "p95", "p99", "rate", "delta"}
+
+// AggregationFunctions returns the supported window aggregation functions
+func AggregationFunctions() []string {
+	return append([]string(nil), aggregationFunctions...)
+}
+
+// IsAggregationFunction reports whether name is a window aggregation function
+func IsAggregationFunction(name string) bool {
+	for _, fn := range aggregationFunctions {
+		if strings.EqualFold(fn, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// MetricSample is a field value observed at a point in time
+type MetricSample struct {
+	Time  time.Time
+	Value float64
+}
+
+// AggregateSamples applies a window aggregation function to samples ordered by time.
+// rate is the per-second increase and delta the total increase over the window; both
+// treat a decrease as a counter reset.
+func AggregateSamples(fn string, samples []MetricSample) (float64, error) {
+	if len(samples) == 0 {
+		return 0, fmt.Errorf("no samples in window")
+	}
+
+	fn = strings.ToLower(fn)
+	switch fn {
+	case "avg":
+		var sum float64
+		for _, s := range samples {
+			sum += s.Value
+		}
+		return sum / float64(len(samples)), nil
+
+	case "min":
+		result := samples[0].Value
+		for _, s := range samples[1:] {
+			result = math.Min(result, s.Value)
+		}
+		return result, nil
+
+	case "max":
+		result := samples[0].Value
+		for _, s := range samples[1:] {
+			result = math.Max(result, s.Value)
+		}
+		return result, nil
+
+	case "sum":
+		var sum float64
+		for _, s := range samples {
+			sum += s.Value
+		}
+		return sum, nil
+
+	case "count":
+		return float64(len(samples)), nil
+
+	case "p50":
+		return percentile(samples, 50), nil
+	case "p90":
+		return percentile(samples, 90), nil
+	case "p95":
+		return percentile(samples, 95), nil
+	case "p99":
+		return percentile(samples, 99), nil
+
+	case "rate", "delta":
+		if len(samples) < 2 {
+			return 0, nil
+		}
+		var increase float64
+		for i := 1; i < len(samples); i++ {
+			diff := samples[i].Value - samples[i-1].Value
+			if diff < 0 {
+				// Counter reset: count from zero
+				diff = samples[i].Value
+			}
+			increase += diff
+		}
+		if fn == "delta" {
+			return increase, nil
+		}
+		elapsed := samples[len(samples)-1].Time.Sub(samples[0].Time).Seconds()
+		if elapsed <= 0 {
+			return 0, nil
+		}
+		return increase / elapsed, nil
+
+	default:
+		return 0, fmt.Errorf("unknown aggregation function: %s", fn)
+	}
+}
+
+// percentile returns the p-th percentile of sample values using linear interpolation
+func percentile(samples []MetricSample, p float64) float64 {
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = s.Value
+	}
+	sort.Float64s(values)
+
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	rank := p / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return values[lower]
+	}
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+}
+
+// hostSampleBuffer holds recent metric field values for one host
+type hostSampleBuffer struct {
+	times  []time.Time
+	values [][]float64 // Indexed like sampleFieldNames
+}
+
+// sampleFieldNames fixes the field order used in hostSampleBuffer values
+var sampleFieldNames = MetricFieldNames()
+
+// sampleFieldIndex maps field names to their position in sampleFieldNames
+var sampleFieldIndex = func() map[string]int {
+	index := make(map[string]int, len(sampleFieldNames))
+	for i, name := range sampleFieldNames {
+		index[name] = i
+	}
+	return index
+}()
+
+// add appends a metrics sample and drops samples older than MaxAggregationWindow
+func (b *hostSampleBuffer) add(metrics *models.SystemMetrics, now time.Time) {
+	values := make([]float64, len(sampleFieldNames))
+	for i, name := range sampleFieldNames {
+		values[i], _ = MetricFieldValue(metrics, name)
+	}
+	b.times = append(b.times, now)
+	b.values = append(b.values, values)
+
+	cutoff := now.Add(-MaxAggregationWindow)
+	drop := 0
+	for drop < len(b.times) && b.times[drop].Before(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		b.times = append(b.times[:0], b.times[drop:]...)
+		b.values = append(b.values[:0], b.values[drop:]...)
+	}
+}
+
+// samples returns a field's samples within window of now
+func (b *hostSampleBuffer) samples(field string, window time.Duration, now time.Time) []MetricSample {
+	index, exists := sampleFieldIndex[strings.ToLower(field)]
+	if !exists {
+		return nil
+	}
+
+	cutoff := now.Add(-window)
+	var result []MetricSample
+	for i, t := range b.times {
+		if t.Before(cutoff) {
+			continue
+		}
+		result = append(result, MetricSample{Time: t, Value: b.values[i][index]})
+	}
+	return result
+}
+
+// latest returns the time of the most recent sample
+func (b *hostSampleBuffer) latest() time.Time {
+	if len(b.times) == 0 {
+		return time.Time{}
+	}
+	return b.times[len(b.times)-1]
+}
+
+// recordSample stores a metrics sample for window aggregations
+func (as *AlertService) recordSample(metrics *models.SystemMetrics) {
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	buffer, exists := as.samples[metrics.Hostname]
+	if !exists {
+		buffer = &hostSampleBuffer{}
+		as.samples[metrics.Hostname] = buffer
+	}
+	buffer.add(metrics, time.Now())
+}
+
+// windowSamples returns a host's samples of a field within a window
+func (as *AlertService) windowSamples(hostname, field string, window time.Duration) []MetricSample {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	buffer, exists := as.samples[hostname]
+	if !exists {
+		return nil
+	}
+	return buffer.samples(field, window, time.Now())
+}
+
+// aggregationWindow returns an alert's aggregation window
+func aggregationWindow(alert *models.Alert) time.Duration {
+	if alert.Window <= 0 {
+		return defaultAggregationWindow
+	}
+	return time.Duration(alert.Window) * time.Second
+}
+
+// windowEnv resolves expression fields from the current sample and window functions from a host's history
+type windowEnv struct {
+	metricsEnv
+	as       *AlertService
+	hostname string
+}
+
+// Samples implements ExpressionEnv
+func (env windowEnv) Samples(field string, window time.Duration) ([]MetricSample, error) {
+	samples := env.as.windowSamples(env.hostname, field, window)
+	if len(samples) == 0 {
+		// Nothing recorded yet: fall back to the current sample
+		return env.metricsEnv.Samples(field, window)
+	}
+	return samples, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func newTestSamples(values ...float64) []MetricSample {
+	start := time.Now().Add(-time.Duration(len(values)) * 10 * time.Second)
+	samples := make([]MetricSample, len(values))
+	for i, v := range values {
+		samples[i] = MetricSample{Time: start.Add(time.Duration(i) * 10 * time.Second), Value: v}
+	}
+	return samples
+}
+
+func TestAggregateSamples(t *testing.T) {
+	samples := newTestSamples(10, 40, 20, 30)
+
+	tests := []struct {
+		fn   string
+		want float64
+	}{
+		{"avg", 25},
+		{"min", 10},
+		{"max", 40},
+		{"sum", 100},
+		{"count", 4},
+		{"p50", 25},
+		{"P95", 38.5},
+		{"delta", 60}, // 10 -> 40 (+30), 40 -> 20 (reset, +20), 20 -> 30 (+10)
+	}
+
+	for _, tt := range tests {
+		got, err := AggregateSamples(tt.fn, samples)
+		require.NoError(t, err, tt.fn)
+		assert.InDelta(t, tt.want, got, 1e-9, tt.fn)
+	}
+
+	rate, err := AggregateSamples("rate", samples)
+	require.NoError(t, err)
+	assert.InDelta(t, 60.0/30.0, rate, 1e-9)
+
+	_, err = AggregateSamples("avg", nil)
+	assert.Error(t, err)
+
+	_, err = AggregateSamples("median", samples)
+	assert.Error(t, err)
+}
+
+func TestParseExpression_Windows(t *testing.T) {
+	expr, err := ParseExpression("avg(cpu, 5m) > 80 and max(load_avg_1, load_avg_5) > 1 or rate(network_errors, 90s) > 10")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, expr.Window())
+	assert.Equal(t, []string{"cpu", "load_avg_1", "load_avg_5", "network_errors"}, expr.Fields())
+
+	invalid := []string{
+		"avg(cpu) > 80",
+		"avg(cpu, 2h) > 80",
+		"avg(unknown, 5m) > 80",
+		"avg(cpu + 1, 5m) > 80",
+		"cpu > 5m",
+	}
+	for _, source := range invalid {
+		_, err := ParseExpression(source)
+		assert.Error(t, err, source)
+	}
+}
+
+func TestAlertService_WindowAggregation(t *testing.T) {
+	as := NewAlertService(nil, nil, nil, nil)
+
+	// A single dip below the threshold doesn't pull the average under it
+	buffer := &hostSampleBuffer{}
+	now := time.Now()
+	for i, usage := range []float64{90, 95, 50, 92, 93} {
+		buffer.add(&models.SystemMetrics{Hostname: "web-1", CPU: models.CPUMetrics{Usage: usage}}, now.Add(time.Duration(i-4)*time.Minute))
+	}
+	as.samples["web-1"] = buffer
+
+	current := &models.SystemMetrics{Hostname: "web-1", CPU: models.CPUMetrics{Usage: 93}}
+	env := windowEnv{metricsEnv: metricsEnv{metrics: current}, as: as, hostname: "web-1"}
+
+	expr, err := ParseExpression("avg(cpu, 5m) > 80")
+	require.NoError(t, err)
+	value, err := expr.Evaluate(env)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, value)
+
+	expr, err = ParseExpression("min(cpu, 90s) < 60")
+	require.NoError(t, err)
+	value, err = expr.Evaluate(env)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, value, "the dip is older than the window")
+
+	// Hosts without history fall back to the current sample
+	env.hostname = "web-2"
+	expr, err = ParseExpression("avg(cpu, 5m)")
+	require.NoError(t, err)
+	value, err = expr.Evaluate(env)
+	require.NoError(t, err)
+	assert.Equal(t, 93.0, value)
+}
+
+func TestHostSampleBuffer_Retention(t *testing.T) {
+	buffer := &hostSampleBuffer{}
+	now := time.Now()
+
+	buffer.add(&models.SystemMetrics{CPU: models.CPUMetrics{Usage: 10}}, now.Add(-2*MaxAggregationWindow))
+	buffer.add(&models.SystemMetrics{CPU: models.CPUMetrics{Usage: 20}}, now)
+
+	assert.Len(t, buffer.times, 1)
+	samples := buffer.samples("cpu", MaxAggregationWindow, now)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 20.0, samples[0].Value)
+}
@@ -18,19 +18,39 @@ import (
 // AlertService manages alert checking and notifications
 type AlertService struct {
 	alertRepo        repository.AlertRepository
+	metricsRepo      repository.MetricsRepository          // Optional: seeds anomaly baselines from history
+	silenceRepo      repository.SilenceRepository          // Optional: silences suppressing notifications
+	policyRepo       repository.EscalationPolicyRepository // Optional: escalation policies of alerts
+	notificationRepo repository.NotificationRepository     // Optional: notification channels, routes and templates
+	hostRepo         repository.HostRepository             // Optional: host labels for alert host selectors
 	emailSender      EmailSender
 	webhookSender    WebhookSender
 	websocketHandler interface{} // WebSocket handler for broadcasting alerts
 	config           *config.AlertConfig
 
 	// Alert state management
-	lastAlerts     map[string]time.Time // Key: alert_id:hostname, Value: last triggered time
-	alertDurations map[string]time.Time // Key: alert_id:hostname, Value: first triggered time
-	isRunning      bool
-	stopChan       chan bool
-	ctx            context.Context
-	cancel         context.CancelFunc
-	mutex          sync.RWMutex
+	instances          map[string]*models.AlertInstance     // Key: alert_id_hostname, Value: persisted pending/firing state
+	dirtyInstances     map[string]bool                      // Key: alert_id_hostname, Value: evaluation not yet persisted
+	processWatch       map[string]*processWatchState        // Key: alert_id_hostname, Value: PID tracking for process watch alerts
+	samples            map[string]*hostSampleBuffer         // Key: hostname, Value: recent samples for window aggregations
+	partitionUsage     map[string][]MetricSample            // Key: hostname|mountpoint, Value: usage history for disk forecasts
+	anomalyBaselines   map[string]*anomalyBaseline          // Key: hostname|field|seasonality, Value: learned baseline
+	heartbeats         map[string]time.Time                 // Key: hostname or hostname|metric, Value: last sample time
+	silences           []*models.Silence                    // Current and recurring silences, refreshed every check interval
+	escalationPolicies map[uint]*models.EscalationPolicy    // Key: policy ID
+	channels           map[uint]*models.NotificationChannel // Key: channel ID
+	routes             []*models.NotificationRoute          // Notification routes in evaluation order
+	templates          map[string]models.MessageTemplates   // Key: channel type
+	hostLabels         map[string]map[string]string         // Key: hostname, Value: host metadata labels
+	hostDescriptions   map[string]string                    // Key: hostname, Value: host metadata description
+	escalationMutex    sync.Mutex                           // Serializes escalation runs so steps are notified once
+	groups             map[string]*notificationGroup        // Key: route|group key values, Value: batched notifications
+	groupMutex         sync.Mutex                           // Guards groups
+	isRunning          bool
+	stopChan           chan bool
+	ctx                context.Context
+	cancel             context.CancelFunc
+	mutex              sync.RWMutex
 
 	// Statistics
 	checkedCount   int64
@@ -54,17 +74,29 @@ func NewAlertService(
 			EnableAlerts:   true,
 			CheckInterval:  30 * time.Second,
 			CooldownPeriod: 5 * time.Minute,
+			GroupWait:      30 * time.Second,
+			GroupInterval:  5 * time.Minute,
+			FlapThreshold:  6,
+			FlapWindow:     30 * time.Minute,
 		}
 	}
 
 	return &AlertService{
-		alertRepo:      alertRepo,
-		emailSender:    emailSender,
-		webhookSender:  webhookSender,
-		config:         alertConfig,
-		lastAlerts:     make(map[string]time.Time),
-		alertDurations: make(map[string]time.Time),
-		stopChan:       make(chan bool, 1),
+		alertRepo:          alertRepo,
+		emailSender:        emailSender,
+		webhookSender:      webhookSender,
+		config:             alertConfig,
+		instances:          make(map[string]*models.AlertInstance),
+		dirtyInstances:     make(map[string]bool),
+		processWatch:       make(map[string]*processWatchState),
+		samples:            make(map[string]*hostSampleBuffer),
+		partitionUsage:     make(map[string][]MetricSample),
+		anomalyBaselines:   make(map[string]*anomalyBaseline),
+		heartbeats:         make(map[string]time.Time),
+		escalationPolicies: make(map[uint]*models.EscalationPolicy),
+		channels:           make(map[uint]*models.NotificationChannel),
+		groups:             make(map[string]*notificationGroup),
+		stopChan:           make(chan bool, 1),
 	}
 }
 
@@ -84,12 +116,21 @@ func (as *AlertService) Start(ctx context.Context) error {
 
 	log.Printf("🚨 Starting alert service with %v check interval", as.config.CheckInterval)
 
+	// Restore cooldowns and pending durations from before the restart
+	as.restoreAlertInstances()
+
 	// Create context for this service
 	as.ctx, as.cancel = context.WithCancel(ctx)
 
 	// Start the alert checking routine
 	go as.alertCheckingRoutine()
 
+	// Start the escalation scheduler
+	go as.escalationRoutine()
+
+	// Start the notification group scheduler
+	go as.groupingRoutine()
+
 	as.isRunning = true
 	log.Println("✅ Alert service started successfully")
 
@@ -141,6 +182,11 @@ func (as *AlertService) CheckMetrics(metrics *models.SystemMetrics) {
 	as.lastCheckTime = time.Now()
 	as.mutex.Unlock()
 
+	// Keep recent samples for window aggregations, disk forecasts and no-data alerts
+	as.recordHeartbeat(metrics)
+	as.recordSample(metrics)
+	as.recordPartitionUsage(metrics)
+
 	// Get active alerts
 	alerts, err := as.alertRepo.GetActiveAlerts()
 	if err != nil {
@@ -166,9 +212,16 @@ func (as *AlertService) checkSingleAlert(alert *models.Alert, metrics *models.Sy
 	as.checkedCount++
 	as.mutex.Unlock()
 
+	// Host selectors and per-host thresholds
+	alert = as.scopeAlertToHost(alert, metrics.Hostname)
+	if alert == nil {
+		return nil
+	}
+
 	// Get current metric value based on alert type
 	var currentValue float64
 	var hostname string
+	var processPID int32
 
 	switch alert.MetricType {
 	case "cpu":
@@ -195,151 +248,121 @@ func (as *AlertService) checkSingleAlert(alert *models.Alert, metrics *models.Sy
 			currentValue = metrics.CPU.LoadAvg[2]
 		}
 		hostname = metrics.Hostname
+	case "file_handles":
+		currentValue = metrics.Limits.FileHandlesPercent
+		hostname = metrics.Hostname
+	case "pid_usage":
+		currentValue = metrics.Limits.PIDPercent
+		hostname = metrics.Hostname
+	case "threads":
+		currentValue = float64(metrics.Limits.Threads)
+		hostname = metrics.Hostname
+	case "conntrack":
+		currentValue = metrics.Limits.ConntrackPercent
+		hostname = metrics.Hostname
+	case "process_fds":
+		currentValue = metrics.Limits.MaxProcessFDPercent
+		hostname = metrics.Hostname
+	case MetricAnomaly:
+		return as.checkAnomaly(alert, metrics)
+	case MetricDiskForecast:
+		return as.checkDiskForecast(alert, metrics)
+	case MetricExpression:
+		expr, err := ParseExpression(alert.Expression)
+		if err != nil {
+			log.Printf("❌ Invalid expression for alert %d: %v", alert.ID, err)
+			return err
+		}
+		value, err := expr.Evaluate(windowEnv{metricsEnv: metricsEnv{metrics: metrics}, as: as, hostname: metrics.Hostname})
+		if err != nil {
+			log.Printf("❌ Error evaluating expression for alert %d: %v", alert.ID, err)
+			return err
+		}
+		return as.evaluateAlertValue(alert, metrics.Hostname, value,
+			as.generateExpressionAlertMessage(alert, expr, metrics), nil)
 	default:
-		log.Printf("Unknown metric type: %s", alert.MetricType)
-		return nil
+		if IsProbeMetricType(alert.MetricType) {
+			return nil // Evaluated from probe results in CheckProbeResult
+		}
+		if IsCertificateMetricType(alert.MetricType) {
+			return nil // Evaluated from certificate inspections in CheckCertificates
+		}
+		if IsNoDataMetricType(alert.MetricType) {
+			return nil // Evaluated on the ticker in checkAbsentAlerts
+		}
+		if !IsProcessMetricType(alert.MetricType) {
+			log.Printf("Unknown metric type: %s", alert.MetricType)
+			return nil
+		}
+		value, pid, err := as.evaluateProcessMetric(alert, metrics)
+		if err != nil {
+			log.Printf("❌ Error evaluating process metric for alert %d: %v", alert.ID, err)
+			return err
+		}
+		currentValue = value
+		processPID = pid
+		hostname = metrics.Hostname
 	}
 
-	alertKey := fmt.Sprintf("%d_%s", alert.ID, hostname)
+	// Aggregate the metric over its window instead of using the instantaneous value
+	if alert.Aggregation != "" && IsMetricField(alert.MetricType) {
+		samples := as.windowSamples(hostname, alert.MetricType, aggregationWindow(alert))
+		if len(samples) == 0 {
+			samples = []MetricSample{{Time: time.Now(), Value: currentValue}}
+		}
+		value, err := AggregateSamples(alert.Aggregation, samples)
+		if err != nil {
+			log.Printf("❌ Error aggregating metric for alert %d: %v", alert.ID, err)
+			return err
+		}
+		currentValue = value
+	}
+
+	message := as.generateAlertMessage(alert, currentValue, hostname)
+	extra := map[string]interface{}{}
+	if IsProcessMetricType(alert.MetricType) {
+		message = as.generateProcessAlertMessage(alert, currentValue, processPID, hostname)
+		if processPID > 0 {
+			extra["pid"] = processPID
+		}
+	}
+
+	return as.evaluateAlertValue(alert, hostname, currentValue, message, extra)
+}
 
+// evaluateAlertValue applies an alert's condition, duration and cooldown to a value
+// observed for a host (or other source) and triggers or resolves the alert.
+// extra holds additional fields broadcast to WebSocket clients.
+func (as *AlertService) evaluateAlertValue(alert *models.Alert, hostname string, currentValue float64, message string, extra map[string]interface{}) error {
 	// Evaluate condition
-	conditionMet, err := as.evaluateCondition(alert.Condition, currentValue, alert.Threshold)
+	conditionMet, err := as.alertConditionMet(alert, currentValue)
 	if err != nil {
 		log.Printf("❌ Error evaluating condition for alert %d: %v", alert.ID, err)
 		return err
 	}
+	if !conditionMet {
+		// Hysteresis: firing alerts only clear once past their clear threshold
+		conditionMet, err = as.clearConditionMet(alert, hostname, currentValue)
+		if err != nil {
+			log.Printf("❌ Error evaluating clear threshold for alert %d: %v", alert.ID, err)
+			return err
+		}
+	}
 
 	log.Printf("🔍 Alert %d (%s): %s %s %.2f (current: %.2f) - Condition met: %v",
 		alert.ID, alert.Name, alert.MetricType, alert.Condition, alert.Threshold, currentValue, conditionMet)
 
-	if conditionMet {
-		// Check if alert should trigger (duration/cooldown logic)
-		if as.shouldTriggerAlert(alert, alertKey) {
-			log.Printf("🚨 Alert %d TRIGGERING: %s on %s (%.2f %s %.2f)",
-				alert.ID, alert.Name, hostname, currentValue, alert.Condition, alert.Threshold)
-
-			// Create alert history entry
-			history := &models.AlertHistory{
-				AlertID:     alert.ID,
-				Hostname:    metrics.Hostname,
-				MetricValue: currentValue,
-				Threshold:   alert.Threshold,
-				Severity:    alert.Severity,
-				Message:     as.generateAlertMessage(alert, currentValue, metrics.Hostname),
-				Resolved:    false,
-			}
-
-			if err := as.alertRepo.CreateAlertHistory(history); err != nil {
-				log.Printf("❌ Failed to create alert history: %v", err)
-			} else {
-				log.Printf("🚨 Alert triggered: %s on %s (%.2f %s threshold)",
-					alert.Name, metrics.Hostname, currentValue, alert.Condition)
-
-				as.mutex.Lock()
-				as.triggeredCount++
-				as.mutex.Unlock()
-
-				// Update alert trigger statistics
-				if err := as.alertRepo.UpdateAlertTriggerStats(alert.ID); err != nil {
-					log.Printf("❌ Failed to update alert trigger stats: %v", err)
-				}
-
-				// Broadcast alert to WebSocket clients
-				if as.websocketHandler != nil {
-					alertData := map[string]interface{}{
-						"alert_id":      alert.ID,
-						"alert_name":    alert.Name,
-						"hostname":      metrics.Hostname,
-						"metric_type":   alert.MetricType,
-						"condition":     alert.Condition,
-						"threshold":     alert.Threshold,
-						"current_value": currentValue,
-						"severity":      alert.Severity,
-						"message":       history.Message,
-						"timestamp":     time.Now(),
-					}
-
-					// Try to call BroadcastAlert method if it exists
-					if broadcaster, ok := as.websocketHandler.(interface{ BroadcastAlert(map[string]interface{}) }); ok {
-						broadcaster.BroadcastAlert(alertData)
-					}
-				}
-
-				// Send notifications
-				go as.sendNotifications(alert, history)
-			}
-
-			// Update last alert time
-			as.mutex.Lock()
-			as.lastAlerts[alertKey] = time.Now()
-			as.mutex.Unlock()
-		} else {
-			// Alert condition met but not triggering due to duration/cooldown
-			log.Printf("⏳ Alert %d condition met but not triggering (duration: %ds, cooldown check)",
-				alert.ID, alert.Duration)
-
-			as.mutex.Lock()
-			if _, exists := as.alertDurations[alertKey]; !exists {
-				as.alertDurations[alertKey] = time.Now()
-				log.Printf("⏰ Started duration tracking for alert %d", alert.ID)
-			}
-			as.mutex.Unlock()
-		}
-	} else {
-		// Alert condition not met, clear duration tracking
-		as.mutex.Lock()
-		delete(as.alertDurations, alertKey)
-		as.mutex.Unlock()
-
-		// Check if we should auto-resolve any unresolved alerts
-		go as.autoResolveAlerts(alert.ID, metrics.Hostname)
-	}
-
+	as.transitionAlert(alert, hostname, conditionMet, currentValue, message, extra)
 	return nil
 }
 
-// shouldTriggerAlert determines if an alert should be triggered
-func (as *AlertService) shouldTriggerAlert(alert *models.Alert, alertKey string) bool {
-	as.mutex.RLock()
-	defer as.mutex.RUnlock()
-
-	log.Printf("🔍 Checking alert %d (duration: %d) for key: %s", alert.ID, alert.Duration, alertKey)
-
-	// For immediate alerts (duration 0), skip cooldown check and trigger immediately
-	if alert.Duration == 0 {
-		log.Printf("✅ Alert %d has duration 0, triggering immediately", alert.ID)
-		return true
-	}
-
-	log.Printf("⏳ Alert %d has duration > 0, checking cooldown", alert.ID)
-	// Check cooldown period
-	if lastTriggered, exists := as.lastAlerts[alertKey]; exists {
-		if time.Since(lastTriggered) < as.config.CooldownPeriod {
-			log.Printf("❌ Alert %d in cooldown", alert.ID)
-			return false // Still in cooldown
-		}
-		log.Printf("✅ Alert %d cooldown passed", alert.ID)
-	} else {
-		log.Printf("ℹ️ Alert %d no previous trigger", alert.ID)
-	}
-
-	// Check duration requirement
-	if alert.Duration > 0 {
-		if firstTriggered, exists := as.alertDurations[alertKey]; exists {
-			requiredDuration := time.Duration(alert.Duration) * time.Second
-			if time.Since(firstTriggered) < requiredDuration {
-				log.Printf("❌ Alert %d duration not met", alert.ID)
-				return false // Haven't been triggered long enough
-			}
-			log.Printf("✅ Alert %d duration met", alert.ID)
-		} else {
-			log.Printf("❌ Alert %d first time, starting duration tracking", alert.ID)
-			return false // First time seeing this condition
-		}
+// alertConditionMet reports whether a value satisfies an alert's condition.
+// Expression alerts without a condition fire when the expression is true (non-zero).
+func (as *AlertService) alertConditionMet(alert *models.Alert, value float64) (bool, error) {
+	if IsExpressionMetricType(alert.MetricType) && strings.TrimSpace(alert.Condition) == "" {
+		return value != 0, nil
 	}
-
-	log.Printf("✅ Alert %d can trigger", alert.ID)
-	return true
+	return as.evaluateCondition(alert.Condition, value, alert.Threshold)
 }
 
 // evaluateCondition evaluates alert condition
@@ -362,16 +385,30 @@ func (as *AlertService) evaluateCondition(condition string, value, threshold flo
 	}
 }
 
+// exceeds reports whether value is further past the threshold of a condition than peak,
+// i.e. lower for less-than conditions and higher otherwise
+func exceeds(condition string, value, peak float64) bool {
+	switch strings.ToLower(strings.TrimSpace(condition)) {
+	case "<", "<=", "lt", "lte", "less_than", "less_than_equal":
+		return value < peak
+	default:
+		return value > peak
+	}
+}
+
 // generateAlertMessage generates a human-readable alert message
 func (as *AlertService) generateAlertMessage(alert *models.Alert, value float64, hostname string) string {
 	unit := ""
 	switch strings.ToLower(alert.MetricType) {
-	case "cpu", "memory", "disk":
+	case "cpu", "memory", "disk", "file_handles", "pid_usage", "conntrack", "process_fds":
 		unit = "%"
 	}
 
 	// Title-case metric type in a Unicode-aware way
 	title := cases.Title(language.Und).String(strings.ToLower(alert.MetricType))
+	if alert.Aggregation != "" {
+		title = fmt.Sprintf("%s(%s, %s)", strings.ToLower(alert.Aggregation), title, aggregationWindow(alert))
+	}
 	return fmt.Sprintf("%s %s %.2f%s (threshold: %.2f%s) on %s",
 		title,
 		alert.Condition,
@@ -380,29 +417,42 @@ func (as *AlertService) generateAlertMessage(alert *models.Alert, value float64,
 		hostname)
 }
 
-// sendNotifications sends email and webhook notifications
-func (as *AlertService) sendNotifications(alert *models.Alert, history *models.AlertHistory) {
+// metricUnit returns the unit shown next to values of a metric type in notifications
+func metricUnit(metricType string) string {
+	metricType = strings.ToLower(metricType)
+	if IsForecastMetricType(metricType) {
+		return "h"
+	}
+	if strings.Contains(metricType, "cpu") ||
+		strings.Contains(metricType, "memory") ||
+		strings.Contains(metricType, "disk") {
+		return "%"
+	}
+	return ""
+}
+
+// generateExpressionAlertMessage generates an alert message listing the fields an expression references
+func (as *AlertService) generateExpressionAlertMessage(alert *models.Alert, expr *Expression, metrics *models.SystemMetrics) string {
+	values := make([]string, 0, len(expr.Fields()))
+	for _, field := range expr.Fields() {
+		value, _ := MetricFieldValue(metrics, field)
+		values = append(values, fmt.Sprintf("%s=%.2f", field, value))
+	}
+
+	return fmt.Sprintf("Expression '%s' matched on %s (%s)", expr, metrics.Hostname, strings.Join(values, ", "))
+}
+
+// sendAlertNotifications sends email and webhook notifications using the alert's own settings
+func (as *AlertService) sendAlertNotifications(alert *models.Alert, history *models.AlertHistory) {
 	// Send email notification
 	if alert.EmailEnabled && as.emailSender != nil {
-		if err := as.emailSender.SendAlert(alert, history); err != nil {
+		if err := as.emailSender.SendAlert(alert, history, as.RenderNotification(ChannelTypeEmail, alert, history)); err != nil {
 			log.Printf("❌ Failed to send email alert: %v", err)
 		} else {
 			log.Printf("📧 Email alert sent successfully")
 
 			// Update history to mark email as sent
-			if err := as.alertRepo.CreateAlertHistory(&models.AlertHistory{
-				BaseModel:   history.BaseModel,
-				AlertID:     history.AlertID,
-				Hostname:    history.Hostname,
-				MetricValue: history.MetricValue,
-				Threshold:   history.Threshold,
-				Severity:    history.Severity,
-				Message:     history.Message,
-				Resolved:    history.Resolved,
-				ResolvedAt:  history.ResolvedAt,
-				EmailSent:   true,
-				WebhookSent: history.WebhookSent,
-			}); err != nil {
+			if err := as.alertRepo.MarkNotificationSent(history.ID, NotificationEmail); err != nil {
 				log.Printf("❌ Failed to update email sent status: %v", err)
 			}
 		}
@@ -410,63 +460,38 @@ func (as *AlertService) sendNotifications(alert *models.Alert, history *models.A
 
 	// Send webhook notification
 	if alert.WebhookEnabled && as.webhookSender != nil {
-		if err := as.webhookSender.SendAlert(alert, history); err != nil {
+		message := as.RenderNotification(DetectWebhookFormat(alert.WebhookURL), alert, history)
+		if err := as.webhookSender.SendAlert(alert, history, message); err != nil {
 			log.Printf("❌ Failed to send webhook alert: %v", err)
 		} else {
 			log.Printf("🔗 Webhook alert sent successfully")
 
 			// Update history to mark webhook as sent
-			if err := as.alertRepo.CreateAlertHistory(&models.AlertHistory{
-				BaseModel:   history.BaseModel,
-				AlertID:     history.AlertID,
-				Hostname:    history.Hostname,
-				MetricValue: history.MetricValue,
-				Threshold:   history.Threshold,
-				Severity:    history.Severity,
-				Message:     history.Message,
-				Resolved:    history.Resolved,
-				ResolvedAt:  history.ResolvedAt,
-				EmailSent:   history.EmailSent,
-				WebhookSent: true,
-			}); err != nil {
+			if err := as.alertRepo.MarkNotificationSent(history.ID, NotificationWebhook); err != nil {
 				log.Printf("❌ Failed to update webhook sent status: %v", err)
 			}
 		}
 	}
 }
 
-// autoResolveAlerts automatically resolves alerts when conditions are no longer met
-func (as *AlertService) autoResolveAlerts(alertID uint, hostname string) {
-	unresolved, err := as.alertRepo.GetUnresolvedAlerts()
-	if err != nil {
-		log.Printf("❌ Failed to get unresolved alerts: %v", err)
-		return
-	}
-
-	for _, history := range unresolved {
-		if history.AlertID == alertID && history.Hostname == hostname {
-			if err := as.alertRepo.ResolveAlert(history.ID); err != nil {
-				log.Printf("❌ Failed to auto-resolve alert: %v", err)
-			} else {
-				log.Printf("✅ Auto-resolved alert: %s on %s", history.Alert.Name, hostname)
-			}
-		}
-	}
-}
-
 // alertCheckingRoutine runs the periodic alert checking
 func (as *AlertService) alertCheckingRoutine() {
 	log.Printf("🔍 Starting alert checking routine with %v interval", as.config.CheckInterval)
 
+	as.seedHeartbeats()
+
 	ticker := time.NewTicker(as.config.CheckInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			// Alert checking is now primarily driven by metrics collection
-			// This routine can be used for cleanup and maintenance tasks
+			// Alert checking is now primarily driven by metrics collection.
+			// No-data alerts are evaluated here since silent hosts send nothing to check.
+			as.ReloadSilences()
+			as.checkAbsentAlerts()
 			as.cleanupOldAlertState()
+			as.syncAlertInstances()
 
 		case <-as.stopChan:
 			log.Println("🔍 Alert checking routine stopped")
@@ -484,19 +509,26 @@ func (as *AlertService) cleanupOldAlertState() {
 	as.mutex.Lock()
 	defer as.mutex.Unlock()
 
-	cutoff := time.Now().Add(-24 * time.Hour)
+	// Clean up usage histories of partitions that stopped reporting
+	for key, history := range as.partitionUsage {
+		if len(history) == 0 || time.Since(history[len(history)-1].Time) > MaxForecastLookback {
+			delete(as.partitionUsage, key)
+		}
+	}
+
+	as.cleanupHeartbeats()
 
-	// Clean up old duration tracking
-	for key, timestamp := range as.alertDurations {
-		if timestamp.Before(cutoff) {
-			delete(as.alertDurations, key)
+	// Clean up anomaly baselines of hosts that stopped reporting
+	for key, baseline := range as.anomalyBaselines {
+		if !baseline.lastObserved.IsZero() && time.Since(baseline.lastObserved) > anomalyBaselineTTL {
+			delete(as.anomalyBaselines, key)
 		}
 	}
 
-	// Clean up old last alert times
-	for key, timestamp := range as.lastAlerts {
-		if timestamp.Before(cutoff) {
-			delete(as.lastAlerts, key)
+	// Clean up sample buffers of hosts that stopped reporting
+	for hostname, buffer := range as.samples {
+		if time.Since(buffer.latest()) > MaxAggregationWindow {
+			delete(as.samples, hostname)
 		}
 	}
 }
@@ -506,16 +538,37 @@ func (as *AlertService) GetStats() map[string]interface{} {
 	as.mutex.RLock()
 	defer as.mutex.RUnlock()
 
+	var pending, firing, cooldown int
+	for _, instance := range as.instances {
+		switch instance.State {
+		case AlertStatePending:
+			pending++
+		case AlertStateFiring:
+			firing++
+		}
+		if !instance.LastNotifiedAt.IsZero() && time.Since(instance.LastNotifiedAt) < as.config.CooldownPeriod {
+			cooldown++
+		}
+	}
+
 	return map[string]interface{}{
 		"is_running":       as.isRunning,
 		"checked_count":    as.checkedCount,
 		"triggered_count":  as.triggeredCount,
 		"last_check_time":  as.lastCheckTime,
-		"active_durations": len(as.alertDurations),
-		"cooldown_alerts":  len(as.lastAlerts),
+		"active_durations": pending,
+		"firing_alerts":    firing,
+		"cooldown_alerts":  cooldown,
 	}
 }
 
+// SetMetricsRepository sets the repository used to seed anomaly baselines from stored history
+func (as *AlertService) SetMetricsRepository(metricsRepo repository.MetricsRepository) {
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+	as.metricsRepo = metricsRepo
+}
+
 // SetWebSocketHandler sets the WebSocket handler for broadcasting alerts
 func (as *AlertService) SetWebSocketHandler(handler interface{}) {
 	as.websocketHandler = handler
@@ -0,0 +1,477 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// Alert instance states. An instance moves inactive → pending → firing → resolved;
+// alerts without a duration go straight from inactive or resolved to firing.
+const (
+	AlertStateInactive = "inactive" // Condition not met
+	AlertStatePending  = "pending"  // Condition met, waiting for the alert duration
+	AlertStateFiring   = "firing"   // Incident open and notified
+	AlertStateResolved = "resolved" // Condition cleared after firing
+)
+
+// Alert lifecycle event types, as sent to the WebSocket and webhooks
+const (
+	AlertEventFiring   = "alert_triggered"
+	AlertEventResolved = "alert_resolved"
+)
+
+// Notification channels recorded on alert history entries
+const (
+	NotificationEmail   = "email"
+	NotificationWebhook = "webhook"
+)
+
+// alertInstanceRetention is how long inactive, resolved or no longer evaluated instances are kept
+const alertInstanceRetention = 24 * time.Hour
+
+// AlertEvent describes a state transition of an alert on a host
+type AlertEvent struct {
+	Type     string
+	Alert    *models.Alert
+	Instance models.AlertInstance
+	History  *models.AlertHistory
+	Extra    map[string]interface{} // Metric specific details for the WebSocket
+}
+
+// AlertStates returns the states an alert instance can be in
+func AlertStates() []string {
+	return []string{AlertStateInactive, AlertStatePending, AlertStateFiring, AlertStateResolved}
+}
+
+// IsAlertState reports whether state is a known alert instance state
+func IsAlertState(state string) bool {
+	for _, known := range AlertStates() {
+		if state == known {
+			return true
+		}
+	}
+	return false
+}
+
+// alertInstanceKey identifies the state of an alert on a host
+func alertInstanceKey(alertID uint, hostname string) string {
+	return fmt.Sprintf("%d_%s", alertID, hostname)
+}
+
+// restoreAlertInstances loads persisted alert instance state so cooldowns, pending durations
+// and open incidents survive restarts. Unresolved history entries without a firing instance
+// are adopted, so the state machine resolves them once their condition clears.
+// Callers must hold as.mutex.
+func (as *AlertService) restoreAlertInstances() {
+	instances, err := as.alertRepo.GetAlertInstances(0, "")
+	if err != nil {
+		log.Printf("❌ Failed to restore alert state: %v", err)
+		return
+	}
+
+	open := make(map[uint]bool)
+	for _, instance := range instances {
+		instance.Alert = models.Alert{}
+		as.instances[alertInstanceKey(instance.AlertID, instance.Hostname)] = instance
+		if instance.State == AlertStateFiring && instance.HistoryID != 0 {
+			open[instance.HistoryID] = true
+		}
+	}
+
+	if len(instances) > 0 {
+		log.Printf("♻️ Restored state of %d alert instances", len(instances))
+	}
+
+	unresolved, err := as.alertRepo.GetUnresolvedAlerts()
+	if err != nil {
+		log.Printf("❌ Failed to get unresolved alerts: %v", err)
+		return
+	}
+
+	// Newest first: the first entry per alert and host is the incident, older ones are duplicates
+	for _, history := range unresolved {
+		if open[history.ID] {
+			continue
+		}
+
+		key := alertInstanceKey(history.AlertID, history.Hostname)
+		if instance, exists := as.instances[key]; exists && instance.State == AlertStateFiring {
+			if err := as.alertRepo.ResolveAlert(history.ID); err != nil {
+				log.Printf("❌ Failed to resolve duplicate alert: %v", err)
+			}
+			continue
+		}
+
+		as.instances[key] = &models.AlertInstance{
+			AlertID:         history.AlertID,
+			Hostname:        history.Hostname,
+			State:           AlertStateFiring,
+			Since:           history.CreatedAt,
+			HistoryID:       history.ID,
+			LastValue:       history.MetricValue,
+			LastEvaluatedAt: history.CreatedAt,
+			LastNotifiedAt:  history.CreatedAt,
+			Acknowledged:    history.Acknowledged,
+			Severity:        history.Severity,
+		}
+		as.dirtyInstances[key] = true
+	}
+}
+
+// nextAlertState returns the state an instance moves to after an evaluation, and whether
+// the evaluation notifies. Firing instances notify again once the cooldown has passed,
+// unless the incident has been acknowledged.
+func nextAlertState(alert *models.Alert, instance *models.AlertInstance, conditionMet bool, now time.Time, cooldown time.Duration) (string, bool) {
+	if !conditionMet {
+		switch instance.State {
+		case AlertStateFiring:
+			return AlertStateResolved, true
+		case AlertStatePending:
+			return AlertStateInactive, false
+		default:
+			return instance.State, false
+		}
+	}
+
+	switch instance.State {
+	case AlertStateFiring:
+		return AlertStateFiring, !instance.Acknowledged && now.Sub(instance.LastNotifiedAt) >= cooldown
+	case AlertStatePending:
+		if now.Sub(instance.Since) >= time.Duration(alert.Duration)*time.Second {
+			return AlertStateFiring, true
+		}
+		return AlertStatePending, false
+	default:
+		if alert.Duration <= 0 {
+			return AlertStateFiring, true
+		}
+		return AlertStatePending, false
+	}
+}
+
+// transitionAlert applies one evaluation of an alert on a host to its instance and handles
+// the resulting incident and events. Hosts that never met the condition get no instance.
+func (as *AlertService) transitionAlert(alert *models.Alert, hostname string, conditionMet bool, value float64, message string, extra map[string]interface{}) {
+	key := alertInstanceKey(alert.ID, hostname)
+	now := time.Now()
+
+	as.mutex.Lock()
+	instance, exists := as.instances[key]
+	if !exists {
+		if !conditionMet {
+			as.mutex.Unlock()
+			return
+		}
+		instance = &models.AlertInstance{AlertID: alert.ID, Hostname: hostname, State: AlertStateInactive, Since: now}
+		as.instances[key] = instance
+	}
+
+	instance.LastValue = value
+	instance.LastEvaluatedAt = now
+	as.dirtyInstances[key] = true
+
+	from := instance.State
+	to, notify := nextAlertState(alert, instance, conditionMet, now, as.config.CooldownPeriod)
+
+	// Multi-level alerts fire at the severity of the highest level the value meets. Escalations
+	// always notify; de-escalations notify like repeat notifications, not once acknowledged or
+	// within the cooldown.
+	previousSeverity := instance.Severity
+	if to == AlertStateFiring {
+		instance.Severity = as.nextSeverity(alert, instance, value, now)
+	}
+	severityChanged := from == AlertStateFiring && to == AlertStateFiring &&
+		previousSeverity != "" && previousSeverity != instance.Severity
+	if severityChanged && severityRank(instance.Severity) > severityRank(previousSeverity) {
+		notify = true
+	}
+	// The peak is the value furthest past the threshold while firing
+	if to == AlertStateFiring && (from != AlertStateFiring || exceeds(alert.Condition, value, instance.PeakValue)) {
+		instance.PeakValue = value
+	}
+	if to != from {
+		instance.State = to
+		instance.Since = now
+		if to == AlertStateFiring {
+			// A new incident starts unacknowledged and unescalated
+			instance.Acknowledged = false
+			instance.EscalationLevel = 0
+			instance.LastEscalatedAt = time.Time{}
+		}
+	}
+	if notify {
+		instance.LastNotifiedAt = now
+	}
+	if (to == AlertStateFiring) != (from == AlertStateFiring) {
+		instance.StateChanges = append(instance.StateChanges, now)
+	}
+	flapping, stabilized := as.updateFlapping(instance, now)
+	snapshot := *instance
+	as.mutex.Unlock()
+
+	if to == from && !notify && !severityChanged && !flapping && !stabilized {
+		return
+	}
+
+	if to != from {
+		log.Printf("🔀 Alert %d (%s) on %s: %s → %s", alert.ID, alert.Name, hostname, from, to)
+	}
+
+	alert = alertAtSeverity(alert, snapshot.Severity)
+	switch {
+	case to == AlertStateFiring && from != AlertStateFiring:
+		as.openIncident(alert, &snapshot, message, extra)
+	case severityChanged:
+		as.changeIncidentSeverity(alert, &snapshot, previousSeverity, message, extra, notify)
+	case to == AlertStateFiring && notify:
+		as.updateIncident(alert, &snapshot, message, extra)
+	case to == AlertStateResolved && from != AlertStateResolved:
+		as.resolveIncident(alert, &snapshot, "condition cleared", extra)
+	case stabilized:
+		// Without a transition, nothing told receivers the current state yet
+		as.announceStabilized(alert, &snapshot, message, extra)
+	}
+
+	if flapping {
+		as.recordFlapChange(alert, &snapshot, true)
+	} else if stabilized {
+		as.recordFlapChange(alert, &snapshot, false)
+	}
+
+	as.saveAlertInstance(&snapshot)
+}
+
+// openIncident creates the history entry of a new incident and notifies about it
+func (as *AlertService) openIncident(alert *models.Alert, instance *models.AlertInstance, message string, extra map[string]interface{}) {
+	log.Printf("🚨 Alert %d TRIGGERING: %s on %s (%.2f %s %.2f)",
+		alert.ID, alert.Name, instance.Hostname, instance.LastValue, alert.Condition, alert.Threshold)
+
+	history := &models.AlertHistory{
+		AlertID:     alert.ID,
+		Hostname:    instance.Hostname,
+		MetricValue: instance.LastValue,
+		Threshold:   alert.Threshold,
+		Severity:    alert.Severity,
+		Message:     message,
+		PeakValue:   instance.PeakValue,
+		Resolved:    false,
+	}
+
+	if err := as.alertRepo.CreateAlertHistory(history); err != nil {
+		log.Printf("❌ Failed to create alert history: %v", err)
+		return
+	}
+
+	instance.HistoryID = history.ID
+	as.mutex.Lock()
+	if current, exists := as.instances[alertInstanceKey(alert.ID, instance.Hostname)]; exists {
+		current.HistoryID = history.ID
+	}
+	as.triggeredCount++
+	as.mutex.Unlock()
+
+	// Update alert trigger statistics
+	if err := as.alertRepo.UpdateAlertTriggerStats(alert.ID); err != nil {
+		log.Printf("❌ Failed to update alert trigger stats: %v", err)
+	}
+
+	as.emitAlertEvent(&AlertEvent{Type: AlertEventFiring, Alert: alert, Instance: *instance, History: history, Extra: extra})
+}
+
+// updateIncident refreshes the history entry of a still firing incident and notifies again
+func (as *AlertService) updateIncident(alert *models.Alert, instance *models.AlertInstance, message string, extra map[string]interface{}) {
+	history, err := as.alertRepo.GetIncident(instance.HistoryID)
+	if err != nil {
+		log.Printf("❌ Failed to load incident %d: %v", instance.HistoryID, err)
+		return
+	}
+
+	history.MetricValue = instance.LastValue
+	history.PeakValue = instance.PeakValue
+	history.Message = message
+	if err := as.alertRepo.UpdateAlertHistory(history); err != nil {
+		log.Printf("❌ Failed to update alert history: %v", err)
+	}
+
+	as.emitAlertEvent(&AlertEvent{Type: AlertEventFiring, Alert: alert, Instance: *instance, History: history, Extra: extra})
+}
+
+// resolveIncident marks the incident of an instance resolved and notifies about it
+func (as *AlertService) resolveIncident(alert *models.Alert, instance *models.AlertInstance, reason string, extra map[string]interface{}) {
+	if instance.HistoryID == 0 {
+		return
+	}
+
+	history, err := as.alertRepo.GetIncident(instance.HistoryID)
+	if err != nil {
+		log.Printf("❌ Failed to load incident %d: %v", instance.HistoryID, err)
+		return
+	}
+	if history.Resolved {
+		// Already resolved by hand
+		return
+	}
+
+	history.Resolved = true
+	history.ResolvedAt = instance.Since
+	history.PeakValue = instance.PeakValue
+	if err := as.alertRepo.UpdateAlertHistory(history); err != nil {
+		log.Printf("❌ Failed to resolve alert: %v", err)
+		return
+	}
+
+	log.Printf("✅ Auto-resolved alert: %s on %s after %s", alert.Name, instance.Hostname, formatIncidentDuration(history))
+	as.recordIncidentEvent(history.ID, IncidentEventResolve, SystemUser, reason)
+	as.emitAlertEvent(&AlertEvent{Type: AlertEventResolved, Alert: alert, Instance: *instance, History: history, Extra: extra})
+}
+
+// emitAlertEvent broadcasts an alert event to WebSocket clients and sends notifications,
+// unless a silence matches the alert
+func (as *AlertService) emitAlertEvent(event *AlertEvent) {
+	silence := as.matchingSilence(event.Alert, event.Instance.Hostname, time.Now())
+
+	if as.websocketHandler != nil {
+		alertData := map[string]interface{}{
+			"alert_id":      event.Alert.ID,
+			"alert_name":    event.Alert.Name,
+			"hostname":      event.Instance.Hostname,
+			"metric_type":   event.Alert.MetricType,
+			"condition":     event.Alert.Condition,
+			"threshold":     event.Alert.Threshold,
+			"current_value": event.Instance.LastValue,
+			"severity":      event.Alert.Severity,
+			"message":       event.History.Message,
+			"state":         event.Instance.State,
+			"incident_id":   event.History.ID,
+			"silenced":      silence != nil,
+			"flapping":      event.Instance.Flapping,
+			"timestamp":     time.Now(),
+		}
+		for key, value := range event.Extra {
+			alertData[key] = value
+		}
+
+		// Try to call BroadcastAlertEvent method if it exists
+		if broadcaster, ok := as.websocketHandler.(interface {
+			BroadcastAlertEvent(string, map[string]interface{})
+		}); ok {
+			broadcaster.BroadcastAlertEvent(event.Type, alertData)
+		}
+	}
+
+	if silence != nil {
+		log.Printf("🔕 Notifications for alert %d on %s suppressed by silence %d", event.Alert.ID, event.Instance.Hostname, silence.ID)
+		return
+	}
+	if event.Instance.Flapping {
+		log.Printf("〰️ Notifications for alert %d on %s suppressed while flapping", event.Alert.ID, event.Instance.Hostname)
+		return
+	}
+
+	if event.Type == AlertEventFiring && as.escalationPolicy(event.Alert) != nil {
+		// The escalation policy decides who is notified and when
+		go as.processEscalations(time.Now())
+		return
+	}
+
+	// Send notifications with a copy, the history is updated as channels deliver
+	history := *event.History
+	go as.sendNotifications(event.Alert, &history)
+}
+
+// saveAlertInstance persists a snapshot of an alert instance
+func (as *AlertService) saveAlertInstance(snapshot *models.AlertInstance) {
+	if err := as.alertRepo.SaveAlertInstance(snapshot); err != nil {
+		log.Printf("❌ Failed to save alert state: %v", err)
+		return
+	}
+
+	key := alertInstanceKey(snapshot.AlertID, snapshot.Hostname)
+
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	if current, exists := as.instances[key]; exists {
+		if current.ID == 0 {
+			current.BaseModel = snapshot.BaseModel
+		}
+		if current.LastEvaluatedAt.Equal(snapshot.LastEvaluatedAt) {
+			delete(as.dirtyInstances, key)
+		}
+	}
+}
+
+// syncAlertInstances flushes evaluation times to the database and drops instances that
+// went inactive or resolved, or stopped being evaluated, longer than alertInstanceRetention ago
+func (as *AlertService) syncAlertInstances() {
+	var (
+		snapshots []models.AlertInstance
+		expired   []uint
+		abandoned []models.AlertInstance // Firing instances whose incident must be resolved
+	)
+
+	as.mutex.Lock()
+	for key, instance := range as.instances {
+		stale := time.Since(instance.LastEvaluatedAt) > alertInstanceRetention
+		if (instance.State == AlertStateInactive || instance.State == AlertStateResolved) &&
+			time.Since(instance.Since) > alertInstanceRetention {
+			stale = true
+		}
+		if stale {
+			if instance.State == AlertStateFiring {
+				abandoned = append(abandoned, *instance)
+			}
+			delete(as.instances, key)
+			delete(as.dirtyInstances, key)
+			if instance.ID != 0 {
+				expired = append(expired, instance.ID)
+			}
+			continue
+		}
+		if as.dirtyInstances[key] {
+			snapshots = append(snapshots, *instance)
+		}
+	}
+	as.mutex.Unlock()
+
+	for i := range snapshots {
+		as.saveAlertInstance(&snapshots[i])
+	}
+
+	for i := range abandoned {
+		as.expireIncident(&abandoned[i])
+	}
+
+	for _, id := range expired {
+		if err := as.alertRepo.DeleteAlertInstance(id); err != nil {
+			log.Printf("❌ Failed to delete alert state: %v", err)
+		}
+	}
+}
+
+// expireIncident resolves the incident of a firing instance that is no longer evaluated, e.g.
+// because its host stopped reporting, so it is not adopted again on restart
+func (as *AlertService) expireIncident(instance *models.AlertInstance) {
+	if instance.HistoryID == 0 {
+		return
+	}
+
+	alert, err := as.alertRepo.GetAlertByID(instance.AlertID)
+	if err != nil {
+		// Without the alert there is nobody to notify, only close the incident
+		log.Printf("❌ Failed to load alert %d: %v", instance.AlertID, err)
+		if err := as.alertRepo.ResolveAlert(instance.HistoryID); err != nil {
+			log.Printf("❌ Failed to resolve alert: %v", err)
+			return
+		}
+		as.recordIncidentEvent(instance.HistoryID, IncidentEventResolve, SystemUser, "no longer evaluated")
+		return
+	}
+
+	instance.State = AlertStateResolved
+	instance.Since = time.Now()
+	as.resolveIncident(alertAtSeverity(alert, instance.Severity), instance, "no longer evaluated", nil)
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func newDurationAlert() *models.Alert {
+	return &models.Alert{
+		BaseModel:  models.BaseModel{ID: 1},
+		Name:       "high cpu",
+		MetricType: "cpu",
+		Condition:  ">",
+		Threshold:  80,
+		Duration:   60,
+		IsActive:   true,
+	}
+}
+
+// eventRecorder collects alert events broadcast to WebSocket clients
+type eventRecorder struct {
+	events []string
+}
+
+func (r *eventRecorder) BroadcastAlertEvent(eventType string, data map[string]interface{}) {
+	r.events = append(r.events, fmt.Sprintf("%s %v %v", eventType, data["hostname"], data["state"]))
+}
+
+func TestAlertService_InstanceLifecycle(t *testing.T) {
+	alert := newDurationAlert()
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+	recorder := &eventRecorder{}
+	as.SetWebSocketHandler(recorder)
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	// Hosts that never meet the condition get no instance
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 10, "", nil))
+	assert.Empty(t, as.instances)
+
+	// Condition met: pending until the duration passes
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	instances, _ := repo.GetAlertInstances(alert.ID, AlertStatePending)
+	require.Len(t, instances, 1)
+	assert.Equal(t, "web-1", instances[0].Hostname)
+	assert.Empty(t, repo.historySnapshot())
+
+	// Pretend the condition has held for the whole duration
+	as.instances[key].Since = time.Now().Add(-2 * time.Minute)
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 95, "cpu at 95", nil))
+	instances, _ = repo.GetAlertInstances(alert.ID, AlertStateFiring)
+	require.Len(t, instances, 1)
+	assert.False(t, instances[0].LastNotifiedAt.IsZero())
+	assert.Equal(t, 95.0, instances[0].LastValue)
+	history := repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.Equal(t, history[0].ID, instances[0].HistoryID)
+
+	// Still firing within the cooldown: no new notification or history
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 96, "cpu at 96", nil))
+	assert.Len(t, recorder.events, 1)
+
+	// After the cooldown the same incident is updated in place and notified again
+	as.instances[key].LastNotifiedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 97, "cpu at 97", nil))
+	history = repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.Equal(t, 97.0, history[0].MetricValue)
+	assert.Equal(t, "cpu at 97", history[0].Message)
+
+	// Condition cleared: the incident resolves
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 10, "", nil))
+	assert.Equal(t, AlertStateResolved, as.instances[key].State)
+	history = repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.True(t, history[0].Resolved)
+	assert.False(t, history[0].ResolvedAt.IsZero())
+
+	assert.Equal(t, []string{
+		"alert_triggered web-1 firing",
+		"alert_triggered web-1 firing",
+		"alert_resolved web-1 resolved",
+	}, recorder.events)
+
+	// A pending instance that clears goes back to inactive without an incident
+	require.NoError(t, as.evaluateAlertValue(alert, "web-2", 90, "", nil))
+	require.NoError(t, as.evaluateAlertValue(alert, "web-2", 10, "", nil))
+	assert.Equal(t, AlertStateInactive, as.instances[alertInstanceKey(alert.ID, "web-2")].State)
+	assert.Len(t, repo.historySnapshot(), 1)
+	assert.Len(t, recorder.events, 3)
+}
+
+func TestAlertService_ImmediateAlert(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+
+	// Alerts without a duration fire at once, and only once per incident
+	for i := 0; i < 3; i++ {
+		require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	}
+	assert.Equal(t, AlertStateFiring, as.instances[alertInstanceKey(alert.ID, "web-1")].State)
+	assert.Len(t, repo.historySnapshot(), 1)
+
+	// A new incident after resolving gets its own history entry
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 10, "", nil))
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	history := repo.historySnapshot()
+	require.Len(t, history, 2)
+	assert.True(t, history[0].Resolved)
+	assert.False(t, history[1].Resolved)
+}
+
+func TestAlertService_RestoreInstances(t *testing.T) {
+	alert := newDurationAlert()
+	repo := newFakeAlertRepo(alert)
+	now := time.Now()
+
+	// Pending long enough before the restart, and firing within the cooldown
+	require.NoError(t, repo.SaveAlertInstance(&models.AlertInstance{
+		AlertID: alert.ID, Hostname: "web-1", State: AlertStatePending,
+		Since: now.Add(-2 * time.Minute), LastEvaluatedAt: now.Add(-time.Minute),
+	}))
+	require.NoError(t, repo.SaveAlertInstance(&models.AlertInstance{
+		AlertID: alert.ID, Hostname: "web-2", State: AlertStateFiring,
+		Since: now.Add(-time.Minute), LastEvaluatedAt: now.Add(-time.Minute), LastNotifiedAt: now.Add(-time.Minute),
+	}))
+
+	as := NewAlertService(nil, repo, nil, nil)
+	as.restoreAlertInstances()
+	require.Len(t, as.instances, 2)
+
+	// The pending duration carries over and the alert fires right away
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	assert.Equal(t, AlertStateFiring, as.instances[alertInstanceKey(alert.ID, "web-1")].State)
+
+	// The firing instance is still in cooldown and does not notify again
+	require.NoError(t, as.evaluateAlertValue(alert, "web-2", 90, "", nil))
+	history := repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.Equal(t, "web-1", history[0].Hostname)
+
+	stats := as.GetStats()
+	assert.Equal(t, 2, stats["firing_alerts"])
+	assert.Equal(t, 0, stats["active_durations"])
+}
+
+func TestAlertService_AdoptUnresolvedHistory(t *testing.T) {
+	alert := newDurationAlert()
+	repo := newFakeAlertRepo(alert)
+	older := &models.AlertHistory{AlertID: alert.ID, Hostname: "web-1", MetricValue: 85}
+	newer := &models.AlertHistory{AlertID: alert.ID, Hostname: "web-1", MetricValue: 90}
+	require.NoError(t, repo.CreateAlertHistory(older))
+	require.NoError(t, repo.CreateAlertHistory(newer))
+
+	as := NewAlertService(nil, repo, nil, nil)
+	as.restoreAlertInstances()
+
+	// The newest entry becomes the open incident, older duplicates are resolved
+	instance := as.instances[alertInstanceKey(alert.ID, "web-1")]
+	require.NotNil(t, instance)
+	assert.Equal(t, AlertStateFiring, instance.State)
+	assert.Equal(t, newer.ID, instance.HistoryID)
+
+	history := repo.historySnapshot()
+	assert.True(t, history[0].Resolved)
+	assert.False(t, history[1].Resolved)
+
+	// And resolves once the condition clears
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 10, "", nil))
+	history = repo.historySnapshot()
+	assert.True(t, history[1].Resolved)
+}
+
+func TestAlertService_SyncInstances(t *testing.T) {
+	alert := newDurationAlert()
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 92, "", nil))
+	assert.Len(t, as.dirtyInstances, 1)
+
+	// Evaluations are flushed on sync
+	as.syncAlertInstances()
+	assert.Empty(t, as.dirtyInstances)
+	instances, _ := repo.GetAlertInstances(alert.ID, "")
+	require.Len(t, instances, 1)
+	assert.Equal(t, 92.0, instances[0].LastValue)
+
+	// Instances no longer evaluated expire
+	as.instances[alertInstanceKey(alert.ID, "web-1")].LastEvaluatedAt = time.Now().Add(-2 * alertInstanceRetention)
+	as.syncAlertInstances()
+	assert.Empty(t, as.instances)
+	instances, _ = repo.GetAlertInstances(alert.ID, "")
+	assert.Empty(t, instances)
+}
+
+func TestAlertService_ExpireFiringInstance(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+	recorder := &eventRecorder{}
+	as.SetWebSocketHandler(recorder)
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	historyID := as.instances[alertInstanceKey(alert.ID, "web-1")].HistoryID
+
+	// A firing host that stopped reporting resolves its incident before the instance expires
+	as.instances[alertInstanceKey(alert.ID, "web-1")].LastEvaluatedAt = time.Now().Add(-2 * alertInstanceRetention)
+	as.syncAlertInstances()
+	assert.Empty(t, as.instances)
+	history := repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.True(t, history[0].Resolved)
+	assert.Equal(t, []string{"alert_triggered web-1 firing", "alert_resolved web-1 resolved"}, recorder.events)
+
+	events, err := repo.GetIncidentEvents(historyID)
+	require.NoError(t, err)
+	assert.Equal(t, "no longer evaluated", events[len(events)-1].Comment)
+
+	// Nothing is adopted again on restart
+	restarted := NewAlertService(nil, repo, nil, nil)
+	restarted.restoreAlertInstances()
+	assert.Empty(t, restarted.instances)
+}
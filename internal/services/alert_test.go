@@ -0,0 +1,231 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// fakeAlertRepo is an in-memory AlertRepository for alert service tests
+type fakeAlertRepo struct {
+	repository.AlertRepository
+
+	mutex     sync.Mutex
+	alerts    []*models.Alert
+	history   []*models.AlertHistory
+	instances map[uint]models.AlertInstance
+	events    []*models.IncidentEvent
+	nextID    uint
+}
+
+func newFakeAlertRepo(alerts ...*models.Alert) *fakeAlertRepo {
+	return &fakeAlertRepo{alerts: alerts, instances: make(map[uint]models.AlertInstance)}
+}
+
+func (r *fakeAlertRepo) GetActiveAlerts() ([]*models.Alert, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var active []*models.Alert
+	for _, alert := range r.alerts {
+		if alert.IsActive {
+			active = append(active, alert)
+		}
+	}
+	return active, nil
+}
+
+func (r *fakeAlertRepo) GetAlertByID(id uint) (*models.Alert, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, alert := range r.alerts {
+		if alert.ID == id {
+			return alert, nil
+		}
+	}
+	return nil, fmt.Errorf("alert not found")
+}
+
+func (r *fakeAlertRepo) UpdateAlertTriggerStats(alertID uint) error {
+	return nil
+}
+
+func (r *fakeAlertRepo) CreateAlertHistory(history *models.AlertHistory) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.nextID++
+	history.ID = r.nextID
+	history.CreatedAt = time.Now()
+	copied := *history
+	r.history = append(r.history, &copied)
+	return nil
+}
+
+func (r *fakeAlertRepo) UpdateAlertHistory(history *models.AlertHistory) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.history {
+		if existing.ID == history.ID {
+			copied := *existing
+			copied.MetricValue, copied.Threshold, copied.Severity = history.MetricValue, history.Threshold, history.Severity
+			copied.Message, copied.Resolved, copied.ResolvedAt = history.Message, history.Resolved, history.ResolvedAt
+			copied.PeakValue = history.PeakValue
+			r.history[i] = &copied
+			return nil
+		}
+	}
+	return fmt.Errorf("alert history not found")
+}
+
+func (r *fakeAlertRepo) GetIncident(historyID uint) (*models.AlertHistory, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, history := range r.history {
+		if history.ID == historyID {
+			copied := *history
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("alert history not found")
+}
+
+func (r *fakeAlertRepo) MarkNotificationSent(historyID uint, channel string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, history := range r.history {
+		if history.ID == historyID {
+			switch channel {
+			case NotificationEmail:
+				history.EmailSent = true
+			case NotificationWebhook:
+				history.WebhookSent = true
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("alert history not found")
+}
+
+func (r *fakeAlertRepo) GetUnresolvedAlerts() ([]*models.AlertHistory, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Newest first, like the repository
+	var unresolved []*models.AlertHistory
+	for i := len(r.history) - 1; i >= 0; i-- {
+		history := r.history[i]
+		if !history.Resolved {
+			copied := *history
+			unresolved = append(unresolved, &copied)
+		}
+	}
+	return unresolved, nil
+}
+
+func (r *fakeAlertRepo) ResolveAlert(historyID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, history := range r.history {
+		if history.ID == historyID {
+			history.Resolved = true
+			history.ResolvedAt = time.Now()
+			return nil
+		}
+	}
+	return fmt.Errorf("alert history not found")
+}
+
+func (r *fakeAlertRepo) UpdateIncidentWorkflow(history *models.AlertHistory) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.history {
+		if existing.ID == history.ID {
+			existing.Acknowledged, existing.AcknowledgedBy, existing.AcknowledgedAt = history.Acknowledged, history.AcknowledgedBy, history.AcknowledgedAt
+			existing.Assignee = history.Assignee
+			return nil
+		}
+	}
+	return fmt.Errorf("alert history not found")
+}
+
+func (r *fakeAlertRepo) CreateIncidentEvent(event *models.IncidentEvent) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.nextID++
+	event.ID = r.nextID
+	event.CreatedAt = time.Now()
+	copied := *event
+	r.events = append(r.events, &copied)
+	return nil
+}
+
+func (r *fakeAlertRepo) GetIncidentEvents(historyID uint) ([]*models.IncidentEvent, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var events []*models.IncidentEvent
+	for _, event := range r.events {
+		if event.HistoryID == historyID {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	return events, nil
+}
+
+func (r *fakeAlertRepo) SaveAlertInstance(instance *models.AlertInstance) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if instance.ID == 0 {
+		r.nextID++
+		instance.ID = r.nextID
+	}
+	r.instances[instance.ID] = *instance
+	return nil
+}
+
+func (r *fakeAlertRepo) GetAlertInstances(alertID uint, state string) ([]*models.AlertInstance, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var instances []*models.AlertInstance
+	for _, instance := range r.instances {
+		if (alertID == 0 || instance.AlertID == alertID) && (state == "" || instance.State == state) {
+			copied := instance
+			instances = append(instances, &copied)
+		}
+	}
+	return instances, nil
+}
+
+func (r *fakeAlertRepo) DeleteAlertInstance(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.instances, id)
+	return nil
+}
+
+// historySnapshot returns a copy of the recorded alert history
+func (r *fakeAlertRepo) historySnapshot() []models.AlertHistory {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	snapshot := make([]models.AlertHistory, len(r.history))
+	for i, history := range r.history {
+		snapshot[i] = *history
+	}
+	return snapshot
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// MetricAnomaly is the alert metric type for statistical anomaly detection.
+// The alert value is how many standard deviations the metric is from its baseline.
+const MetricAnomaly = "anomaly"
+
+// Anomaly detection methods
+const (
+	AnomalyMethodEWMA = "ewma" // Exponentially weighted mean and standard deviation (z-score)
+	AnomalyMethodMAD  = "mad"  // Median and median absolute deviation of recent values
+)
+
+// Anomaly seasonality modes
+const (
+	SeasonalityNone   = "none"   // One baseline for all times
+	SeasonalityDaily  = "daily"  // One baseline per hour of day
+	SeasonalityWeekly = "weekly" // One baseline per hour of week
+)
+
+// Anomaly directions
+const (
+	AnomalyDirectionBoth  = "both"
+	AnomalyDirectionAbove = "above"
+	AnomalyDirectionBelow = "below"
+)
+
+// Anomaly baseline settings
+const (
+	anomalyEWMAAlpha      = 0.1                // Weight of each new observation in the EWMA
+	anomalyMADWindow      = 120                // Recent observations kept per bucket for MAD
+	anomalyMinSamples     = 10                 // Observations a bucket needs before it is used
+	anomalySampleInterval = time.Minute        // Baselines learn at most one observation per interval
+	anomalyMADScale       = 1.4826             // Scales MAD to a standard deviation for normal data
+	anomalySeedLimit      = 20000              // Maximum history rows used to seed a baseline
+	anomalyBaselineTTL    = 7 * 24 * time.Hour // Baselines of hosts that stopped reporting are dropped
+)
+
+// AnomalyMethods returns the supported anomaly detection methods
+func AnomalyMethods() []string {
+	return []string{AnomalyMethodEWMA, AnomalyMethodMAD}
+}
+
+// AnomalySeasonalities returns the supported seasonality modes
+func AnomalySeasonalities() []string {
+	return []string{SeasonalityNone, SeasonalityDaily, SeasonalityWeekly}
+}
+
+// AnomalyDirections returns the supported anomaly directions
+func AnomalyDirections() []string {
+	return []string{AnomalyDirectionBoth, AnomalyDirectionAbove, AnomalyDirectionBelow}
+}
+
+// IsAnomalyMetricType reports whether a metric type is an anomaly rule
+func IsAnomalyMetricType(metricType string) bool {
+	return strings.EqualFold(metricType, MetricAnomaly)
+}
+
+// AnomalyBandPoint is the expected range of a metric for one seasonal bucket
+type AnomalyBandPoint struct {
+	Bucket   int     `json:"bucket"`   // Hour of day (daily), hour of week from Sunday 00:00 (weekly) or 0
+	Expected float64 `json:"expected"` // Baseline center (EWMA mean or median)
+	Spread   float64 `json:"spread"`   // Baseline standard deviation estimate
+	Lower    float64 `json:"lower"`    // Expected - sensitivity * spread
+	Upper    float64 `json:"upper"`    // Expected + sensitivity * spread
+	Samples  int     `json:"samples"`  // Observations learned in this bucket
+	Ready    bool    `json:"ready"`    // Whether the bucket has enough observations to alert
+}
+
+// AnomalyBand describes a host's baseline for an anomaly alert
+type AnomalyBand struct {
+	Hostname    string             `json:"hostname"`
+	Field       string             `json:"field"`
+	Method      string             `json:"method"`
+	Seasonality string             `json:"seasonality"`
+	Sensitivity float64            `json:"sensitivity"` // K: allowed deviation in standard deviations
+	Current     AnomalyBandPoint   `json:"current"`     // Bucket for the current time
+	Profile     []AnomalyBandPoint `json:"profile"`     // All buckets, in bucket order
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// anomalyBucket holds the learned statistics of one seasonal bucket
+type anomalyBucket struct {
+	mean     float64   // EWMA mean
+	variance float64   // EWMA variance
+	count    int       // Observations learned
+	recent   []float64 // Ring buffer of recent observations for MAD
+	next     int       // Next ring buffer position
+}
+
+// observe adds an observation to the bucket
+func (b *anomalyBucket) observe(value float64) {
+	if b.count == 0 {
+		b.mean = value
+	} else {
+		diff := value - b.mean
+		increment := anomalyEWMAAlpha * diff
+		b.mean += increment
+		b.variance = (1 - anomalyEWMAAlpha) * (b.variance + diff*increment)
+	}
+	b.count++
+
+	if len(b.recent) < anomalyMADWindow {
+		b.recent = append(b.recent, value)
+	} else {
+		b.recent[b.next] = value
+		b.next = (b.next + 1) % anomalyMADWindow
+	}
+}
+
+// estimate returns the bucket's center and spread for a method
+func (b *anomalyBucket) estimate(method string) (center, spread float64) {
+	if method == AnomalyMethodMAD && len(b.recent) > 0 {
+		center = median(b.recent)
+		deviations := make([]float64, len(b.recent))
+		for i, v := range b.recent {
+			deviations[i] = math.Abs(v - center)
+		}
+		spread = median(deviations) * anomalyMADScale
+	} else {
+		center = b.mean
+		spread = math.Sqrt(b.variance)
+	}
+
+	// Perfectly flat series would make any change infinitely anomalous
+	return center, math.Max(spread, math.Max(math.Abs(center)*0.01, 1e-6))
+}
+
+// median returns the median of values without modifying them
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// anomalyBaseline is a seasonal baseline of one metric field on one host
+type anomalyBaseline struct {
+	seasonality  string
+	buckets      []*anomalyBucket
+	lastObserved time.Time
+}
+
+// newAnomalyBaseline creates an empty baseline
+func newAnomalyBaseline(seasonality string) *anomalyBaseline {
+	size := 1
+	switch seasonality {
+	case SeasonalityDaily:
+		size = 24
+	case SeasonalityWeekly:
+		size = 7 * 24
+	}
+
+	buckets := make([]*anomalyBucket, size)
+	for i := range buckets {
+		buckets[i] = &anomalyBucket{}
+	}
+	return &anomalyBaseline{seasonality: seasonality, buckets: buckets}
+}
+
+// bucketIndex returns the seasonal bucket for a time
+func (b *anomalyBaseline) bucketIndex(t time.Time) int {
+	switch b.seasonality {
+	case SeasonalityDaily:
+		return t.Hour()
+	case SeasonalityWeekly:
+		return int(t.Weekday())*24 + t.Hour()
+	default:
+		return 0
+	}
+}
+
+// observe learns a value, at most once per anomalySampleInterval
+func (b *anomalyBaseline) observe(t time.Time, value float64) {
+	if !b.lastObserved.IsZero() && t.Sub(b.lastObserved) < anomalySampleInterval {
+		return
+	}
+	b.buckets[b.bucketIndex(t)].observe(value)
+	b.lastObserved = t
+}
+
+// score returns how many spreads value is from the baseline at t, and whether the bucket is ready
+func (b *anomalyBaseline) score(t time.Time, value float64, method string) (float64, bool) {
+	bucket := b.buckets[b.bucketIndex(t)]
+	if bucket.count < anomalyMinSamples {
+		return 0, false
+	}
+	center, spread := bucket.estimate(method)
+	return (value - center) / spread, true
+}
+
+// band returns the expected range of a bucket
+func (b *anomalyBaseline) band(index int, method string, sensitivity float64) AnomalyBandPoint {
+	bucket := b.buckets[index]
+	point := AnomalyBandPoint{Bucket: index, Samples: bucket.count, Ready: bucket.count >= anomalyMinSamples}
+	if bucket.count == 0 {
+		return point
+	}
+
+	point.Expected, point.Spread = bucket.estimate(method)
+	point.Lower = point.Expected - sensitivity*point.Spread
+	point.Upper = point.Expected + sensitivity*point.Spread
+	return point
+}
+
+// anomalySettings returns an alert's method and seasonality with defaults applied
+func anomalySettings(alert *models.Alert) (method, seasonality string) {
+	method = strings.ToLower(alert.AnomalyMethod)
+	if method == "" {
+		method = AnomalyMethodEWMA
+	}
+	seasonality = strings.ToLower(alert.Seasonality)
+	if seasonality == "" {
+		seasonality = SeasonalityDaily
+	}
+	return method, seasonality
+}
+
+// anomalyKey identifies a baseline
+func anomalyKey(hostname, field, seasonality string) string {
+	return hostname + "|" + strings.ToLower(field) + "|" + seasonality
+}
+
+// anomalyBaselineFor returns the baseline for a host and field, seeding new baselines from stored history
+func (as *AlertService) anomalyBaselineFor(hostname, field, seasonality string) *anomalyBaseline {
+	key := anomalyKey(hostname, field, seasonality)
+
+	as.mutex.RLock()
+	baseline, exists := as.anomalyBaselines[key]
+	metricsRepo := as.metricsRepo
+	as.mutex.RUnlock()
+	if exists {
+		return baseline
+	}
+
+	baseline = newAnomalyBaseline(seasonality)
+	if metricsRepo != nil {
+		lookback := 24 * time.Hour
+		switch seasonality {
+		case SeasonalityDaily:
+			lookback = 3 * 24 * time.Hour
+		case SeasonalityWeekly:
+			lookback = 14 * 24 * time.Hour
+		}
+
+		now := time.Now()
+		history, err := metricsRepo.GetHistoryByHostname(hostname, now.Add(-lookback), now, anomalySeedLimit, 0)
+		if err != nil {
+			log.Printf("❌ Failed to seed anomaly baseline for %s on %s: %v", field, hostname, err)
+		}
+		// History is newest first
+		for i := len(history) - 1; i >= 0; i-- {
+			if value, ok := MetricFieldValue(models.ConvertDBMetricToSystemMetrics(history[i]), field); ok {
+				baseline.observe(history[i].Timestamp, value)
+			}
+		}
+		if len(history) > 0 {
+			log.Printf("📈 Seeded %s anomaly baseline for %s on %s from %d samples", seasonality, field, hostname, len(history))
+		}
+	}
+
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+	if existing, exists := as.anomalyBaselines[key]; exists {
+		return existing
+	}
+	as.anomalyBaselines[key] = baseline
+	return baseline
+}
+
+// checkAnomaly scores the alert's field against the host's baseline, then learns the current value
+func (as *AlertService) checkAnomaly(alert *models.Alert, metrics *models.SystemMetrics) error {
+	value, ok := MetricFieldValue(metrics, alert.AnomalyField)
+	if !ok {
+		return fmt.Errorf("unknown anomaly field: %s", alert.AnomalyField)
+	}
+
+	method, seasonality := anomalySettings(alert)
+	baseline := as.anomalyBaselineFor(metrics.Hostname, alert.AnomalyField, seasonality)
+	now := time.Now()
+
+	// Score before learning so the current value can't mask itself
+	as.mutex.Lock()
+	score, ready := baseline.score(now, value, method)
+	band := baseline.band(baseline.bucketIndex(now), method, alert.Threshold)
+	baseline.observe(now, value)
+	as.mutex.Unlock()
+
+	if !ready {
+		return nil // Baseline still warming up
+	}
+
+	deviation := math.Abs(score)
+	switch strings.ToLower(alert.AnomalyDirection) {
+	case AnomalyDirectionAbove:
+		deviation = math.Max(score, 0)
+	case AnomalyDirectionBelow:
+		deviation = math.Max(-score, 0)
+	}
+
+	extra := map[string]interface{}{
+		"field":    alert.AnomalyField,
+		"value":    value,
+		"expected": band.Expected,
+		"lower":    band.Lower,
+		"upper":    band.Upper,
+	}
+	message := as.generateAnomalyAlertMessage(alert, value, score, band, metrics.Hostname)
+	return as.evaluateAlertValue(alert, metrics.Hostname, deviation, message, extra)
+}
+
+// GetAnomalyBands returns the baselines of an anomaly alert, for one host or all known hosts
+func (as *AlertService) GetAnomalyBands(alert *models.Alert, hostname string) ([]AnomalyBand, error) {
+	if !IsAnomalyMetricType(alert.MetricType) {
+		return nil, fmt.Errorf("alert %d is not an anomaly alert", alert.ID)
+	}
+
+	method, seasonality := anomalySettings(alert)
+	suffix := anomalyKey("", alert.AnomalyField, seasonality)
+	now := time.Now()
+
+	if hostname != "" {
+		// Seed the baseline if the host hasn't been evaluated yet
+		as.anomalyBaselineFor(hostname, alert.AnomalyField, seasonality)
+	}
+
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	bands := []AnomalyBand{}
+	for key, baseline := range as.anomalyBaselines {
+		if !strings.HasSuffix(key, suffix) {
+			continue
+		}
+		host := strings.TrimSuffix(key, suffix)
+		if hostname != "" && host != hostname {
+			continue
+		}
+
+		band := AnomalyBand{
+			Hostname:    host,
+			Field:       strings.ToLower(alert.AnomalyField),
+			Method:      method,
+			Seasonality: seasonality,
+			Sensitivity: alert.Threshold,
+			Current:     baseline.band(baseline.bucketIndex(now), method, alert.Threshold),
+			Profile:     make([]AnomalyBandPoint, len(baseline.buckets)),
+			UpdatedAt:   baseline.lastObserved,
+		}
+		for i := range baseline.buckets {
+			band.Profile[i] = baseline.band(i, method, alert.Threshold)
+		}
+		bands = append(bands, band)
+	}
+
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Hostname < bands[j].Hostname })
+	return bands, nil
+}
+
+// generateAnomalyAlertMessage generates a human-readable message for anomaly alerts
+func (as *AlertService) generateAnomalyAlertMessage(alert *models.Alert, value, score float64, band AnomalyBandPoint, hostname string) string {
+	direction := "above"
+	if score < 0 {
+		direction = "below"
+	}
+
+	return fmt.Sprintf("%s on %s is %.2f, %.1f standard deviations %s its baseline of %.2f (expected %.2f to %.2f)",
+		strings.ToLower(alert.AnomalyField), hostname, value, math.Abs(score), direction, band.Expected, band.Lower, band.Upper)
+}
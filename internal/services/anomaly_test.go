@@ -0,0 +1,149 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// seedBaseline feeds one observation per minute, varying by up to 2 around center
+func seedBaseline(b *anomalyBaseline, start time.Time, minutes int, center func(t time.Time) float64) time.Time {
+	t := start
+	for i := 0; i < minutes; i++ {
+		b.observe(t, center(t)+float64(i%5-2))
+		t = t.Add(time.Minute)
+	}
+	return t
+}
+
+func TestAnomalyBaseline_Score(t *testing.T) {
+	for _, method := range AnomalyMethods() {
+		t.Run(method, func(t *testing.T) {
+			baseline := newAnomalyBaseline(SeasonalityNone)
+			start := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+			// Not ready before enough observations
+			seedBaseline(baseline, start, anomalyMinSamples-1, func(time.Time) float64 { return 50 })
+			_, ready := baseline.score(start, 50, method)
+			assert.False(t, ready)
+
+			now := seedBaseline(baseline, start.Add(time.Hour), 60, func(time.Time) float64 { return 50 })
+
+			score, ready := baseline.score(now, 51, method)
+			require.True(t, ready)
+			assert.Less(t, score, 2.0)
+
+			score, _ = baseline.score(now, 80, method)
+			assert.Greater(t, score, 10.0)
+
+			score, _ = baseline.score(now, 20, method)
+			assert.Less(t, score, -10.0)
+		})
+	}
+}
+
+func TestAnomalyBaseline_Seasonality(t *testing.T) {
+	baseline := newAnomalyBaseline(SeasonalityDaily)
+	assert.Len(t, baseline.buckets, 24)
+
+	// Busy during business hours, quiet at night
+	load := func(t time.Time) float64 {
+		if t.Hour() >= 9 && t.Hour() < 17 {
+			return 80
+		}
+		return 10
+	}
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	seedBaseline(baseline, start, 3*24*60, load)
+
+	noon := time.Date(2026, 1, 8, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 1, 8, 3, 0, 0, 0, time.UTC)
+
+	score, ready := baseline.score(noon, 80, AnomalyMethodEWMA)
+	require.True(t, ready)
+	assert.Less(t, score, 3.0, "80% at noon is normal")
+
+	score, _ = baseline.score(night, 80, AnomalyMethodEWMA)
+	assert.Greater(t, score, 3.0, "80% at night is anomalous")
+
+	band := baseline.band(baseline.bucketIndex(noon), AnomalyMethodMAD, 3)
+	assert.Equal(t, 12, band.Bucket)
+	assert.InDelta(t, 80, band.Expected, 1)
+	assert.Less(t, band.Lower, band.Expected)
+	assert.Greater(t, band.Upper, band.Expected)
+
+	weekly := newAnomalyBaseline(SeasonalityWeekly)
+	assert.Len(t, weekly.buckets, 168)
+	assert.Equal(t, int(noon.Weekday())*24+12, weekly.bucketIndex(noon))
+}
+
+func TestAnomalyBaseline_Downsampling(t *testing.T) {
+	baseline := newAnomalyBaseline(SeasonalityNone)
+	now := time.Now()
+
+	baseline.observe(now, 1)
+	baseline.observe(now.Add(10*time.Second), 2)
+	baseline.observe(now.Add(time.Minute), 3)
+
+	assert.Equal(t, 2, baseline.buckets[0].count)
+}
+
+// historyRepo serves stored metrics history for seeding baselines
+type historyRepo struct {
+	repository.MetricsRepository
+	metrics []*models.Metric
+}
+
+func (r *historyRepo) GetHistoryByHostname(hostname string, from, to time.Time, limit, offset int) ([]*models.Metric, error) {
+	if hostname != "web-1" {
+		return nil, fmt.Errorf("unexpected host %s", hostname)
+	}
+	return r.metrics, nil
+}
+
+func TestAlertService_AnomalyBaselineSeededFromHistory(t *testing.T) {
+	// Newest first, like the repository
+	now := time.Now()
+	var history []*models.Metric
+	for i := 0; i < 30; i++ {
+		history = append(history, &models.Metric{
+			Hostname:  "web-1",
+			Timestamp: now.Add(-time.Duration(i+1) * time.Minute),
+			CPUUsage:  40 + float64(i%3),
+		})
+	}
+
+	as := NewAlertService(nil, nil, nil, nil)
+	as.SetMetricsRepository(&historyRepo{metrics: history})
+
+	alert := &models.Alert{MetricType: MetricAnomaly, AnomalyField: "cpu", Seasonality: SeasonalityNone, Condition: ">", Threshold: 3}
+	bands, err := as.GetAnomalyBands(alert, "web-1")
+	require.NoError(t, err)
+	require.Len(t, bands, 1)
+
+	band := bands[0]
+	assert.Equal(t, "web-1", band.Hostname)
+	assert.Equal(t, AnomalyMethodEWMA, band.Method)
+	assert.True(t, band.Current.Ready)
+	assert.Equal(t, 30, band.Current.Samples)
+	assert.InDelta(t, 41, band.Current.Expected, 1)
+	assert.Len(t, band.Profile, 1)
+
+	_, err = as.GetAnomalyBands(&models.Alert{MetricType: "cpu"}, "")
+	assert.Error(t, err)
+}
+
+func TestAlertService_AnomalyMessage(t *testing.T) {
+	as := NewAlertService(nil, nil, nil, nil)
+	alert := &models.Alert{AnomalyField: "cpu"}
+	band := AnomalyBandPoint{Expected: 20, Lower: 14, Upper: 26}
+
+	message := as.generateAnomalyAlertMessage(alert, 50, 15, band, "web-1")
+	assert.Equal(t, "cpu on web-1 is 50.00, 15.0 standard deviations above its baseline of 20.00 (expected 14.00 to 26.00)", message)
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// BacktestSampleLimit is the maximum number of stored samples replayed by a backtest
+const BacktestSampleLimit = 100000
+
+// BacktestOptions selects the history an alert rule is replayed against
+type BacktestOptions struct {
+	From     time.Time
+	To       time.Time
+	Hostname string        // Only replay this host (empty = all hosts)
+	Cooldown time.Duration // Minimum delay between repeat notifications (0 = ALERTS_COOLDOWN)
+}
+
+// BacktestIncident is an incident an alert rule would have opened
+type BacktestIncident struct {
+	Hostname      string     `json:"hostname"`
+	Severity      string     `json:"severity"` // Most severe level reached
+	StartedAt     time.Time  `json:"started_at"`
+	EndedAt       *time.Time `json:"ended_at"`         // nil = still firing at the end of the range
+	Duration      float64    `json:"duration_seconds"` // Until the end of the range when still firing
+	PeakValue     float64    `json:"peak_value"`       // Value furthest past the threshold
+	PeakAt        time.Time  `json:"peak_at"`
+	Notifications int        `json:"notifications"` // Notifications it would have sent, including repeats and the resolution
+	Flapping      bool       `json:"flapping"`      // Notifications were suppressed by flap detection at some point
+}
+
+// BacktestResult holds the incidents an alert rule would have opened over a time range
+type BacktestResult struct {
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Samples   int                `json:"samples"`
+	Hosts     int                `json:"hosts"`
+	Truncated bool               `json:"truncated"` // Only the newest BacktestSampleLimit samples were replayed
+	Incidents []BacktestIncident `json:"incidents"`
+}
+
+// CanBacktest reports whether alerts of a metric type can be replayed against stored metrics.
+// Process, probe, certificate, forecast, anomaly and no-data alerts depend on data that is
+// not kept in the metrics history.
+func CanBacktest(metricType string) bool {
+	return IsMetricField(metricType) || IsExpressionMetricType(metricType)
+}
+
+// replayEnv resolves expression fields from a replayed sample and window functions from the
+// samples replayed before it
+type replayEnv struct {
+	metricsEnv
+	buffer *hostSampleBuffer
+	now    time.Time
+}
+
+// Samples implements ExpressionEnv
+func (env replayEnv) Samples(field string, window time.Duration) ([]MetricSample, error) {
+	samples := env.buffer.samples(field, window, env.now)
+	if len(samples) == 0 {
+		return env.metricsEnv.Samples(field, window)
+	}
+	return samples, nil
+}
+
+// Backtest replays the stored metrics of a time range through an alert rule and returns the
+// incidents it would have opened. It applies the same host scoping, duration, clear threshold,
+// severity levels, cooldown and flap detection as live evaluation, but sends no notifications
+// and changes no alert state. Silences and acknowledgements are not taken into account.
+func (as *AlertService) Backtest(alert *models.Alert, options BacktestOptions) (*BacktestResult, error) {
+	if !CanBacktest(alert.MetricType) {
+		return nil, fmt.Errorf("backtesting is not supported for %s alerts", alert.MetricType)
+	}
+
+	var expr *Expression
+	if IsExpressionMetricType(alert.MetricType) {
+		var err error
+		if expr, err = ParseExpression(alert.Expression); err != nil {
+			return nil, fmt.Errorf("invalid expression: %w", err)
+		}
+	}
+
+	if options.Cooldown <= 0 {
+		options.Cooldown = as.config.CooldownPeriod
+	}
+
+	as.mutex.RLock()
+	metricsRepo := as.metricsRepo
+	as.mutex.RUnlock()
+	if metricsRepo == nil {
+		return nil, fmt.Errorf("metrics history is not available")
+	}
+
+	var history []*models.Metric
+	var err error
+	if options.Hostname != "" {
+		history, err = metricsRepo.GetHistoryByHostname(options.Hostname, options.From, options.To, BacktestSampleLimit, 0)
+	} else {
+		history, err = metricsRepo.GetHistory(options.From, options.To, BacktestSampleLimit, 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics history: %w", err)
+	}
+
+	// History is newest first; replay each host in time order
+	var hostnames []string
+	byHost := make(map[string][]*models.Metric)
+	for i := len(history) - 1; i >= 0; i-- {
+		hostname := history[i].Hostname
+		if _, exists := byHost[hostname]; !exists {
+			hostnames = append(hostnames, hostname)
+		}
+		byHost[hostname] = append(byHost[hostname], history[i])
+	}
+	sort.Strings(hostnames)
+
+	result := &BacktestResult{
+		From:      options.From,
+		To:        options.To,
+		Samples:   len(history),
+		Hosts:     len(hostnames),
+		Truncated: len(history) >= BacktestSampleLimit,
+		Incidents: []BacktestIncident{},
+	}
+	for _, hostname := range hostnames {
+		if !as.alertAppliesToHost(alert, hostname) {
+			continue
+		}
+		incidents, err := as.replayHost(applyHostOverride(alert, hostname), expr, byHost[hostname], options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay %s: %w", hostname, err)
+		}
+		result.Incidents = append(result.Incidents, incidents...)
+	}
+
+	sort.SliceStable(result.Incidents, func(i, j int) bool {
+		return result.Incidents[i].StartedAt.Before(result.Incidents[j].StartedAt)
+	})
+	return result, nil
+}
+
+// replayHost evaluates an alert against the samples of one host, oldest first, with the
+// state machine of transitionAlert applied to a throwaway instance
+func (as *AlertService) replayHost(alert *models.Alert, expr *Expression, samples []*models.Metric, options BacktestOptions) ([]BacktestIncident, error) {
+	var incidents []BacktestIncident
+	var open *BacktestIncident
+	buffer := &hostSampleBuffer{}
+	instance := &models.AlertInstance{AlertID: alert.ID, State: AlertStateInactive}
+
+	for _, sample := range samples {
+		now := sample.Timestamp
+		metrics := models.ConvertDBMetricToSystemMetrics(sample)
+		buffer.add(metrics, now)
+
+		value, err := replayValue(alert, expr, metrics, buffer, now)
+		if err != nil {
+			return nil, err
+		}
+
+		conditionMet, err := as.alertConditionMet(alert, value)
+		if err != nil {
+			return nil, err
+		}
+		if !conditionMet && alert.ClearThreshold != nil && instance.State == AlertStateFiring {
+			if conditionMet, err = as.evaluateCondition(alert.Condition, value, *alert.ClearThreshold); err != nil {
+				return nil, err
+			}
+		}
+
+		from := instance.State
+		to, notify := nextAlertState(alert, instance, conditionMet, now, options.Cooldown)
+
+		previousSeverity := instance.Severity
+		if to == AlertStateFiring {
+			instance.Severity = as.alertSeverity(alert, value)
+		}
+		if from == AlertStateFiring && to == AlertStateFiring && previousSeverity != instance.Severity {
+			notify = true
+		}
+		if to != from {
+			instance.State = to
+			instance.Since = now
+		}
+		if notify {
+			instance.LastNotifiedAt = now
+		}
+		if (to == AlertStateFiring) != (from == AlertStateFiring) {
+			instance.StateChanges = append(instance.StateChanges, now)
+		}
+		as.updateFlapping(instance, now)
+
+		if to == AlertStateFiring && from != AlertStateFiring {
+			open = &BacktestIncident{
+				Hostname:  sample.Hostname,
+				Severity:  instance.Severity,
+				StartedAt: now,
+				PeakValue: value,
+				PeakAt:    now,
+			}
+		}
+		if open == nil {
+			continue
+		}
+
+		if instance.Flapping {
+			open.Flapping = true
+		} else if notify {
+			open.Notifications++
+		}
+		if to == AlertStateFiring {
+			if severityRank(instance.Severity) > severityRank(open.Severity) {
+				open.Severity = instance.Severity
+			}
+			if exceeds(alert.Condition, value, open.PeakValue) {
+				open.PeakValue = value
+				open.PeakAt = now
+			}
+			continue
+		}
+
+		endedAt := now
+		open.EndedAt = &endedAt
+		open.Duration = endedAt.Sub(open.StartedAt).Seconds()
+		incidents = append(incidents, *open)
+		open = nil
+	}
+
+	if open != nil {
+		open.Duration = options.To.Sub(open.StartedAt).Seconds()
+		incidents = append(incidents, *open)
+	}
+	return incidents, nil
+}
+
+// replayValue computes the value an alert evaluates for a replayed sample
+func replayValue(alert *models.Alert, expr *Expression, metrics *models.SystemMetrics, buffer *hostSampleBuffer, now time.Time) (float64, error) {
+	if expr != nil {
+		return expr.Evaluate(replayEnv{metricsEnv: metricsEnv{metrics: metrics}, buffer: buffer, now: now})
+	}
+
+	value, _ := MetricFieldValue(metrics, alert.MetricType)
+	if alert.Aggregation == "" {
+		return value, nil
+	}
+	samples := buffer.samples(alert.MetricType, aggregationWindow(alert), now)
+	if len(samples) == 0 {
+		samples = []MetricSample{{Time: now, Value: value}}
+	}
+	return AggregateSamples(alert.Aggregation, samples)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// rangeRepo serves stored metrics history of all hosts
+type rangeRepo struct {
+	repository.MetricsRepository
+	metrics []*models.Metric
+}
+
+func (r *rangeRepo) GetHistory(from, to time.Time, limit, offset int) ([]*models.Metric, error) {
+	return r.metrics, nil
+}
+
+func TestAlertService_Backtest(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	series := map[string][]float64{
+		"web-1": {50, 85, 90, 97, 70, 50}, // Fires once the duration passed, then resolves
+		"db-1":  {90, 50, 50, 50, 50, 50}, // Too short to fire
+		"web-2": {99, 99, 99, 99, 99, 99}, // Excluded
+		"web-3": {65, 65, 65, 65, 65, 65}, // Overridden threshold, still firing at the end
+	}
+
+	// Newest first, like the repository
+	var history []*models.Metric
+	for i := 5; i >= 0; i-- {
+		for hostname, values := range series {
+			history = append(history, &models.Metric{
+				Hostname:  hostname,
+				Timestamp: start.Add(time.Duration(i) * 30 * time.Second),
+				CPUUsage:  values[i],
+			})
+		}
+	}
+
+	as := NewAlertService(nil, nil, nil, nil)
+	as.SetMetricsRepository(&rangeRepo{metrics: history})
+
+	alert := newDurationAlert()
+	alert.Severity = "warning"
+	alert.HostSelector = models.HostSelector{Exclude: []string{"web-2"}}
+	alert.HostOverrides = []models.HostOverride{{Host: "web-3", Threshold: 60}}
+
+	end := start.Add(3 * time.Minute)
+	result, err := as.Backtest(alert, BacktestOptions{From: start, To: end, Cooldown: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 24, result.Samples)
+	assert.Equal(t, 4, result.Hosts)
+	assert.False(t, result.Truncated)
+	require.Len(t, result.Incidents, 2)
+
+	web3 := result.Incidents[0]
+	assert.Equal(t, "web-3", web3.Hostname)
+	assert.Equal(t, start.Add(time.Minute), web3.StartedAt)
+	assert.Nil(t, web3.EndedAt)
+	assert.Equal(t, 120.0, web3.Duration)
+	assert.Equal(t, 2, web3.Notifications) // Initial and one repeat after the cooldown
+
+	web1 := result.Incidents[1]
+	assert.Equal(t, "web-1", web1.Hostname)
+	assert.Equal(t, "warning", web1.Severity)
+	assert.Equal(t, start.Add(90*time.Second), web1.StartedAt)
+	require.NotNil(t, web1.EndedAt)
+	assert.Equal(t, start.Add(2*time.Minute), *web1.EndedAt)
+	assert.Equal(t, 97.0, web1.PeakValue)
+	assert.Equal(t, 2, web1.Notifications) // Firing and resolved
+
+	// Nothing live was touched
+	assert.Empty(t, as.instances)
+}
+
+func TestAlertService_BacktestUnsupported(t *testing.T) {
+	as := NewAlertService(nil, nil, nil, nil)
+	as.SetMetricsRepository(&rangeRepo{})
+
+	_, err := as.Backtest(&models.Alert{MetricType: MetricAnomaly}, BacktestOptions{})
+	assert.Error(t, err)
+	assert.False(t, CanBacktest(MetricNoData))
+	assert.True(t, CanBacktest("cpu"))
+	assert.True(t, CanBacktest(MetricExpression))
+}
+
+func TestExceeds(t *testing.T) {
+	assert.True(t, exceeds(">", 95, 90))
+	assert.False(t, exceeds(">=", 85, 90))
+	assert.True(t, exceeds("<", 5, 10))
+	assert.False(t, exceeds("lte", 15, 10))
+}
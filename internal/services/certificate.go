@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eyzaun/godash/internal/collector"
+	"github.com/eyzaun/godash/internal/config"
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// MetricCertExpiryDays is the alert metric type for certificate expiry
+const MetricCertExpiryDays = "cert_expiry_days"
+
+// IsCertificateMetricType reports whether a metric type is evaluated from certificates
+func IsCertificateMetricType(metricType string) bool {
+	return strings.EqualFold(metricType, MetricCertExpiryDays)
+}
+
+// CertificateService periodically inspects TLS certificates and feeds them to the alert service
+type CertificateService struct {
+	collector    *collector.CertificateCollector
+	alertService *AlertService
+	interval     time.Duration
+
+	// Latest inspection results
+	certificates []models.CertificateInfo
+	lastCheck    time.Time
+
+	isRunning bool
+	stopChan  chan bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	mutex     sync.RWMutex
+}
+
+// NewCertificateService creates a new certificate service
+func NewCertificateService(cfg *config.Config) *CertificateService {
+	certConfig := &config.CertificateConfig{
+		CheckInterval: time.Hour,
+		Timeout:       10 * time.Second,
+	}
+	if cfg != nil && cfg.Certificates != nil {
+		certConfig = cfg.Certificates
+	}
+
+	return &CertificateService{
+		collector:    collector.NewCertificateCollector(certConfig.Files, certConfig.Endpoints, certConfig.Timeout),
+		interval:     certConfig.CheckInterval,
+		certificates: []models.CertificateInfo{},
+		stopChan:     make(chan bool, 1),
+	}
+}
+
+// SetAlertService sets the alert service that evaluates certificate expiry
+func (cs *CertificateService) SetAlertService(alertService *AlertService) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.alertService = alertService
+	log.Println("🔗 Alert service integrated with certificate service")
+}
+
+// Start starts periodic certificate inspection
+func (cs *CertificateService) Start(ctx context.Context) error {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	if cs.isRunning {
+		return fmt.Errorf("certificate service is already running")
+	}
+
+	log.Printf("🔐 Starting certificate service with %v check interval", cs.interval)
+
+	cs.ctx, cs.cancel = context.WithCancel(ctx)
+	go cs.checkRoutine()
+
+	cs.isRunning = true
+	log.Println("✅ Certificate service started successfully")
+
+	return nil
+}
+
+// Stop stops periodic certificate inspection
+func (cs *CertificateService) Stop() error {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	if !cs.isRunning {
+		return fmt.Errorf("certificate service is not running")
+	}
+
+	log.Println("🛑 Stopping certificate service...")
+
+	if cs.cancel != nil {
+		cs.cancel()
+	}
+
+	select {
+	case cs.stopChan <- true:
+	default:
+		// Channel might be full or closed
+	}
+
+	cs.isRunning = false
+	log.Println("✅ Certificate service stopped successfully")
+
+	return nil
+}
+
+// GetCertificates returns the latest inspection results, inspecting now if none exist yet
+func (cs *CertificateService) GetCertificates() []models.CertificateInfo {
+	cs.mutex.RLock()
+	certificates := cs.certificates
+	checked := !cs.lastCheck.IsZero()
+	cs.mutex.RUnlock()
+
+	if !checked {
+		return cs.Refresh()
+	}
+	return certificates
+}
+
+// GetLastCheck returns when certificates were last inspected
+func (cs *CertificateService) GetLastCheck() time.Time {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+	return cs.lastCheck
+}
+
+// Refresh inspects all certificates now and evaluates certificate alerts
+func (cs *CertificateService) Refresh() []models.CertificateInfo {
+	certificates := cs.collector.GetCertificates()
+
+	cs.mutex.Lock()
+	cs.certificates = certificates
+	cs.lastCheck = time.Now()
+	alertService := cs.alertService
+	cs.mutex.Unlock()
+
+	if alertService != nil {
+		alertService.CheckCertificates(certificates)
+	}
+
+	return certificates
+}
+
+// checkRoutine inspects certificates at startup and then on every interval
+func (cs *CertificateService) checkRoutine() {
+	cs.Refresh()
+
+	ticker := time.NewTicker(cs.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cs.Refresh()
+
+		case <-cs.stopChan:
+			log.Println("🔐 Certificate check routine stopped")
+			return
+
+		case <-cs.ctx.Done():
+			log.Println("🔐 Certificate check routine cancelled")
+			return
+		}
+	}
+}
+
+// CheckCertificates evaluates certificate expiry alerts against inspected certificates
+func (as *AlertService) CheckCertificates(certificates []models.CertificateInfo) {
+	if !as.config.EnableAlerts || len(certificates) == 0 {
+		return
+	}
+
+	alerts, err := as.alertRepo.GetActiveAlerts()
+	if err != nil {
+		log.Printf("❌ Failed to get active alerts: %v", err)
+		return
+	}
+
+	for _, alert := range alerts {
+		if !IsCertificateMetricType(alert.MetricType) {
+			continue
+		}
+
+		for _, cert := range certificates {
+			if cert.Error != "" || !matchesCertificate(alert.CertificateFilter, cert) {
+				continue
+			}
+
+			as.mutex.Lock()
+			as.checkedCount++
+			as.mutex.Unlock()
+
+			extra := map[string]interface{}{
+				"certificate": cert.Name,
+				"not_after":   cert.NotAfter,
+			}
+			message := as.generateCertificateAlertMessage(alert, cert)
+			if err := as.evaluateAlertValue(alert, "cert:"+cert.Name, cert.DaysUntilExpiry, message, extra); err != nil {
+				log.Printf("❌ Error evaluating certificate alert %d: %v", alert.ID, err)
+			}
+		}
+	}
+}
+
+// matchesCertificate reports whether a certificate matches an alert's filter.
+// The filter is a case-insensitive substring of the source, common name or a SAN; empty matches all.
+func matchesCertificate(filter string, cert models.CertificateInfo) bool {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return true
+	}
+
+	candidates := append([]string{cert.Name, cert.CommonName}, cert.SANs...)
+	for _, candidate := range candidates {
+		if strings.Contains(strings.ToLower(candidate), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateCertificateAlertMessage generates a human-readable message for certificate expiry alerts
+func (as *AlertService) generateCertificateAlertMessage(alert *models.Alert, cert models.CertificateInfo) string {
+	name := cert.CommonName
+	if name == "" {
+		name = cert.Subject
+	}
+
+	if cert.Expired {
+		return fmt.Sprintf("Certificate '%s' (%s) expired %.1f days ago on %s",
+			name, cert.Source, -cert.DaysUntilExpiry, cert.NotAfter.Format("2006-01-02"))
+	}
+
+	return fmt.Sprintf("Certificate '%s' (%s) expires in %.1f days on %s (threshold: %s %.0f days)",
+		name, cert.Source, cert.DaysUntilExpiry, cert.NotAfter.Format("2006-01-02"), alert.Condition, alert.Threshold)
+}
@@ -47,6 +47,7 @@ func NewCollectorService(cfg *config.Config, metricsRepo repository.MetricsRepos
 				EnableDisk:         true,
 				EnableNetwork:      true,
 				EnableProcesses:    true,
+				EnableLimits:       true,
 			},
 		}
 	}
@@ -59,6 +60,7 @@ func NewCollectorService(cfg *config.Config, metricsRepo repository.MetricsRepos
 		EnableDisk:      cfg.Metrics.EnableDisk,
 		EnableNetwork:   cfg.Metrics.EnableNetwork,
 		EnableProcesses: cfg.Metrics.EnableProcesses,
+		EnableLimits:    cfg.Metrics.EnableLimits,
 	}
 
 	// Create system collector
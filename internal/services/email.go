@@ -14,7 +14,7 @@ import (
 
 // EmailSender interface for sending alert emails
 type EmailSender interface {
-	SendAlert(alert *models.Alert, history *models.AlertHistory) error
+	SendAlert(alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error
 	SendTestEmail(to, subject, message string) error
 	ValidateConfiguration() error
 }
@@ -43,10 +43,14 @@ type AlertEmailData struct {
 	Timestamp    time.Time
 	DashboardURL string
 	Condition    string
+	Resolved     bool
+	Duration     string  // How long the incident was firing, for resolved incidents
+	PeakValue    float64 // Value furthest past the threshold during the incident
 }
 
-// SendAlert sends an alert email notification
-func (s *SMTPEmailSender) SendAlert(alert *models.Alert, history *models.AlertHistory) error {
+// SendAlert sends an alert email notification. The subject, text and HTML of a rendered
+// message replace the built-in ones; nil keeps the built-in email.
+func (s *SMTPEmailSender) SendAlert(alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error {
 	if s.config == nil {
 		return fmt.Errorf("email configuration is not available")
 	}
@@ -77,6 +81,8 @@ func (s *SMTPEmailSender) SendAlert(alert *models.Alert, history *models.AlertHi
 		Timestamp:    history.CreatedAt,
 		DashboardURL: "http://localhost:8080", // In production, use config
 		Condition:    alert.Condition,
+		Resolved:     history.Resolved,
+		PeakValue:    history.PeakValue,
 	}
 
 	// Generate email subject
@@ -85,14 +91,32 @@ func (s *SMTPEmailSender) SendAlert(alert *models.Alert, history *models.AlertHi
 		strings.ToUpper(alert.MetricType),
 		alert.Condition,
 		history.Hostname)
+	if history.Resolved {
+		subject = fmt.Sprintf("[GoDash Resolved] %s on %s after %s", alert.Name, history.Hostname, formatIncidentDuration(history))
+		templateData.Timestamp = history.ResolvedAt
+		templateData.Duration = formatIncidentDuration(history)
+	}
+
+	if message == nil {
+		message = &NotificationMessage{}
+	}
+	if message.Subject != "" {
+		subject = message.Subject
+	}
 
 	// Generate email body
-	htmlBody, err := s.generateHTMLBody(templateData)
-	if err != nil {
-		return fmt.Errorf("failed to generate HTML email body: %w", err)
+	htmlBody := message.HTML
+	if htmlBody == "" {
+		var err error
+		if htmlBody, err = s.generateHTMLBody(templateData); err != nil {
+			return fmt.Errorf("failed to generate HTML email body: %w", err)
+		}
 	}
 
-	textBody := s.generateTextBody(templateData)
+	textBody := message.Text
+	if textBody == "" {
+		textBody = s.generateTextBody(templateData)
+	}
 
 	// Send email to all recipients
 	for _, recipient := range recipients {
@@ -240,10 +264,10 @@ func (s *SMTPEmailSender) generateHTMLBody(data AlertEmailData) (string, error)
     <style>
         body { font-family: Arial, sans-serif; margin: 0; padding: 20px; background-color: #f5f5f5; }
         .container { max-width: 600px; margin: 0 auto; background-color: white; border-radius: 8px; box-shadow: 0 2px 8px rgba(0,0,0,0.1); overflow: hidden; }
-        .header { background-color: {{.SeverityColor}}; color: white; padding: 20px; text-align: center; }
+        .header { background-color: {{SeverityColor}}; color: white; padding: 20px; text-align: center; }
         .content { padding: 20px; }
         .metric-box { background-color: #f8f9fa; border: 1px solid #dee2e6; border-radius: 4px; padding: 15px; margin: 15px 0; }
-        .metric-value { font-size: 24px; font-weight: bold; color: {{.SeverityColor}}; }
+        .metric-value { font-size: 24px; font-weight: bold; color: {{SeverityColor}}; }
         .footer { background-color: #f8f9fa; padding: 15px; text-align: center; font-size: 12px; color: #666; }
         .button { display: inline-block; padding: 10px 20px; background-color: #007bff; color: white; text-decoration: none; border-radius: 4px; margin: 10px 0; }
         table { width: 100%; border-collapse: collapse; margin: 15px 0; }
@@ -254,16 +278,21 @@ func (s *SMTPEmailSender) generateHTMLBody(data AlertEmailData) (string, error)
 <body>
     <div class="container">
         <div class="header">
+            {{if .Resolved}}
+            <h1>✅ Alert Resolved</h1>
+            <p>{{.Severity}} Alert Resolved</p>
+            {{else}}
             <h1>🚨 System Alert</h1>
             <p>{{.Severity}} Alert Triggered</p>
+            {{end}}
         </div>
         <div class="content">
             <h2>{{.AlertName}}</h2>
             <p>{{.Message}}</p>
             
             <div class="metric-box">
-                <div class="metric-value">{{.CurrentValue}}{{.MetricUnit}}</div>
-                <p>Current {{.MetricType}} usage (Threshold: {{.Threshold}}{{.MetricUnit}})</p>
+                <div class="metric-value">{{.CurrentValue}}{{MetricUnit}}</div>
+                <p>Current {{.MetricType}} usage (Threshold: {{.Threshold}}{{MetricUnit}})</p>
             </div>
 
             <table>
@@ -272,6 +301,10 @@ func (s *SMTPEmailSender) generateHTMLBody(data AlertEmailData) (string, error)
                 <tr><td class="label">Condition:</td><td>{{.Condition}}</td></tr>
                 <tr><td class="label">Severity:</td><td>{{.Severity}}</td></tr>
                 <tr><td class="label">Time:</td><td>{{.Timestamp.Format "2006-01-02 15:04:05"}}</td></tr>
+                {{if .Resolved}}
+                <tr><td class="label">Duration:</td><td>{{.Duration}}</td></tr>
+                <tr><td class="label">Peak Value:</td><td>{{printf "%.2f" .PeakValue}}{{MetricUnit}}</td></tr>
+                {{end}}
             </table>
 
             <div style="text-align: center;">
@@ -323,14 +356,16 @@ func (s *SMTPEmailSender) generateHTMLBody(data AlertEmailData) (string, error)
 
 // generateTextBody generates plain text email body
 func (s *SMTPEmailSender) generateTextBody(data AlertEmailData) string {
-	unit := "%"
-	if !strings.Contains(strings.ToLower(data.MetricType), "cpu") &&
-		!strings.Contains(strings.ToLower(data.MetricType), "memory") &&
-		!strings.Contains(strings.ToLower(data.MetricType), "disk") {
-		unit = ""
+	unit := metricUnit(data.MetricType)
+
+	title := "GoDash System Alert"
+	resolution := ""
+	if data.Resolved {
+		title = "GoDash Alert Resolved"
+		resolution = fmt.Sprintf("Duration: %s\nPeak Value: %.2f%s\n", data.Duration, data.PeakValue, unit)
 	}
 
-	return fmt.Sprintf(`GoDash System Alert
+	return fmt.Sprintf(`%s
 
 Alert: %s
 Severity: %s
@@ -343,12 +378,13 @@ Current Value: %.2f%s
 Threshold: %.2f%s
 Condition: %s
 Time: %s
-
+%s
 Dashboard: %s
 
 ---
 This alert was generated by GoDash System Monitor
 Timestamp: %s UTC`,
+		title,
 		data.AlertName,
 		data.Severity,
 		data.Message,
@@ -358,6 +394,7 @@ Timestamp: %s UTC`,
 		data.Threshold, unit,
 		data.Condition,
 		data.Timestamp.Format("2006-01-02 15:04:05"),
+		resolution,
 		data.DashboardURL,
 		data.Timestamp.Format("2006-01-02 15:04:05"))
 }
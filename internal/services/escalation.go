@@ -0,0 +1,281 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// escalationCheckInterval is how often the escalation scheduler looks for due steps
+const escalationCheckInterval = 15 * time.Second
+
+// MinEscalationRepeat bounds how often the last step of a policy may repeat
+const MinEscalationRepeat = time.Minute
+
+// ValidateEscalationPolicy validates an escalation policy and normalizes its steps
+func ValidateEscalationPolicy(policy *models.EscalationPolicy) error {
+	policy.Name = strings.TrimSpace(policy.Name)
+	if policy.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(policy.Steps) == 0 {
+		return fmt.Errorf("at least one step is required")
+	}
+
+	previous := 0
+	for i := range policy.Steps {
+		step := &policy.Steps[i]
+		step.Channel = strings.ToLower(strings.TrimSpace(step.Channel))
+		step.Target = strings.TrimSpace(step.Target)
+
+		if step.ChannelID == 0 && step.Channel != NotificationEmail && step.Channel != NotificationWebhook {
+			return fmt.Errorf("step %d: needs a channel_id, or a channel of %s or %s", i+1, NotificationEmail, NotificationWebhook)
+		}
+		if step.After < 0 {
+			return fmt.Errorf("step %d: after cannot be negative", i+1)
+		}
+		if step.After < previous {
+			return fmt.Errorf("step %d: steps must be ordered by their delay", i+1)
+		}
+		previous = step.After
+	}
+
+	if policy.RepeatInterval < 0 {
+		return fmt.Errorf("repeat interval cannot be negative")
+	}
+	if policy.RepeatInterval > 0 && time.Duration(policy.RepeatInterval)*time.Second < MinEscalationRepeat {
+		return fmt.Errorf("repeat interval must be at least %s", MinEscalationRepeat)
+	}
+	return nil
+}
+
+// nextEscalationStep returns the step of a policy due for an instance at now and the escalation
+// level reached by notifying it. When several steps became due at once, only the last one is
+// notified, e.g. after a silence ended.
+func nextEscalationStep(policy *models.EscalationPolicy, instance *models.AlertInstance, now time.Time) (models.EscalationStep, int, bool) {
+	elapsed := now.Sub(instance.Since)
+	level := instance.EscalationLevel
+
+	next := level
+	for next < len(policy.Steps) && elapsed >= time.Duration(policy.Steps[next].After)*time.Second {
+		next++
+	}
+	if next > level {
+		return policy.Steps[next-1], next, true
+	}
+
+	if level >= len(policy.Steps) && policy.RepeatInterval > 0 &&
+		now.Sub(instance.LastEscalatedAt) >= time.Duration(policy.RepeatInterval)*time.Second {
+		return policy.Steps[len(policy.Steps)-1], level, true
+	}
+	return models.EscalationStep{}, level, false
+}
+
+// SetEscalationPolicyRepository sets the repository escalation policies are loaded from
+func (as *AlertService) SetEscalationPolicyRepository(policyRepo repository.EscalationPolicyRepository) {
+	as.mutex.Lock()
+	as.policyRepo = policyRepo
+	as.mutex.Unlock()
+
+	as.ReloadEscalationPolicies()
+}
+
+// ReloadEscalationPolicies refreshes the cached escalation policies; call it after policies change
+func (as *AlertService) ReloadEscalationPolicies() {
+	as.mutex.RLock()
+	policyRepo := as.policyRepo
+	as.mutex.RUnlock()
+	if policyRepo == nil {
+		return
+	}
+
+	policies, err := policyRepo.GetEscalationPolicies()
+	if err != nil {
+		log.Printf("❌ Failed to load escalation policies: %v", err)
+		return
+	}
+
+	byID := make(map[uint]*models.EscalationPolicy, len(policies))
+	for _, policy := range policies {
+		byID[policy.ID] = policy
+	}
+
+	as.mutex.Lock()
+	as.escalationPolicies = byID
+	as.mutex.Unlock()
+}
+
+// escalationPolicy returns the escalation policy of an alert, if it has one
+func (as *AlertService) escalationPolicy(alert *models.Alert) *models.EscalationPolicy {
+	if alert.EscalationPolicyID == 0 {
+		return nil
+	}
+
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+	return as.escalationPolicies[alert.EscalationPolicyID]
+}
+
+// escalationRoutine runs the escalation scheduler
+func (as *AlertService) escalationRoutine() {
+	ticker := time.NewTicker(escalationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			as.processEscalations(time.Now())
+
+		case <-as.ctx.Done():
+			return
+		}
+	}
+}
+
+// processEscalations notifies the due escalation steps of firing, unacknowledged incidents.
+// Escalation stops once an incident is acknowledged or resolved.
+func (as *AlertService) processEscalations(now time.Time) {
+	as.escalationMutex.Lock()
+	defer as.escalationMutex.Unlock()
+
+	as.mutex.RLock()
+	var candidates []models.AlertInstance
+	if len(as.escalationPolicies) > 0 {
+		for _, instance := range as.instances {
+			if instance.State == AlertStateFiring && instance.HistoryID != 0 && !instance.Acknowledged && !instance.Flapping {
+				candidates = append(candidates, *instance)
+			}
+		}
+	}
+	as.mutex.RUnlock()
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	alerts, err := as.alertRepo.GetActiveAlerts()
+	if err != nil {
+		log.Printf("❌ Failed to get active alerts for escalation: %v", err)
+		return
+	}
+	alertsByID := make(map[uint]*models.Alert, len(alerts))
+	for _, alert := range alerts {
+		alertsByID[alert.ID] = alert
+	}
+
+	for i := range candidates {
+		instance := &candidates[i]
+		alert, exists := alertsByID[instance.AlertID]
+		if !exists {
+			continue
+		}
+		policy := as.escalationPolicy(alert)
+		if policy == nil {
+			continue
+		}
+
+		step, level, due := nextEscalationStep(policy, instance, now)
+		if !due {
+			continue
+		}
+
+		if silence := as.matchingSilence(alert, instance.Hostname, now); silence != nil {
+			continue
+		}
+
+		history, err := as.alertRepo.GetIncident(instance.HistoryID)
+		if err != nil {
+			log.Printf("❌ Failed to load incident %d: %v", instance.HistoryID, err)
+			continue
+		}
+		if history.Resolved || history.Acknowledged {
+			continue
+		}
+
+		// The instance may have started flapping since candidates were collected, e.g. when
+		// escalation runs from emitAlertEvent
+		if !as.escalationPending(instance) {
+			continue
+		}
+
+		description := fmt.Sprintf("step %d of %s: notified %s", level, policy.Name, as.describeEscalationStep(step))
+		if level == instance.EscalationLevel {
+			description = "repeated " + description
+		}
+
+		log.Printf("📣 Escalating incident %d (%s on %s): %s", history.ID, alert.Name, instance.Hostname, description)
+		as.notifyEscalationStep(alertAtSeverity(alert, history.Severity), history, step)
+		as.recordIncidentEvent(history.ID, IncidentEventEscalate, SystemUser, description)
+
+		as.mutex.Lock()
+		current, exists := as.instances[alertInstanceKey(instance.AlertID, instance.Hostname)]
+		if !exists || current.HistoryID != instance.HistoryID {
+			as.mutex.Unlock()
+			continue
+		}
+		current.EscalationLevel = level
+		current.LastEscalatedAt = now
+		current.LastNotifiedAt = now
+		snapshot := *current
+		as.mutex.Unlock()
+
+		as.saveAlertInstance(&snapshot)
+	}
+}
+
+// escalationPending reports whether the live instance of an escalation candidate still has
+// the same incident open, unacknowledged and not flapping
+func (as *AlertService) escalationPending(candidate *models.AlertInstance) bool {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	current, exists := as.instances[alertInstanceKey(candidate.AlertID, candidate.Hostname)]
+	return exists && current.HistoryID == candidate.HistoryID && current.State == AlertStateFiring &&
+		!current.Acknowledged && !current.Flapping
+}
+
+// describeEscalationStep names the destination of an escalation step for the incident timeline
+func (as *AlertService) describeEscalationStep(step models.EscalationStep) string {
+	if step.ChannelID != 0 {
+		if channel := as.notificationChannel(step.ChannelID); channel != nil {
+			return "channel " + channel.Name
+		}
+		return fmt.Sprintf("channel %d", step.ChannelID)
+	}
+	if step.Target != "" {
+		return step.Channel + " " + step.Target
+	}
+	return step.Channel
+}
+
+// notifyEscalationStep sends an incident to the channel of an escalation step
+func (as *AlertService) notifyEscalationStep(alert *models.Alert, history *models.AlertHistory, step models.EscalationStep) {
+	if step.ChannelID != 0 {
+		channel := as.notificationChannel(step.ChannelID)
+		if channel == nil || !channel.Enabled {
+			log.Printf("⚠️ Escalation channel %d is missing or disabled", step.ChannelID)
+			return
+		}
+		as.notifyChannel(channel, alert, history)
+		return
+	}
+
+	stepAlert := *alert
+	stepAlert.EmailEnabled = step.Channel == NotificationEmail
+	stepAlert.WebhookEnabled = step.Channel == NotificationWebhook
+
+	if step.Target != "" {
+		switch step.Channel {
+		case NotificationEmail:
+			stepAlert.EmailRecipients = step.Target
+		case NotificationWebhook:
+			stepAlert.WebhookURL = step.Target
+		}
+	}
+
+	as.sendAlertNotifications(&stepAlert, history)
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// recordingSender records the destinations alerts are sent to
+type recordingSender struct {
+	mutex    sync.Mutex
+	sent     []string
+	messages []string
+	rendered []*NotificationMessage
+}
+
+func (s *recordingSender) SendAlert(alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	destination := alert.EmailRecipients
+	if alert.WebhookEnabled {
+		destination = alert.WebhookURL
+	}
+	s.sent = append(s.sent, destination)
+	s.messages = append(s.messages, history.Message)
+	s.rendered = append(s.rendered, message)
+	return nil
+}
+
+func (s *recordingSender) SendAlertTo(channel *models.NotificationChannel, alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sent = append(s.sent, channel.Type+" "+channel.Config.URL)
+	s.messages = append(s.messages, history.Message)
+	s.rendered = append(s.rendered, message)
+	return nil
+}
+
+func (s *recordingSender) SendTestEmail(to, subject, message string) error { return nil }
+
+func (s *recordingSender) SendTestWebhook(url string, payload map[string]interface{}) error {
+	return nil
+}
+
+func (s *recordingSender) ValidateConfiguration() error { return nil }
+
+func (s *recordingSender) destinations() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.sent...)
+}
+
+func TestValidateEscalationPolicy(t *testing.T) {
+	policy := &models.EscalationPolicy{
+		Name: " on-call ",
+		Steps: []models.EscalationStep{
+			{After: 0, Channel: "Email"},
+			{After: 900, Channel: "webhook", Target: "https://hooks.example.com/b"},
+		},
+		RepeatInterval: 1800,
+	}
+	require.NoError(t, ValidateEscalationPolicy(policy))
+	assert.Equal(t, "on-call", policy.Name)
+	assert.Equal(t, NotificationEmail, policy.Steps[0].Channel)
+
+	tests := []struct {
+		name   string
+		policy models.EscalationPolicy
+	}{
+		{"no name", models.EscalationPolicy{Steps: []models.EscalationStep{{Channel: "email"}}}},
+		{"no steps", models.EscalationPolicy{Name: "p"}},
+		{"bad channel", models.EscalationPolicy{Name: "p", Steps: []models.EscalationStep{{Channel: "sms"}}}},
+		{"unordered", models.EscalationPolicy{Name: "p", Steps: []models.EscalationStep{{After: 600, Channel: "email"}, {After: 60, Channel: "email"}}}},
+		{"short repeat", models.EscalationPolicy{Name: "p", Steps: []models.EscalationStep{{Channel: "email"}}, RepeatInterval: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, ValidateEscalationPolicy(&tt.policy))
+		})
+	}
+}
+
+func TestNextEscalationStep(t *testing.T) {
+	policy := &models.EscalationPolicy{
+		Steps: []models.EscalationStep{
+			{After: 0, Channel: NotificationEmail, Target: "a@example.com"},
+			{After: 900, Channel: NotificationEmail, Target: "b@example.com"},
+		},
+		RepeatInterval: 1800,
+	}
+	start := time.Now()
+	instance := &models.AlertInstance{Since: start}
+
+	step, level, due := nextEscalationStep(policy, instance, start)
+	require.True(t, due)
+	assert.Equal(t, "a@example.com", step.Target)
+	assert.Equal(t, 1, level)
+
+	instance.EscalationLevel, instance.LastEscalatedAt = 1, start
+	_, _, due = nextEscalationStep(policy, instance, start.Add(10*time.Minute))
+	assert.False(t, due)
+
+	step, level, due = nextEscalationStep(policy, instance, start.Add(15*time.Minute))
+	require.True(t, due)
+	assert.Equal(t, "b@example.com", step.Target)
+	assert.Equal(t, 2, level)
+
+	// The last step repeats every repeat interval
+	instance.EscalationLevel, instance.LastEscalatedAt = 2, start.Add(15*time.Minute)
+	_, _, due = nextEscalationStep(policy, instance, start.Add(30*time.Minute))
+	assert.False(t, due)
+	step, level, due = nextEscalationStep(policy, instance, start.Add(45*time.Minute))
+	require.True(t, due)
+	assert.Equal(t, "b@example.com", step.Target)
+	assert.Equal(t, 2, level)
+
+	// Steps that became due together are notified once, at the last one
+	instance.EscalationLevel = 0
+	step, level, due = nextEscalationStep(policy, instance, start.Add(time.Hour))
+	require.True(t, due)
+	assert.Equal(t, "b@example.com", step.Target)
+	assert.Equal(t, 2, level)
+}
+
+func TestAlertService_ProcessEscalations(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	alert.EmailRecipients = "default@example.com"
+	alert.EscalationPolicyID = 3
+	repo := newFakeAlertRepo(alert)
+	sender := &recordingSender{}
+	as := NewAlertService(nil, repo, sender, sender)
+	as.escalationPolicies[3] = &models.EscalationPolicy{
+		BaseModel: models.BaseModel{ID: 3},
+		Name:      "on-call",
+		Steps: []models.EscalationStep{
+			{After: 0, Channel: NotificationEmail},
+			{After: 900, Channel: NotificationWebhook, Target: "https://hooks.example.com/b"},
+		},
+	}
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	start := as.instances[key].Since
+
+	// The first step goes to the alert's own recipients
+	as.processEscalations(start)
+	assert.Equal(t, []string{"default@example.com"}, sender.destinations())
+	assert.Equal(t, 1, as.instances[key].EscalationLevel)
+
+	// Nothing more is due until the second step's delay
+	as.processEscalations(start.Add(5 * time.Minute))
+	assert.Len(t, sender.destinations(), 1)
+
+	as.processEscalations(start.Add(15 * time.Minute))
+	assert.Equal(t, []string{"default@example.com", "https://hooks.example.com/b"}, sender.destinations())
+
+	timeline, err := repo.GetIncidentEvents(as.instances[key].HistoryID)
+	require.NoError(t, err)
+	require.Len(t, timeline, 2)
+	assert.Equal(t, IncidentEventEscalate, timeline[1].Type)
+
+	// Acknowledged incidents stop escalating
+	as.escalationPolicies[3].RepeatInterval = 60
+	_, err = as.AcknowledgeIncident(as.instances[key].HistoryID, "alice", "")
+	require.NoError(t, err)
+	as.processEscalations(start.Add(time.Hour))
+	assert.Len(t, sender.destinations(), 2)
+}
+
+func TestAlertService_FlappingStopsEscalation(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	alert.EmailRecipients = "default@example.com"
+	alert.EscalationPolicyID = 3
+	sender := &recordingSender{}
+	as := NewAlertService(nil, newFakeAlertRepo(alert), sender, sender)
+	as.escalationPolicies[3] = &models.EscalationPolicy{
+		BaseModel: models.BaseModel{ID: 3},
+		Name:      "on-call",
+		Steps:     []models.EscalationStep{{After: 0, Channel: NotificationEmail}},
+	}
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	start := as.instances[key].Since
+	as.instances[key].Flapping = true
+
+	// Past the step delay, but suppressed as flapping
+	as.processEscalations(start.Add(time.Minute))
+	assert.Empty(t, sender.destinations())
+	assert.Zero(t, as.instances[key].EscalationLevel)
+
+	// Candidates that start flapping before their step is sent are skipped as well
+	candidate := *as.instances[key]
+	candidate.Flapping = false
+	assert.False(t, as.escalationPending(&candidate))
+
+	as.instances[key].Flapping = false
+	assert.True(t, as.escalationPending(&candidate))
+	as.processEscalations(start.Add(time.Minute))
+	assert.Equal(t, []string{"default@example.com"}, sender.destinations())
+}
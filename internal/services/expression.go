@@ -0,0 +1,675 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// MetricExpression is the alert metric type for expression-based rules
+const MetricExpression = "expression"
+
+// IsExpressionMetricType reports whether a metric type is evaluated from an expression
+func IsExpressionMetricType(metricType string) bool {
+	return strings.EqualFold(metricType, MetricExpression)
+}
+
+// ExpressionEnv resolves field references and window samples while evaluating an expression
+type ExpressionEnv interface {
+	Field(name string) (float64, error)
+	Samples(name string, window time.Duration) ([]MetricSample, error)
+}
+
+// Expression is a parsed alert expression such as "cpu > 90 and load_avg_5 > cores * 2".
+//
+// Supported syntax, from lowest to highest precedence:
+//   - or, ||
+//   - and, &&
+//   - not, !
+//   - comparisons: >, >=, <, <=, ==, !=
+//   - +, -
+//   - *, /, %
+//   - unary minus, numbers, metric fields, parentheses and the functions abs, min and max
+//
+// Window aggregations take a field and a duration, e.g. "avg(cpu, 5m) > 80",
+// "p95(disk_read_speed, 10m) > 200" or "rate(network_errors, 1m) > 10"; see AggregateSamples.
+//
+// Comparisons and boolean operators yield 1 (true) or 0 (false); any non-zero value is true.
+type Expression struct {
+	source string
+	root   exprNode
+	fields []string
+	window time.Duration
+}
+
+// ParseExpression parses an expression and checks that every field it references exists
+func ParseExpression(source string) (*Expression, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, fmt.Errorf("expression is required")
+	}
+
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+
+	expr := &Expression{source: source, root: root, window: p.window}
+	seen := make(map[string]bool)
+	for _, name := range p.fields {
+		if !IsMetricField(name) {
+			return nil, fmt.Errorf("unknown field %q. Valid fields: %s", name, strings.Join(MetricFieldNames(), ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			expr.fields = append(expr.fields, name)
+		}
+	}
+
+	return expr, nil
+}
+
+// String returns the expression source
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Fields returns the distinct fields referenced by the expression, in order of appearance
+func (e *Expression) Fields() []string {
+	return e.fields
+}
+
+// Window returns the longest aggregation window used by the expression (0 if none)
+func (e *Expression) Window() time.Duration {
+	return e.window
+}
+
+// Evaluate evaluates the expression, resolving fields through env
+func (e *Expression) Evaluate(env ExpressionEnv) (float64, error) {
+	return e.root.eval(env)
+}
+
+// EvaluateMetrics evaluates the expression against a single metrics sample
+func (e *Expression) EvaluateMetrics(metrics *models.SystemMetrics) (float64, error) {
+	return e.Evaluate(metricsEnv{metrics: metrics})
+}
+
+// metricsEnv resolves fields from a metrics sample
+type metricsEnv struct {
+	metrics *models.SystemMetrics
+}
+
+// Field implements ExpressionEnv
+func (env metricsEnv) Field(name string) (float64, error) {
+	value, ok := MetricFieldValue(env.metrics, name)
+	if !ok {
+		return 0, fmt.Errorf("unknown field %q", name)
+	}
+	return value, nil
+}
+
+// Samples implements ExpressionEnv with the current sample as the only one in any window
+func (env metricsEnv) Samples(name string, window time.Duration) ([]MetricSample, error) {
+	value, err := env.Field(name)
+	if err != nil {
+		return nil, err
+	}
+	return []MetricSample{{Time: time.Now(), Value: value}}, nil
+}
+
+// Expression AST
+
+type exprNode interface {
+	eval(env ExpressionEnv) (float64, error)
+}
+
+type numberNode struct {
+	value float64
+}
+
+func (n numberNode) eval(ExpressionEnv) (float64, error) {
+	return n.value, nil
+}
+
+type fieldNode struct {
+	name string
+}
+
+func (n fieldNode) eval(env ExpressionEnv) (float64, error) {
+	return env.Field(n.name)
+}
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n unaryNode) eval(env ExpressionEnv) (float64, error) {
+	value, err := n.operand.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	if n.op == "not" {
+		return boolValue(value == 0), nil
+	}
+	return -value, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n binaryNode) eval(env ExpressionEnv) (float64, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return 0, err
+	}
+
+	// Short-circuit boolean operators
+	switch n.op {
+	case "and":
+		if left == 0 {
+			return 0, nil
+		}
+	case "or":
+		if left != 0 {
+			return 1, nil
+		}
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "and", "or":
+		return boolValue(right != 0), nil
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	case "%":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return math.Mod(left, right), nil
+	case ">":
+		return boolValue(left > right), nil
+	case ">=":
+		return boolValue(left >= right), nil
+	case "<":
+		return boolValue(left < right), nil
+	case "<=":
+		return boolValue(left <= right), nil
+	case "==":
+		return boolValue(left == right), nil
+	case "!=":
+		return boolValue(left != right), nil
+	default:
+		return 0, fmt.Errorf("unsupported operator: %s", n.op)
+	}
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+func (n callNode) eval(env ExpressionEnv) (float64, error) {
+	values := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(env)
+		if err != nil {
+			return 0, err
+		}
+		values[i] = value
+	}
+
+	switch n.name {
+	case "abs":
+		return math.Abs(values[0]), nil
+	case "min":
+		result := values[0]
+		for _, v := range values[1:] {
+			result = math.Min(result, v)
+		}
+		return result, nil
+	case "max":
+		result := values[0]
+		for _, v := range values[1:] {
+			result = math.Max(result, v)
+		}
+		return result, nil
+	default:
+		return 0, fmt.Errorf("unknown function: %s", n.name)
+	}
+}
+
+type windowNode struct {
+	fn     string
+	field  string
+	window time.Duration
+}
+
+func (n windowNode) eval(env ExpressionEnv) (float64, error) {
+	samples, err := env.Samples(n.field, n.window)
+	if err != nil {
+		return 0, err
+	}
+	return AggregateSamples(n.fn, samples)
+}
+
+// expressionFunctions maps function names to their minimum and maximum argument counts (-1 = unlimited)
+var expressionFunctions = map[string][2]int{
+	"abs": {1, 1},
+	"min": {1, -1},
+	"max": {1, -1},
+}
+
+// boolValue converts a boolean to the expression representation
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Tokenizer
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenDuration
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// expressionKeywords maps word operators to their symbolic form
+var expressionKeywords = map[string]string{
+	"and": "and",
+	"or":  "or",
+	"not": "not",
+}
+
+// tokenizeExpression splits an expression into tokens
+func tokenizeExpression(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// A unit suffix makes a duration such as 30s, 5m or 1h
+			if i < len(runes) && strings.ContainsRune("smh", runes[i]) &&
+				(i+1 == len(runes) || !(unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1]) || runes[i+1] == '_')) {
+				i++
+				tokens = append(tokens, exprToken{kind: tokenDuration, text: string(runes[start:i]), pos: start})
+				continue
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			word := strings.ToLower(string(runes[start:i]))
+			if op, isKeyword := expressionKeywords[word]; isKeyword {
+				tokens = append(tokens, exprToken{kind: tokenOperator, text: op, pos: start})
+			} else {
+				tokens = append(tokens, exprToken{kind: tokenIdent, text: word, pos: start})
+			}
+
+		case r == '(':
+			tokens = append(tokens, exprToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, exprToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, exprToken{kind: tokenComma, text: ",", pos: i})
+			i++
+
+		default:
+			// Two-character operators first
+			if i+1 < len(runes) {
+				switch string(runes[i : i+2]) {
+				case ">=", "<=", "==", "!=":
+					tokens = append(tokens, exprToken{kind: tokenOperator, text: string(runes[i : i+2]), pos: i})
+					i += 2
+					continue
+				case "&&":
+					tokens = append(tokens, exprToken{kind: tokenOperator, text: "and", pos: i})
+					i += 2
+					continue
+				case "||":
+					tokens = append(tokens, exprToken{kind: tokenOperator, text: "or", pos: i})
+					i += 2
+					continue
+				}
+			}
+
+			switch r {
+			case '+', '-', '*', '/', '%', '>', '<':
+				tokens = append(tokens, exprToken{kind: tokenOperator, text: string(r), pos: i})
+			case '=':
+				tokens = append(tokens, exprToken{kind: tokenOperator, text: "==", pos: i})
+			case '!':
+				tokens = append(tokens, exprToken{kind: tokenOperator, text: "not", pos: i})
+			default:
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i+1)
+			}
+			i++
+		}
+	}
+
+	return append(tokens, exprToken{kind: tokenEOF, text: "end of expression", pos: len(runes)}), nil
+}
+
+// Parser
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	fields []string
+	window time.Duration // Longest aggregation window
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// acceptOperator consumes the next token if it is one of ops
+func (p *exprParser) acceptOperator(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.next()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOperator("or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: "or", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOperator("and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: "and", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if _, ok := p.acceptOperator("not"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op: "not", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := p.acceptOperator(">", ">=", "<", "<=", "==", "!=")
+	if !ok {
+		return left, nil
+	}
+
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	// Chained comparisons such as "1 < cpu < 5" are ambiguous
+	if tok := p.peek(); tok.kind == tokenOperator && strings.ContainsAny(tok.text, "<>=") {
+		return nil, fmt.Errorf("chained comparison at position %d; combine comparisons with and/or", tok.pos+1)
+	}
+
+	return binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOperator("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOperator("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if op, ok := p.acceptOperator("-", "+"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "+" {
+			return operand, nil
+		}
+		return unaryNode{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos+1)
+		}
+		return numberNode{value: value}, nil
+
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+		p.fields = append(p.fields, tok.text)
+		return fieldNode{name: tok.text}, nil
+
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ) at position %d, got %q", closing.pos+1, closing.text)
+		}
+		return inner, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+}
+
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	// Window aggregations take a field and a duration; min and max are also plain functions
+	if IsAggregationFunction(name.text) && p.isWindowCall() {
+		return p.parseWindowCall(name)
+	}
+
+	arity, exists := expressionFunctions[name.text]
+	if !exists && IsAggregationFunction(name.text) {
+		return nil, fmt.Errorf("%s at position %d expects a field and a window, e.g. %s(cpu, 5m)", name.text, name.pos+1, name.text)
+	}
+	if !exists {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos+1)
+	}
+
+	p.next() // (
+	var args []exprNode
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if closing := p.next(); closing.kind != tokenRParen {
+		return nil, fmt.Errorf("expected ) at position %d, got %q", closing.pos+1, closing.text)
+	}
+
+	if len(args) < arity[0] || (arity[1] >= 0 && len(args) > arity[1]) {
+		return nil, fmt.Errorf("wrong number of arguments to %s: %d", name.text, len(args))
+	}
+
+	return callNode{name: name.text, args: args}, nil
+}
+
+// isWindowCall reports whether the upcoming tokens are "(field, duration)"
+func (p *exprParser) isWindowCall() bool {
+	if p.pos+4 >= len(p.tokens) {
+		return false
+	}
+	next := p.tokens[p.pos : p.pos+5]
+	return next[0].kind == tokenLParen && next[1].kind == tokenIdent && next[2].kind == tokenComma &&
+		next[3].kind == tokenDuration && next[4].kind == tokenRParen
+}
+
+// parseWindowCall parses an aggregation over a field such as avg(cpu, 5m)
+func (p *exprParser) parseWindowCall(name exprToken) (exprNode, error) {
+	p.next() // (
+	field := p.next()
+	p.next() // ,
+	durationToken := p.next()
+	p.next() // )
+
+	window, err := time.ParseDuration(durationToken.text)
+	if err != nil || window <= 0 {
+		return nil, fmt.Errorf("invalid window %q at position %d", durationToken.text, durationToken.pos+1)
+	}
+	if window > MaxAggregationWindow {
+		return nil, fmt.Errorf("window %s at position %d exceeds the maximum of %s", durationToken.text, durationToken.pos+1, MaxAggregationWindow)
+	}
+
+	p.fields = append(p.fields, field.text)
+	if window > p.window {
+		p.window = window
+	}
+
+	return windowNode{fn: name.text, field: field.text, window: window}, nil
+}
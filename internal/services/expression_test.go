@@ -0,0 +1,119 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func newExpressionTestMetrics() *models.SystemMetrics {
+	return &models.SystemMetrics{
+		Hostname: "web-1",
+		CPU: models.CPUMetrics{
+			Usage:   95,
+			Cores:   4,
+			LoadAvg: []float64{6, 9, 4},
+		},
+		Memory: models.MemoryMetrics{
+			Percent:     70,
+			SwapPercent: 60,
+		},
+		Network: models.NetworkMetrics{
+			Interfaces: []models.NetworkInterface{
+				{Name: "eth0", Errors: 3},
+				{Name: "eth1", Errors: 4},
+			},
+		},
+	}
+}
+
+func TestParseExpression_Evaluate(t *testing.T) {
+	metrics := newExpressionTestMetrics()
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"cpu > 90 and load_avg_5 > cores * 2", 1},
+		{"cpu > 90 && load_avg_5 > cores * 3", 0},
+		{"memory > 85 or swap_percent > 50", 1},
+		{"memory > 85 || swap_percent > 65", 0},
+		{"not (memory > 85)", 1},
+		{"!(cpu >= 95)", 0},
+		{"CPU == 95 AND NOT memory != 70", 1},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-cores + 10 % 4", -2},
+		{"load_avg_1 / cores", 1.5},
+		{"max(load_avg_1, load_avg_5, load_avg_15) - min(1, 2)", 8},
+		{"abs(-2.5)", 2.5},
+		{"network_errors", 7},
+		{"0 and 1 / 0", 0}, // short-circuit skips the division
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expression)
+			require.NoError(t, err)
+
+			got, err := expr.EvaluateMetrics(metrics)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestParseExpression_Errors(t *testing.T) {
+	invalid := []string{
+		"",
+		"cpu >",
+		"cpu > 90 and",
+		"(cpu > 90",
+		"cpu > 90)",
+		"cpu $ 90",
+		"unknown_field > 1",
+		"sqrt(cpu)",
+		"abs(cpu, memory)",
+		"1 < cpu < 5",
+		"cpu 90",
+	}
+
+	for _, source := range invalid {
+		_, err := ParseExpression(source)
+		assert.Error(t, err, source)
+	}
+}
+
+func TestExpression_Fields(t *testing.T) {
+	expr, err := ParseExpression("cpu > 90 and load_avg_5 > cores * 2 or cpu > 99")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "load_avg_5", "cores"}, expr.Fields())
+}
+
+func TestExpression_DivisionByZero(t *testing.T) {
+	expr, err := ParseExpression("cpu / (cores - 4)")
+	require.NoError(t, err)
+
+	_, err = expr.EvaluateMetrics(newExpressionTestMetrics())
+	assert.Error(t, err)
+}
+
+func TestAlertService_ExpressionAlertMessage(t *testing.T) {
+	expr, err := ParseExpression("cpu > 90 and load_avg_5 > cores * 2")
+	require.NoError(t, err)
+
+	as := NewAlertService(nil, nil, nil, nil)
+	message := as.generateExpressionAlertMessage(&models.Alert{}, expr, newExpressionTestMetrics())
+	assert.Equal(t, "Expression 'cpu > 90 and load_avg_5 > cores * 2' matched on web-1 (cpu=95.00, load_avg_5=9.00, cores=4.00)", message)
+
+	met, err := as.alertConditionMet(&models.Alert{MetricType: MetricExpression}, 1)
+	require.NoError(t, err)
+	assert.True(t, met)
+
+	met, err = as.alertConditionMet(&models.Alert{MetricType: MetricExpression, Condition: ">", Threshold: 5}, 3)
+	require.NoError(t, err)
+	assert.False(t, met)
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// clearConditionMet reports whether a firing alert still holds under its clear threshold.
+// Alerts without a clear threshold, or not firing on the host, use their condition as is.
+func (as *AlertService) clearConditionMet(alert *models.Alert, hostname string, value float64) (bool, error) {
+	if alert.ClearThreshold == nil {
+		return false, nil
+	}
+
+	as.mutex.RLock()
+	instance, exists := as.instances[alertInstanceKey(alert.ID, hostname)]
+	firing := exists && instance.State == AlertStateFiring
+	as.mutex.RUnlock()
+	if !firing {
+		return false, nil
+	}
+
+	return as.evaluateCondition(alert.Condition, value, *alert.ClearThreshold)
+}
+
+// updateFlapping drops state changes older than the flap window and updates whether an
+// instance is flapping. An instance starts flapping once it changed state FlapThreshold
+// times within the window and stabilizes when fewer than half as many changes remain.
+// Callers must hold as.mutex.
+func (as *AlertService) updateFlapping(instance *models.AlertInstance, now time.Time) (started, stabilized bool) {
+	if as.config.FlapThreshold <= 0 {
+		instance.StateChanges = nil
+		if instance.Flapping {
+			instance.Flapping = false
+			return false, true
+		}
+		return false, false
+	}
+
+	// Copy on write, snapshots of the instance share the old slice
+	cutoff := now.Add(-as.config.FlapWindow)
+	var changes []time.Time
+	for _, changedAt := range instance.StateChanges {
+		if changedAt.After(cutoff) {
+			changes = append(changes, changedAt)
+		}
+	}
+	instance.StateChanges = changes
+
+	switch {
+	case !instance.Flapping && len(changes) >= as.config.FlapThreshold:
+		instance.Flapping = true
+		return true, false
+	case instance.Flapping && len(changes) < (as.config.FlapThreshold+1)/2:
+		instance.Flapping = false
+		return false, true
+	}
+	return false, false
+}
+
+// recordFlapChange logs an instance starting or stopping to flap on its latest incident
+func (as *AlertService) recordFlapChange(alert *models.Alert, instance *models.AlertInstance, flapping bool) {
+	comment := "stabilized, notifications resumed"
+	if flapping {
+		comment = "flapping, notifications suppressed until it stabilizes"
+	}
+	log.Printf("〰️ Alert %d (%s) on %s %s", alert.ID, alert.Name, instance.Hostname, comment)
+
+	if instance.HistoryID != 0 {
+		as.recordIncidentEvent(instance.HistoryID, IncidentEventFlapping, SystemUser, comment)
+	}
+}
+
+// announceStabilized notifies the current state of an instance that stopped flapping,
+// since the transitions while it was flapping were not notified
+func (as *AlertService) announceStabilized(alert *models.Alert, instance *models.AlertInstance, message string, extra map[string]interface{}) {
+	if instance.HistoryID == 0 {
+		return
+	}
+
+	if instance.State == AlertStateFiring {
+		as.updateIncident(alert, instance, message, extra)
+		return
+	}
+
+	history, err := as.alertRepo.GetIncident(instance.HistoryID)
+	if err != nil {
+		log.Printf("❌ Failed to load incident %d: %v", instance.HistoryID, err)
+		return
+	}
+	if history.Resolved {
+		as.emitAlertEvent(&AlertEvent{Type: AlertEventResolved, Alert: alert, Instance: *instance, History: history, Extra: extra})
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flapRecorder records broadcast alert events and whether they were flapping
+type flapRecorder struct {
+	events []string
+}
+
+func (r *flapRecorder) BroadcastAlertEvent(eventType string, data map[string]interface{}) {
+	if data["flapping"].(bool) {
+		eventType += " (flapping)"
+	}
+	r.events = append(r.events, eventType)
+}
+
+func TestAlertService_ClearThreshold(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	clearAt := 70.0
+	alert.ClearThreshold = &clearAt
+	as := NewAlertService(nil, newFakeAlertRepo(alert), nil, nil)
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	// Below the threshold but above the clear threshold: no incident yet
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 75, "", nil))
+	assert.Empty(t, as.instances)
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	assert.Equal(t, AlertStateFiring, as.instances[key].State)
+
+	// Firing alerts only clear past the clear threshold
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 75, "", nil))
+	assert.Equal(t, AlertStateFiring, as.instances[key].State)
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 65, "", nil))
+	assert.Equal(t, AlertStateResolved, as.instances[key].State)
+}
+
+func TestAlertService_FlapDetection(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+	as.config.FlapThreshold = 4
+	recorder := &flapRecorder{}
+	as.SetWebSocketHandler(recorder)
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	for _, value := range []float64{90, 10, 90, 10, 90} {
+		require.NoError(t, as.evaluateAlertValue(alert, "web-1", value, "", nil))
+	}
+	assert.Equal(t, []string{
+		AlertEventFiring, AlertEventResolved, AlertEventFiring,
+		AlertEventResolved + " (flapping)", AlertEventFiring + " (flapping)",
+	}, recorder.events)
+	assert.True(t, as.instances[key].Flapping)
+
+	flapComments := func() []string {
+		var comments []string
+		for _, event := range repo.events {
+			if event.Type == IncidentEventFlapping {
+				comments = append(comments, event.Comment)
+			}
+		}
+		return comments
+	}
+	assert.Equal(t, []string{"flapping, notifications suppressed until it stabilizes"}, flapComments())
+
+	// Once the changes age out of the window, the current state is notified again
+	as.instances[key].StateChanges = []time.Time{time.Now().Add(-time.Hour)}
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 91, "", nil))
+	assert.False(t, as.instances[key].Flapping)
+	assert.Equal(t, AlertEventFiring, recorder.events[len(recorder.events)-1])
+	assert.Equal(t, "stabilized, notifications resumed", flapComments()[1])
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// MetricDiskForecast is the alert metric type for predicted hours until a partition is full
+const MetricDiskForecast = "disk_forecast"
+
+// Disk forecast settings
+const (
+	// MaxForecastLookback is the longest usage history kept for forecasting
+	MaxForecastLookback = 24 * time.Hour
+	// defaultForecastLookback is used by forecast alerts without a window
+	defaultForecastLookback = 6 * time.Hour
+	// minForecastSpan is the least history needed before a forecast is made
+	minForecastSpan = 15 * time.Minute
+	// forecastSampleInterval downsamples partition usage to keep long histories small
+	forecastSampleInterval = time.Minute
+	// MaxForecastHours caps projections, and is the projection of partitions that are not
+	// growing, so alert values stay finite and JSON encodable
+	MaxForecastHours = 10 * 365 * 24
+)
+
+// IsForecastMetricType reports whether a metric type is a disk forecast
+func IsForecastMetricType(metricType string) bool {
+	return strings.EqualFold(metricType, MetricDiskForecast)
+}
+
+// DiskForecast is a linear projection of a partition's usage
+type DiskForecast struct {
+	Mountpoint     string    `json:"mountpoint"`
+	CurrentPercent float64   `json:"current_percent"`
+	GrowthPerHour  float64   `json:"growth_percent_per_hour"` // Fitted usage growth (percentage points per hour)
+	HoursUntilFull float64   `json:"hours_until_full"`        // MaxForecastHours when usage is not growing
+	FullAt         time.Time `json:"full_at"`                 // Zero when usage is not growing
+	Samples        int       `json:"samples"`
+}
+
+// ForecastDiskFull fits a least-squares line to usage samples (percent over time) and
+// projects when usage reaches 100%. It returns false if the samples span too little time.
+func ForecastDiskFull(samples []MetricSample, now time.Time) (DiskForecast, bool) {
+	if len(samples) < 3 || samples[len(samples)-1].Time.Sub(samples[0].Time) < minForecastSpan {
+		return DiskForecast{}, false
+	}
+
+	// Fit usage = intercept + slope*hours, with hours relative to the first sample
+	origin := samples[0].Time
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.Time.Sub(origin).Hours()
+		sumX += x
+		sumY += s.Value
+		sumXY += x * s.Value
+		sumXX += x * x
+	}
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return DiskForecast{}, false
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+
+	current := samples[len(samples)-1].Value
+	forecast := DiskForecast{
+		CurrentPercent: current,
+		GrowthPerHour:  slope,
+		HoursUntilFull: MaxForecastHours,
+		Samples:        len(samples),
+	}
+
+	if slope > 0 && (100-current)/slope < MaxForecastHours {
+		forecast.HoursUntilFull = math.Max(0, (100-current)/slope)
+		forecast.FullAt = now.Add(time.Duration(forecast.HoursUntilFull * float64(time.Hour)))
+	}
+
+	return forecast, true
+}
+
+// partitionKey identifies a partition's usage history
+func partitionKey(hostname, mountpoint string) string {
+	return hostname + "|" + mountpoint
+}
+
+// recordPartitionUsage stores partition usage for disk forecasts, at most once per forecastSampleInterval
+func (as *AlertService) recordPartitionUsage(metrics *models.SystemMetrics) {
+	now := time.Now()
+	cutoff := now.Add(-MaxForecastLookback)
+
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	for _, partition := range metrics.Disk.Partitions {
+		key := partitionKey(metrics.Hostname, partition.Mountpoint)
+		history := as.partitionUsage[key]
+		if len(history) > 0 && now.Sub(history[len(history)-1].Time) < forecastSampleInterval {
+			continue
+		}
+
+		history = append(history, MetricSample{Time: now, Value: partition.Percent})
+		drop := 0
+		for drop < len(history) && history[drop].Time.Before(cutoff) {
+			drop++
+		}
+		as.partitionUsage[key] = history[drop:]
+	}
+}
+
+// partitionSamples returns a partition's usage samples within the lookback window
+func (as *AlertService) partitionSamples(hostname, mountpoint string, lookback time.Duration) []MetricSample {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	cutoff := time.Now().Add(-lookback)
+	var samples []MetricSample
+	for _, s := range as.partitionUsage[partitionKey(hostname, mountpoint)] {
+		if !s.Time.Before(cutoff) {
+			samples = append(samples, s)
+		}
+	}
+	return samples
+}
+
+// forecastLookback returns a forecast alert's lookback window
+func forecastLookback(alert *models.Alert) time.Duration {
+	if alert.Window <= 0 {
+		return defaultForecastLookback
+	}
+	return time.Duration(alert.Window) * time.Second
+}
+
+// checkDiskForecast evaluates a disk forecast alert for each partition of a host.
+// The alert value is the projected hours until the partition is full.
+func (as *AlertService) checkDiskForecast(alert *models.Alert, metrics *models.SystemMetrics) error {
+	lookback := forecastLookback(alert)
+
+	for _, partition := range metrics.Disk.Partitions {
+		if alert.Mountpoint != "" && alert.Mountpoint != partition.Mountpoint {
+			continue
+		}
+
+		forecast, ok := ForecastDiskFull(as.partitionSamples(metrics.Hostname, partition.Mountpoint, lookback), time.Now())
+		if !ok {
+			continue // Not enough history yet
+		}
+		forecast.Mountpoint = partition.Mountpoint
+
+		extra := map[string]interface{}{
+			"mountpoint":              partition.Mountpoint,
+			"growth_percent_per_hour": forecast.GrowthPerHour,
+		}
+		if !forecast.FullAt.IsZero() {
+			extra["full_at"] = forecast.FullAt
+		}
+
+		source := fmt.Sprintf("%s:%s", metrics.Hostname, partition.Mountpoint)
+		message := as.generateForecastAlertMessage(alert, forecast, metrics.Hostname)
+		if err := as.evaluateAlertValue(alert, source, forecast.HoursUntilFull, message, extra); err != nil {
+			log.Printf("❌ Error evaluating disk forecast for alert %d on %s: %v", alert.ID, source, err)
+		}
+	}
+
+	return nil
+}
+
+// generateForecastAlertMessage generates a human-readable message for disk forecast alerts
+func (as *AlertService) generateForecastAlertMessage(alert *models.Alert, forecast DiskForecast, hostname string) string {
+	if forecast.FullAt.IsZero() {
+		return fmt.Sprintf("Partition %s on %s is %.1f%% full and not growing",
+			forecast.Mountpoint, hostname, forecast.CurrentPercent)
+	}
+
+	return fmt.Sprintf("Partition %s on %s is %.1f%% full and growing %.2f%%/h; projected full in %.1fh (around %s, threshold: %s %.0fh)",
+		forecast.Mountpoint, hostname, forecast.CurrentPercent, forecast.GrowthPerHour,
+		forecast.HoursUntilFull, forecast.FullAt.Format("2006-01-02 15:04 MST"), alert.Condition, alert.Threshold)
+}
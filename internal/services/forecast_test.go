@@ -0,0 +1,127 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// newUsageSamples returns hourly usage samples ending at now
+func newUsageSamples(now time.Time, values ...float64) []MetricSample {
+	samples := make([]MetricSample, len(values))
+	for i, v := range values {
+		samples[i] = MetricSample{Time: now.Add(-time.Duration(len(values)-1-i) * time.Hour), Value: v}
+	}
+	return samples
+}
+
+func TestForecastDiskFull(t *testing.T) {
+	now := time.Now()
+
+	// Growing 2 points per hour from 80%: full in 10 hours
+	forecast, ok := ForecastDiskFull(newUsageSamples(now, 74, 76, 78, 80), now)
+	require.True(t, ok)
+	assert.InDelta(t, 2, forecast.GrowthPerHour, 1e-9)
+	assert.InDelta(t, 10, forecast.HoursUntilFull, 1e-9)
+	assert.WithinDuration(t, now.Add(10*time.Hour), forecast.FullAt, time.Second)
+
+	// Noisy growth still fits the trend
+	forecast, ok = ForecastDiskFull(newUsageSamples(now, 50, 53, 52, 56, 57, 60), now)
+	require.True(t, ok)
+	assert.Greater(t, forecast.GrowthPerHour, 1.5)
+	assert.Less(t, forecast.HoursUntilFull, 30.0)
+
+	// Flat or shrinking usage never fills up
+	forecast, ok = ForecastDiskFull(newUsageSamples(now, 60, 59, 58), now)
+	require.True(t, ok)
+	assert.Equal(t, float64(MaxForecastHours), forecast.HoursUntilFull)
+	assert.True(t, forecast.FullAt.IsZero())
+
+	// So does usage growing too slowly to fill up within the cap
+	forecast, ok = ForecastDiskFull(newUsageSamples(now, 10, 10.0001, 10.0002), now)
+	require.True(t, ok)
+	assert.Equal(t, float64(MaxForecastHours), forecast.HoursUntilFull)
+	assert.True(t, forecast.FullAt.IsZero())
+
+	// Too little history
+	_, ok = ForecastDiskFull(newUsageSamples(now, 60, 61), now)
+	assert.False(t, ok)
+
+	short := []MetricSample{
+		{Time: now.Add(-2 * time.Minute), Value: 10},
+		{Time: now.Add(-time.Minute), Value: 20},
+		{Time: now, Value: 30},
+	}
+	_, ok = ForecastDiskFull(short, now)
+	assert.False(t, ok)
+}
+
+func TestAlertService_DiskForecastMessage(t *testing.T) {
+	as := NewAlertService(nil, nil, nil, nil)
+	alert := &models.Alert{MetricType: MetricDiskForecast, Condition: "<", Threshold: 24}
+	fullAt := time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)
+
+	message := as.generateForecastAlertMessage(alert, DiskForecast{
+		Mountpoint:     "/var",
+		CurrentPercent: 80,
+		GrowthPerHour:  2,
+		HoursUntilFull: 10,
+		FullAt:         fullAt,
+	}, "web-1")
+	assert.Equal(t, "Partition /var on web-1 is 80.0% full and growing 2.00%/h; projected full in 10.0h (around 2026-01-02 15:04 UTC, threshold: < 24h)", message)
+
+	assert.Equal(t, "h", metricUnit(MetricDiskForecast))
+	assert.Equal(t, "%", metricUnit("disk"))
+	assert.Equal(t, "", metricUnit("threads"))
+}
+
+func TestAlertService_RecordPartitionUsage(t *testing.T) {
+	as := NewAlertService(nil, nil, nil, nil)
+	metrics := &models.SystemMetrics{
+		Hostname: "web-1",
+		Disk: models.DiskMetrics{
+			Partitions: []models.PartitionInfo{{Mountpoint: "/", Percent: 40}, {Mountpoint: "/data", Percent: 70}},
+		},
+	}
+
+	as.recordPartitionUsage(metrics)
+	as.recordPartitionUsage(metrics) // Downsampled: within the sample interval
+
+	samples := as.partitionSamples("web-1", "/data", time.Hour)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 70.0, samples[0].Value)
+	assert.Empty(t, as.partitionSamples("web-2", "/data", time.Hour))
+}
+
+func TestAlertService_DiskForecastFlatPartition(t *testing.T) {
+	alert := &models.Alert{
+		BaseModel: models.BaseModel{ID: 1}, Name: "disk filling", MetricType: MetricDiskForecast,
+		Condition: "<", Threshold: 24, Severity: "warning", IsActive: true,
+	}
+	as := NewAlertService(nil, newFakeAlertRepo(alert), nil, nil)
+	metrics := &models.SystemMetrics{
+		Hostname: "web-1",
+		Disk:     models.DiskMetrics{Partitions: []models.PartitionInfo{{Mountpoint: "/", Percent: 80}}},
+	}
+	key := alertInstanceKey(alert.ID, "web-1:/")
+	now := time.Now()
+
+	// Growing: full in 10 hours
+	as.partitionUsage[partitionKey("web-1", "/")] = newUsageSamples(now, 74, 76, 78, 80)
+	require.NoError(t, as.checkDiskForecast(alert, metrics))
+	assert.Equal(t, AlertStateFiring, as.instances[key].State)
+
+	// Flat: the incident resolves and the instance still encodes
+	as.partitionUsage[partitionKey("web-1", "/")] = newUsageSamples(now, 80, 80, 80, 80)
+	require.NoError(t, as.checkDiskForecast(alert, metrics))
+	instance := as.instances[key]
+	assert.Equal(t, AlertStateResolved, instance.State)
+	assert.Equal(t, float64(MaxForecastHours), instance.LastValue)
+	_, err := json.Marshal(instance)
+	assert.NoError(t, err)
+}
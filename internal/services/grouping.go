@@ -0,0 +1,371 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// groupCheckInterval is how often notification groups are checked for due notifications
+const groupCheckInterval = 5 * time.Second
+
+// groupMember is an incident collected in a notification group
+type groupMember struct {
+	alert   *models.Alert
+	history models.AlertHistory
+}
+
+// notificationGroup batches the notifications of alerts sharing the same group key values.
+// The first notification goes out after the group wait, later ones at most every group
+// interval and only when the firing members or their severities changed.
+type notificationGroup struct {
+	route     *models.NotificationRoute // nil = the email and webhook settings of the alert
+	channels  []*models.NotificationChannel
+	labels    []string                  // key=value of each group key
+	wait      time.Duration             // Delay before the first notification
+	interval  time.Duration             // Minimum delay between notifications
+	members   map[string]groupMember    // Key: alert_id_hostname, Value: firing incident
+	resolved  []groupMember             // Notified members resolved since the last notification
+	notified  map[string]notifiedMember // Key: alert_id_hostname, Value: incident as last notified
+	nextFlush time.Time
+}
+
+// notifiedMember identifies the incident and severity a group member was last notified with
+type notifiedMember struct {
+	incident uint
+	severity string
+}
+
+// groupNotification is a snapshot of a notification group due to be sent
+type groupNotification struct {
+	route    *models.NotificationRoute
+	channels []*models.NotificationChannel
+	labels   []string
+	firing   []groupMember
+	resolved []groupMember
+}
+
+// normalizeGroupBy trims group keys, lowercases the built-in ones and drops duplicates
+func normalizeGroupBy(keys []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool)
+	for i, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("group key %d is empty", i+1)
+		}
+		switch lower := strings.ToLower(key); lower {
+		case MatchAlertName, MatchSeverity, MatchHostname:
+			key = lower
+		}
+		if !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, key)
+		}
+	}
+	return normalized, nil
+}
+
+// notified returns the incident and severity a notification about the member covers
+func (m groupMember) notified() notifiedMember {
+	return notifiedMember{incident: m.history.ID, severity: m.history.Severity}
+}
+
+// changed reports whether the firing members or their severities differ from the last notification
+func (g *notificationGroup) changed() bool {
+	if len(g.members) != len(g.notified) {
+		return true
+	}
+	for key, member := range g.members {
+		if g.notified[key] != member.notified() {
+			return true
+		}
+	}
+	return false
+}
+
+// addToNotificationGroup collects an alert notification in the group of its route and group
+// key values. A nil route groups by ALERTS_GROUP_BY and notifies with the settings of the alert.
+func (as *AlertService) addToNotificationGroup(route *models.NotificationRoute, channels []*models.NotificationChannel, alert *models.Alert, history *models.AlertHistory) {
+	groupBy := as.config.GroupBy
+	wait := as.config.GroupWait
+	interval := as.config.GroupInterval
+	// Fallback groups stay per alert since each alert has its own recipients
+	routeKey := fmt.Sprintf("alert:%d", alert.ID)
+	if route != nil {
+		groupBy = route.GroupBy
+		routeKey = fmt.Sprintf("route:%d", route.ID)
+		if route.GroupWait > 0 {
+			wait = time.Duration(route.GroupWait) * time.Second
+		}
+		if route.GroupInterval > 0 {
+			interval = time.Duration(route.GroupInterval) * time.Second
+		}
+	}
+
+	labels := make([]string, 0, len(groupBy))
+	for _, key := range groupBy {
+		labels = append(labels, key+"="+alertFieldValue(key, alert, history.Hostname))
+	}
+	groupKey := routeKey + "|" + strings.Join(labels, ",")
+	memberKey := alertInstanceKey(alert.ID, history.Hostname)
+
+	as.groupMutex.Lock()
+	group, exists := as.groups[groupKey]
+
+	if history.Resolved {
+		member, isMember := group.memberOf(memberKey)
+		if !isMember || member.history.ID != history.ID {
+			// Not collected in a group, e.g. an incident notified by its escalation policy
+			as.groupMutex.Unlock()
+			if notifiesResolution(alert, history) {
+				as.deliverUngrouped(route, channels, alert, history)
+			}
+			return
+		}
+
+		delete(group.members, memberKey)
+		if group.notified[memberKey].incident == history.ID && notifiesResolution(alert, history) {
+			group.resolved = append(group.resolved, groupMember{alert: alert, history: *history})
+		}
+		as.groupMutex.Unlock()
+		return
+	}
+
+	if !exists {
+		group = &notificationGroup{
+			labels:    labels,
+			wait:      wait,
+			interval:  interval,
+			members:   make(map[string]groupMember),
+			notified:  make(map[string]notifiedMember),
+			nextFlush: time.Now().Add(wait),
+		}
+		as.groups[groupKey] = group
+		log.Printf("🗂️ Started notification group %s", groupKey)
+	}
+	group.route = route
+	group.channels = channels
+	group.members[memberKey] = groupMember{alert: alert, history: *history}
+
+	// A member whose severity changed moves out of the group keyed by its old severity
+	for key, other := range as.groups {
+		if key != groupKey && strings.HasPrefix(key, routeKey+"|") {
+			delete(other.members, memberKey)
+		}
+	}
+	as.groupMutex.Unlock()
+}
+
+// memberOf returns a firing member of a group, which may be nil
+func (g *notificationGroup) memberOf(key string) (groupMember, bool) {
+	if g == nil {
+		return groupMember{}, false
+	}
+	member, exists := g.members[key]
+	return member, exists
+}
+
+// deliverUngrouped sends an alert right away to the channels of a route, or with its own settings
+func (as *AlertService) deliverUngrouped(route *models.NotificationRoute, channels []*models.NotificationChannel, alert *models.Alert, history *models.AlertHistory) {
+	if route == nil {
+		as.sendAlertNotifications(alert, history)
+		return
+	}
+	for _, channel := range channels {
+		as.notifyChannel(channel, alert, history)
+	}
+}
+
+// groupingRoutine sends notification groups once their wait or interval passed
+func (as *AlertService) groupingRoutine() {
+	ticker := time.NewTicker(groupCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			as.flushNotificationGroups(time.Now())
+
+		case <-as.ctx.Done():
+			return
+		}
+	}
+}
+
+// flushNotificationGroups notifies the due groups whose firing members changed since their
+// last notification. Groups without firing members are dropped once their resolution is sent.
+func (as *AlertService) flushNotificationGroups(now time.Time) {
+	as.groupMutex.Lock()
+	var due []groupNotification
+	for key, group := range as.groups {
+		if now.Before(group.nextFlush) {
+			continue
+		}
+		group.nextFlush = now.Add(group.interval)
+
+		if group.changed() {
+			notification := groupNotification{
+				route:    group.route,
+				channels: group.channels,
+				labels:   group.labels,
+				resolved: group.resolved,
+			}
+			group.notified = make(map[string]notifiedMember, len(group.members))
+			for memberKey, member := range group.members {
+				notification.firing = append(notification.firing, member)
+				group.notified[memberKey] = member.notified()
+			}
+			group.resolved = nil
+			// Nothing to tell when the only change is a resolution the alert opted out of
+			if len(notification.firing)+len(notification.resolved) > 0 {
+				due = append(due, notification)
+			}
+		}
+
+		if len(group.members) == 0 {
+			delete(as.groups, key)
+		}
+	}
+	as.groupMutex.Unlock()
+
+	for i := range due {
+		as.deliverGroupNotification(&due[i], now)
+	}
+}
+
+// deliverGroupNotification sends the summary of a notification group and marks the
+// notification sent on its firing incidents
+func (as *AlertService) deliverGroupNotification(notification *groupNotification, now time.Time) {
+	alert, history := summarizeGroup(notification, now)
+
+	var incidents []uint
+	for _, member := range notification.firing {
+		incidents = append(incidents, member.history.ID)
+	}
+	markSent := func(sent string) {
+		for _, id := range incidents {
+			if err := as.alertRepo.MarkNotificationSent(id, sent); err != nil {
+				log.Printf("❌ Failed to update %s sent status: %v", sent, err)
+			}
+		}
+	}
+
+	log.Printf("🗂️ Sending notification group %s: %d firing, %d resolved",
+		strings.Join(notification.labels, ","), len(notification.firing), len(notification.resolved))
+
+	if notification.route == nil {
+		if alert.EmailEnabled && as.emailSender != nil {
+			if err := as.emailSender.SendAlert(alert, history, as.RenderNotification(ChannelTypeEmail, alert, history)); err != nil {
+				log.Printf("❌ Failed to send email alert: %v", err)
+			} else {
+				markSent(NotificationEmail)
+			}
+		}
+		if alert.WebhookEnabled && as.webhookSender != nil {
+			message := as.RenderNotification(DetectWebhookFormat(alert.WebhookURL), alert, history)
+			if err := as.webhookSender.SendAlert(alert, history, message); err != nil {
+				log.Printf("❌ Failed to send webhook alert: %v", err)
+			} else {
+				markSent(NotificationWebhook)
+			}
+		}
+		return
+	}
+
+	for _, channel := range notification.channels {
+		if err := as.deliverToChannel(channel, alert, history); err != nil {
+			log.Printf("❌ Failed to notify channel %s: %v", channel.Name, err)
+			continue
+		}
+		if channel.Type == ChannelTypeEmail {
+			markSent(NotificationEmail)
+		} else {
+			markSent(NotificationWebhook)
+		}
+	}
+}
+
+// summarizeGroup builds the alert and history of a group notification. A group with a single
+// member is notified as that incident; larger groups get a summary listing every member.
+func summarizeGroup(notification *groupNotification, now time.Time) (*models.Alert, *models.AlertHistory) {
+	firing := sortGroupMembers(notification.firing)
+	resolved := sortGroupMembers(notification.resolved)
+
+	members := append(append([]groupMember(nil), firing...), resolved...)
+	if len(members) == 1 {
+		alert := *members[0].alert
+		history := members[0].history
+		return &alert, &history
+	}
+
+	// The most severe member leads the summary
+	lead := members[0]
+	alert := *lead.alert
+	history := lead.history
+	history.ID = 0
+	history.Resolved = len(firing) == 0
+	if history.Resolved {
+		history.ResolvedAt = now
+	}
+
+	names := make(map[string]bool)
+	hosts := make(map[string]bool)
+	for _, member := range members {
+		names[member.alert.Name] = true
+		hosts[member.history.Hostname] = true
+		if member.history.CreatedAt.Before(history.CreatedAt) {
+			history.CreatedAt = member.history.CreatedAt
+		}
+	}
+	if len(names) > 1 {
+		alert.Name = fmt.Sprintf("%d grouped alerts", len(names))
+	}
+	if len(hosts) > 1 {
+		history.Hostname = fmt.Sprintf("%d hosts", len(hosts))
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "%d firing, %d resolved", len(firing), len(resolved))
+	if len(notification.labels) > 0 {
+		fmt.Fprintf(&message, " in group %s", strings.Join(notification.labels, ", "))
+	}
+	writeMembers := func(title string, members []groupMember) {
+		if len(members) == 0 {
+			return
+		}
+		fmt.Fprintf(&message, "\n%s:", title)
+		for _, member := range members {
+			fmt.Fprintf(&message, "\n- %s on %s: %s", member.alert.Name, member.history.Hostname, member.history.Message)
+			if member.history.Resolved {
+				fmt.Fprintf(&message, " (after %s, peak %.2f%s)", formatIncidentDuration(&member.history),
+					member.history.PeakValue, metricUnit(member.alert.MetricType))
+			}
+		}
+	}
+	writeMembers("Firing", firing)
+	writeMembers("Resolved", resolved)
+	history.Message = message.String()
+
+	return &alert, &history
+}
+
+// sortGroupMembers orders group members by descending severity, then alert name and hostname
+func sortGroupMembers(members []groupMember) []groupMember {
+	sorted := append([]groupMember(nil), members...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if rankA, rankB := severityRank(a.alert.Severity), severityRank(b.alert.Severity); rankA != rankB {
+			return rankA > rankB
+		}
+		if a.alert.Name != b.alert.Name {
+			return a.alert.Name < b.alert.Name
+		}
+		return a.history.Hostname < b.history.Hostname
+	})
+	return sorted
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func TestNormalizeGroupBy(t *testing.T) {
+	keys, err := normalizeGroupBy([]string{" AlertName", "group", "alertname", "Severity"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{MatchAlertName, "group", MatchSeverity}, keys)
+
+	_, err = normalizeGroupBy([]string{"alertname", " "})
+	assert.Error(t, err)
+}
+
+func TestAlertService_NotificationGrouping(t *testing.T) {
+	route := &models.NotificationRoute{Enabled: true, ChannelIDs: []uint{2},
+		GroupBy: []string{MatchAlertName, "group"}, GroupWait: 30, GroupInterval: 300}
+	as, sender := newRoutedAlertService(route)
+
+	alert := &models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "Packet loss", Severity: "warning",
+		Labels: map[string]string{"group": "web"}}
+	incident := func(id uint, hostname string) *models.AlertHistory {
+		return &models.AlertHistory{BaseModel: models.BaseModel{ID: id}, Hostname: hostname, Message: "loss on " + hostname}
+	}
+
+	start := time.Now()
+	as.sendNotifications(alert, incident(1, "web-1"))
+	as.sendNotifications(alert, incident(2, "web-2"))
+	as.sendNotifications(alert, incident(3, "web-3"))
+
+	// Nothing is sent before the group wait
+	as.flushNotificationGroups(start)
+	assert.Empty(t, sender.destinations())
+
+	// One summary covers every member
+	as.flushNotificationGroups(start.Add(31 * time.Second))
+	require.Equal(t, []string{"slack https://hooks.slack.com/ops"}, sender.destinations())
+	assert.Contains(t, sender.messages[0], "3 firing, 0 resolved in group alertname=Packet loss, group=web")
+	assert.Contains(t, sender.messages[0], "Packet loss on web-3: loss on web-3")
+
+	// Repeats of known members do not change the group
+	as.sendNotifications(alert, incident(2, "web-2"))
+	as.flushNotificationGroups(start.Add(10 * time.Minute))
+	assert.Len(t, sender.destinations(), 1)
+
+	// A new member is sent with the next interval
+	as.sendNotifications(alert, incident(4, "web-4"))
+	as.flushNotificationGroups(start.Add(11 * time.Minute))
+	assert.Len(t, sender.destinations(), 1)
+	as.flushNotificationGroups(start.Add(16 * time.Minute))
+	require.Len(t, sender.destinations(), 2)
+	assert.Contains(t, sender.messages[1], "4 firing, 0 resolved")
+
+	// Resolutions leave the group; the last one resolves the summary and drops the group
+	for id, hostname := range map[uint]string{1: "web-1", 2: "web-2", 3: "web-3", 4: "web-4"} {
+		resolved := incident(id, hostname)
+		resolved.Resolved = true
+		as.sendNotifications(alert, resolved)
+	}
+	as.flushNotificationGroups(start.Add(22 * time.Minute))
+	require.Len(t, sender.destinations(), 3)
+	assert.Contains(t, sender.messages[2], "0 firing, 4 resolved")
+	assert.Empty(t, as.groups)
+
+	// Other group key values get their own group
+	db := *alert
+	db.Labels = map[string]string{"group": "db"}
+	as.sendNotifications(alert, incident(5, "web-1"))
+	as.sendNotifications(&db, incident(6, "db-1"))
+	assert.Len(t, as.groups, 2)
+}
+
+func TestAlertService_FallbackGroupingSendsSingleMemberAsIs(t *testing.T) {
+	as, sender := newRoutedAlertService()
+	as.config.GroupBy = []string{MatchAlertName}
+
+	alert := &models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "cpu", EmailEnabled: true, EmailRecipients: "inline@example.com"}
+	history := &models.AlertHistory{BaseModel: models.BaseModel{ID: 7}, Hostname: "web-1", Message: "cpu is high"}
+	as.sendNotifications(alert, history)
+	assert.Empty(t, sender.destinations())
+
+	as.flushNotificationGroups(time.Now().Add(as.config.GroupWait))
+	assert.Equal(t, []string{"inline@example.com"}, sender.destinations())
+	assert.Equal(t, []string{"cpu is high"}, sender.messages)
+
+	// Incidents resolved before the group was notified are dropped quietly
+	as.sendNotifications(alert, &models.AlertHistory{BaseModel: models.BaseModel{ID: 8}, Hostname: "web-2"})
+	as.sendNotifications(alert, &models.AlertHistory{BaseModel: models.BaseModel{ID: 8}, Hostname: "web-2", Resolved: true})
+	as.flushNotificationGroups(time.Now().Add(time.Hour))
+	assert.Len(t, sender.destinations(), 1)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// ValidateHostScope validates the host selector and threshold overrides of an alert and
+// trims their entries
+func ValidateHostScope(alert *models.Alert) error {
+	selector := &alert.HostSelector
+	for _, patterns := range [][]string{selector.Include, selector.Exclude} {
+		for i := range patterns {
+			patterns[i] = strings.TrimSpace(patterns[i])
+			if err := validateHostPattern(patterns[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if err := validateMatchers(selector.Labels); err != nil {
+		return fmt.Errorf("host label %w", err)
+	}
+
+	for i := range alert.HostOverrides {
+		override := &alert.HostOverrides[i]
+		override.Host = strings.TrimSpace(override.Host)
+		if err := validateHostPattern(override.Host); err != nil {
+			return fmt.Errorf("override %d: %w", i+1, err)
+		}
+
+		if len(alert.Levels) == 0 {
+			if len(override.Levels) > 0 {
+				return fmt.Errorf("override %d: levels require an alert with levels", i+1)
+			}
+			continue
+		}
+		if len(override.Levels) == 0 {
+			return fmt.Errorf("override %d: alerts with levels need override levels", i+1)
+		}
+		if err := ValidateSeverityLevels(alert.Condition, override.Levels); err != nil {
+			return fmt.Errorf("override %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validateHostPattern checks a hostname or glob
+func validateHostPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("host patterns cannot be empty")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid host pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// hostPatternsMatch reports whether a hostname matches any of the hostnames or globs
+func hostPatternsMatch(patterns []string, hostname string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, hostname); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// labelsMatch reports whether all matchers match a set of labels
+func labelsMatch(matchers []models.AlertMatcher, labels map[string]string) bool {
+	for _, matcher := range matchers {
+		if !matcherMatches(matcher, labels[matcher.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetHostRepository sets the repository host metadata is loaded from
+func (as *AlertService) SetHostRepository(hostRepo repository.HostRepository) {
+	as.mutex.Lock()
+	as.hostRepo = hostRepo
+	as.mutex.Unlock()
+
+	as.ReloadHostMetadata()
+}
+
+// ReloadHostMetadata refreshes the cached host labels and descriptions; call it after host
+// metadata changes
+func (as *AlertService) ReloadHostMetadata() {
+	as.mutex.RLock()
+	hostRepo := as.hostRepo
+	as.mutex.RUnlock()
+	if hostRepo == nil {
+		return
+	}
+
+	metadata, err := hostRepo.GetAllHostMetadata()
+	if err != nil {
+		log.Printf("❌ Failed to load host metadata: %v", err)
+		return
+	}
+
+	labels := make(map[string]map[string]string, len(metadata))
+	descriptions := make(map[string]string, len(metadata))
+	for _, host := range metadata {
+		labels[host.Hostname] = host.Labels
+		descriptions[host.Hostname] = host.Description
+	}
+
+	as.mutex.Lock()
+	as.hostLabels = labels
+	as.hostDescriptions = descriptions
+	as.mutex.Unlock()
+}
+
+// alertAppliesToHost reports whether the host selector of an alert selects a host
+func (as *AlertService) alertAppliesToHost(alert *models.Alert, hostname string) bool {
+	selector := alert.HostSelector
+	if len(selector.Include) > 0 && !hostPatternsMatch(selector.Include, hostname) {
+		return false
+	}
+	if hostPatternsMatch(selector.Exclude, hostname) {
+		return false
+	}
+	if len(selector.Labels) == 0 {
+		return true
+	}
+
+	as.mutex.RLock()
+	labels := as.hostLabels[hostname]
+	as.mutex.RUnlock()
+	return labelsMatch(selector.Labels, labels)
+}
+
+// scopeAlertToHost applies the host selector and threshold overrides of an alert to a host.
+// It returns nil when the alert does not apply to the host, after resolving what the alert
+// still had open there, e.g. once a host is excluded.
+func (as *AlertService) scopeAlertToHost(alert *models.Alert, hostname string) *models.Alert {
+	if !as.alertAppliesToHost(alert, hostname) {
+		as.mutex.RLock()
+		instance, exists := as.instances[alertInstanceKey(alert.ID, hostname)]
+		var lastValue float64
+		active := exists && (instance.State == AlertStateFiring || instance.State == AlertStatePending)
+		if active {
+			lastValue = instance.LastValue
+		}
+		as.mutex.RUnlock()
+
+		if active {
+			as.transitionAlert(alert, hostname, false, lastValue, "", nil)
+		}
+		return nil
+	}
+	return applyHostOverride(alert, hostname)
+}
+
+// applyHostOverride returns a copy of an alert with the thresholds of the first override
+// matching a host, or the alert itself when none does
+func applyHostOverride(alert *models.Alert, hostname string) *models.Alert {
+	for _, override := range alert.HostOverrides {
+		if !hostPatternsMatch([]string{override.Host}, hostname) {
+			continue
+		}
+
+		scoped := *alert
+		scoped.Threshold = override.Threshold
+		if len(override.Levels) > 0 {
+			scoped.Levels = override.Levels
+			scoped.Threshold = override.Levels[0].Threshold
+			scoped.Severity = override.Levels[0].Severity
+		}
+		return &scoped
+	}
+	return alert
+}
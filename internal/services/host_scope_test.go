@@ -0,0 +1,88 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func TestValidateHostScope(t *testing.T) {
+	alert := &models.Alert{
+		Condition:     ">",
+		HostSelector:  models.HostSelector{Include: []string{" db-* "}, Labels: []models.AlertMatcher{{Name: " env ", Value: "prod"}}},
+		HostOverrides: []models.HostOverride{{Host: "db-2 ", Threshold: 95}},
+	}
+	require.NoError(t, ValidateHostScope(alert))
+	assert.Equal(t, "db-*", alert.HostSelector.Include[0])
+	assert.Equal(t, "env", alert.HostSelector.Labels[0].Name)
+	assert.Equal(t, "db-2", alert.HostOverrides[0].Host)
+
+	tests := []struct {
+		name  string
+		alert models.Alert
+	}{
+		{"bad glob", models.Alert{HostSelector: models.HostSelector{Exclude: []string{"db-["}}}},
+		{"empty pattern", models.Alert{HostSelector: models.HostSelector{Include: []string{" "}}}},
+		{"bad label regex", models.Alert{HostSelector: models.HostSelector{Labels: []models.AlertMatcher{{Name: "env", Value: "(", IsRegex: true}}}}},
+		{"override without host", models.Alert{HostOverrides: []models.HostOverride{{Threshold: 90}}}},
+		{"override levels on single level alert", models.Alert{Condition: ">", HostOverrides: []models.HostOverride{{Host: "db-1", Levels: []models.SeverityLevel{{Severity: "warning", Threshold: 90}}}}}},
+		{"override without levels on multi-level alert", models.Alert{Condition: ">", Levels: []models.SeverityLevel{{Severity: "warning", Threshold: 80}},
+			HostOverrides: []models.HostOverride{{Host: "db-1", Threshold: 90}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, ValidateHostScope(&tt.alert))
+		})
+	}
+}
+
+func TestAlertService_HostScope(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	alert.HostSelector = models.HostSelector{
+		Include: []string{"db-*", "web-1"},
+		Exclude: []string{"db-3"},
+		Labels:  []models.AlertMatcher{{Name: "env", Value: "prod"}},
+	}
+	alert.HostOverrides = []models.HostOverride{{Host: "db-2", Threshold: 95}}
+	as := NewAlertService(nil, newFakeAlertRepo(alert), nil, nil)
+	as.hostLabels = map[string]map[string]string{
+		"db-1":  {"env": "prod"},
+		"db-2":  {"env": "prod"},
+		"db-3":  {"env": "prod"},
+		"db-4":  {"env": "staging"},
+		"web-1": {"env": "prod"},
+		"web-2": {"env": "prod"},
+	}
+
+	state := func(hostname string) string {
+		if instance, exists := as.instances[alertInstanceKey(alert.ID, hostname)]; exists {
+			return instance.State
+		}
+		return ""
+	}
+	check := func(hostname string, cpu float64) {
+		require.NoError(t, as.checkSingleAlert(alert, &models.SystemMetrics{Hostname: hostname, CPU: models.CPUMetrics{Usage: cpu}}))
+	}
+
+	for _, hostname := range []string{"db-1", "db-2", "db-3", "db-4", "web-1", "web-2"} {
+		check(hostname, 90)
+	}
+	assert.Equal(t, AlertStateFiring, state("db-1"))
+	assert.Equal(t, "", state("db-2"), "the override raises the threshold")
+	assert.Equal(t, "", state("db-3"), "excluded")
+	assert.Equal(t, "", state("db-4"), "label mismatch")
+	assert.Equal(t, AlertStateFiring, state("web-1"))
+	assert.Equal(t, "", state("web-2"), "not included")
+
+	check("db-2", 96)
+	assert.Equal(t, AlertStateFiring, state("db-2"))
+
+	// Excluding a host resolves what the alert had open there
+	alert.HostSelector.Exclude = append(alert.HostSelector.Exclude, "web-*")
+	check("web-1", 90)
+	assert.Equal(t, AlertStateResolved, state("web-1"))
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// Incident timeline event types
+const (
+	IncidentEventAck      = "ack"
+	IncidentEventUnack    = "unack"
+	IncidentEventAssign   = "assign"
+	IncidentEventComment  = "comment"
+	IncidentEventEscalate = "escalate"
+	IncidentEventResolve  = "resolve"
+	IncidentEventFlapping = "flapping"
+	IncidentEventSeverity = "severity"
+)
+
+// SystemUser is recorded as the user of timeline entries GoDash creates itself
+const SystemUser = "system"
+
+// AcknowledgeIncident acknowledges a firing incident, which stops its repeat notifications
+// without resolving it
+func (as *AlertService) AcknowledgeIncident(historyID uint, user, comment string) (*models.AlertHistory, error) {
+	history, err := as.openIncidentForUpdate(historyID)
+	if err != nil {
+		return nil, err
+	}
+	if history.Acknowledged {
+		return nil, fmt.Errorf("incident is already acknowledged")
+	}
+
+	history.Acknowledged = true
+	history.AcknowledgedBy = userOrSystem(user)
+	history.AcknowledgedAt = time.Now()
+	if err := as.alertRepo.UpdateIncidentWorkflow(history); err != nil {
+		return nil, err
+	}
+
+	as.setIncidentAcknowledged(history, true)
+	as.recordIncidentEvent(history.ID, IncidentEventAck, user, comment)
+	log.Printf("👍 Incident %d acknowledged by %s", history.ID, userOrSystem(user))
+	return history, nil
+}
+
+// UnacknowledgeIncident withdraws the acknowledgement of an incident, so repeat
+// notifications resume
+func (as *AlertService) UnacknowledgeIncident(historyID uint, user, comment string) (*models.AlertHistory, error) {
+	history, err := as.openIncidentForUpdate(historyID)
+	if err != nil {
+		return nil, err
+	}
+	if !history.Acknowledged {
+		return nil, fmt.Errorf("incident is not acknowledged")
+	}
+
+	history.Acknowledged = false
+	history.AcknowledgedBy = ""
+	history.AcknowledgedAt = time.Time{}
+	if err := as.alertRepo.UpdateIncidentWorkflow(history); err != nil {
+		return nil, err
+	}
+
+	as.setIncidentAcknowledged(history, false)
+	as.recordIncidentEvent(history.ID, IncidentEventUnack, user, comment)
+	return history, nil
+}
+
+// AssignIncident sets the assignee of an incident; an empty assignee unassigns it
+func (as *AlertService) AssignIncident(historyID uint, user, assignee, comment string) (*models.AlertHistory, error) {
+	history, err := as.alertRepo.GetIncident(historyID)
+	if err != nil {
+		return nil, err
+	}
+
+	history.Assignee = strings.TrimSpace(assignee)
+	if err := as.alertRepo.UpdateIncidentWorkflow(history); err != nil {
+		return nil, err
+	}
+
+	if comment == "" {
+		comment = "unassigned"
+		if history.Assignee != "" {
+			comment = "assigned to " + history.Assignee
+		}
+	}
+	as.recordIncidentEvent(history.ID, IncidentEventAssign, user, comment)
+	return history, nil
+}
+
+// CommentIncident adds a comment to the timeline of an incident
+func (as *AlertService) CommentIncident(historyID uint, user, comment string) (*models.IncidentEvent, error) {
+	if strings.TrimSpace(comment) == "" {
+		return nil, fmt.Errorf("comment is required")
+	}
+	if _, err := as.alertRepo.GetIncident(historyID); err != nil {
+		return nil, err
+	}
+
+	event := &models.IncidentEvent{HistoryID: historyID, Type: IncidentEventComment, User: userOrSystem(user), Comment: comment}
+	if err := as.alertRepo.CreateIncidentEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ResolveIncidentManually resolves an incident by hand. Its alert instance is resolved too,
+// so the alert opens a new incident if the condition still holds on the next evaluation.
+func (as *AlertService) ResolveIncidentManually(historyID uint, user, comment string) (*models.AlertHistory, error) {
+	history, err := as.openIncidentForUpdate(historyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.alertRepo.ResolveAlert(history.ID); err != nil {
+		return nil, err
+	}
+	history.Resolved = true
+	history.ResolvedAt = time.Now()
+	as.recordIncidentEvent(history.ID, IncidentEventResolve, user, comment)
+	log.Printf("✅ Incident %d resolved by %s", history.ID, userOrSystem(user))
+
+	key := alertInstanceKey(history.AlertID, history.Hostname)
+	as.mutex.Lock()
+	instance, exists := as.instances[key]
+	if !exists || instance.HistoryID != history.ID || instance.State != AlertStateFiring {
+		as.mutex.Unlock()
+		return history, nil
+	}
+	instance.State = AlertStateResolved
+	instance.Since = history.ResolvedAt
+	snapshot := *instance
+	as.mutex.Unlock()
+
+	as.saveAlertInstance(&snapshot)
+
+	// Record the peak reached since the last notification updated the incident
+	history.PeakValue = snapshot.PeakValue
+	if err := as.alertRepo.UpdateAlertHistory(history); err != nil {
+		log.Printf("❌ Failed to update alert history: %v", err)
+	}
+
+	alert, err := as.alertRepo.GetAlertByID(history.AlertID)
+	if err != nil {
+		log.Printf("❌ Failed to load alert %d: %v", history.AlertID, err)
+		return history, nil
+	}
+	as.emitAlertEvent(&AlertEvent{Type: AlertEventResolved, Alert: alertAtSeverity(alert, history.Severity), Instance: snapshot, History: history})
+	return history, nil
+}
+
+// openIncidentForUpdate loads an incident that has not been resolved yet
+func (as *AlertService) openIncidentForUpdate(historyID uint) (*models.AlertHistory, error) {
+	history, err := as.alertRepo.GetIncident(historyID)
+	if err != nil {
+		return nil, err
+	}
+	if history.Resolved {
+		return nil, fmt.Errorf("incident is already resolved")
+	}
+	return history, nil
+}
+
+// setIncidentAcknowledged mirrors the acknowledgement of an incident on its firing alert instance
+func (as *AlertService) setIncidentAcknowledged(history *models.AlertHistory, acknowledged bool) {
+	key := alertInstanceKey(history.AlertID, history.Hostname)
+
+	as.mutex.Lock()
+	instance, exists := as.instances[key]
+	if !exists || instance.HistoryID != history.ID {
+		as.mutex.Unlock()
+		return
+	}
+	instance.Acknowledged = acknowledged
+	if !acknowledged {
+		// Notify again after a full cooldown rather than immediately
+		instance.LastNotifiedAt = time.Now()
+	}
+	snapshot := *instance
+	as.mutex.Unlock()
+
+	as.saveAlertInstance(&snapshot)
+}
+
+// recordIncidentEvent adds an entry to an incident timeline, logging failures
+func (as *AlertService) recordIncidentEvent(historyID uint, eventType, user, comment string) {
+	event := &models.IncidentEvent{HistoryID: historyID, Type: eventType, User: userOrSystem(user), Comment: comment}
+	if err := as.alertRepo.CreateIncidentEvent(event); err != nil {
+		log.Printf("❌ Failed to record incident event: %v", err)
+	}
+}
+
+// userOrSystem returns user, or SystemUser when no user is given
+func userOrSystem(user string) string {
+	if user = strings.TrimSpace(user); user != "" {
+		return user
+	}
+	return SystemUser
+}
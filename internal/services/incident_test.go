@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertService_AcknowledgeIncident(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+	recorder := &eventRecorder{}
+	as.SetWebSocketHandler(recorder)
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	historyID := as.instances[key].HistoryID
+	require.NotZero(t, historyID)
+
+	incident, err := as.AcknowledgeIncident(historyID, "alice", "looking into it")
+	require.NoError(t, err)
+	assert.True(t, incident.Acknowledged)
+	assert.Equal(t, "alice", incident.AcknowledgedBy)
+	assert.True(t, as.instances[key].Acknowledged)
+
+	_, err = as.AcknowledgeIncident(historyID, "bob", "")
+	assert.EqualError(t, err, "incident is already acknowledged")
+
+	// Acknowledged incidents keep firing without repeat notifications
+	as.instances[key].LastNotifiedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 95, "", nil))
+	assert.Equal(t, AlertStateFiring, as.instances[key].State)
+	assert.Len(t, recorder.events, 1)
+	assert.False(t, repo.historySnapshot()[0].Resolved)
+
+	// Unacknowledging resumes them after the cooldown
+	_, err = as.UnacknowledgeIncident(historyID, "alice", "")
+	require.NoError(t, err)
+	as.instances[key].LastNotifiedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 96, "", nil))
+	assert.Len(t, recorder.events, 2)
+
+	_, err = as.AssignIncident(historyID, "alice", "bob", "")
+	require.NoError(t, err)
+	_, err = as.CommentIncident(historyID, "bob", "disk cleanup running")
+	require.NoError(t, err)
+	_, err = as.CommentIncident(historyID, "bob", " ")
+	assert.Error(t, err)
+
+	// Manual resolution resolves the instance too
+	_, err = as.ResolveIncidentManually(historyID, "bob", "fixed")
+	require.NoError(t, err)
+	assert.Equal(t, AlertStateResolved, as.instances[key].State)
+	assert.True(t, repo.historySnapshot()[0].Resolved)
+	assert.Len(t, recorder.events, 3)
+
+	_, err = as.AcknowledgeIncident(historyID, "alice", "")
+	assert.EqualError(t, err, "incident is already resolved")
+
+	timeline, err := repo.GetIncidentEvents(historyID)
+	require.NoError(t, err)
+	var types []string
+	for _, event := range timeline {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{IncidentEventAck, IncidentEventUnack, IncidentEventAssign, IncidentEventComment, IncidentEventResolve}, types)
+	assert.Equal(t, "assigned to bob", timeline[2].Comment)
+	assert.Equal(t, "bob", repo.historySnapshot()[0].Assignee)
+
+	// A new incident starts unacknowledged
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 97, "", nil))
+	assert.False(t, as.instances[key].Acknowledged)
+	assert.NotEqual(t, historyID, as.instances[key].HistoryID)
+}
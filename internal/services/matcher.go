@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// Alert matcher names; any other name matches the alert label of that name
+const (
+	MatchAlertName = "alertname"
+	MatchSeverity  = "severity"
+	MatchHostname  = "hostname"
+)
+
+// validateMatchers validates alert matchers and trims their names
+func validateMatchers(matchers []models.AlertMatcher) error {
+	for i := range matchers {
+		matcher := &matchers[i]
+		matcher.Name = strings.TrimSpace(matcher.Name)
+		if matcher.Name == "" {
+			return fmt.Errorf("matcher %d has no name", i+1)
+		}
+		if matcher.IsRegex {
+			if _, err := compileMatcherRegex(matcher.Value); err != nil {
+				return fmt.Errorf("matcher %s has an invalid regex: %w", matcher.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// compileMatcherRegex compiles a matcher regex anchored to the whole value
+func compileMatcherRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// MatchersMatch reports whether all matchers match an alert on a host
+func MatchersMatch(matchers []models.AlertMatcher, alert *models.Alert, hostname string) bool {
+	for _, matcher := range matchers {
+		if !matcherMatches(matcher, alertFieldValue(matcher.Name, alert, hostname)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matcherMatches reports whether a matcher matches a value
+func matcherMatches(matcher models.AlertMatcher, value string) bool {
+	if matcher.IsRegex {
+		re, err := compileMatcherRegex(matcher.Value)
+		return err == nil && re.MatchString(value)
+	}
+	return value == matcher.Value
+}
+
+// alertFieldValue returns the value of a matcher or group key for an alert on a host:
+// alertname, severity, hostname or an alert label
+func alertFieldValue(name string, alert *models.Alert, hostname string) string {
+	switch strings.ToLower(name) {
+	case MatchAlertName:
+		return alert.Name
+	case MatchSeverity:
+		return alert.Severity
+	case MatchHostname:
+		return hostname
+	default:
+		return alert.Labels[name]
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// bytesPerGB converts byte counters to gigabytes for expression fields
+const bytesPerGB = 1024 * 1024 * 1024
+
+// metricFields maps field names usable in alert expressions to their value in a metrics sample
+var metricFields = map[string]func(m *models.SystemMetrics) float64{
+	// CPU
+	"cpu":             func(m *models.SystemMetrics) float64 { return m.CPU.Usage },
+	"cores":           func(m *models.SystemMetrics) float64 { return float64(m.CPU.Cores) },
+	"cpu_frequency":   func(m *models.SystemMetrics) float64 { return m.CPU.Frequency },
+	"cpu_temperature": func(m *models.SystemMetrics) float64 { return m.CPU.Temperature },
+	"load_avg_1":      func(m *models.SystemMetrics) float64 { return loadAverage(m, 0) },
+	"load_avg_5":      func(m *models.SystemMetrics) float64 { return loadAverage(m, 1) },
+	"load_avg_15":     func(m *models.SystemMetrics) float64 { return loadAverage(m, 2) },
+
+	// Memory (sizes in GB)
+	"memory":           func(m *models.SystemMetrics) float64 { return m.Memory.Percent },
+	"memory_total":     func(m *models.SystemMetrics) float64 { return float64(m.Memory.Total) / bytesPerGB },
+	"memory_used":      func(m *models.SystemMetrics) float64 { return float64(m.Memory.Used) / bytesPerGB },
+	"memory_available": func(m *models.SystemMetrics) float64 { return float64(m.Memory.Available) / bytesPerGB },
+	"swap_percent":     func(m *models.SystemMetrics) float64 { return m.Memory.SwapPercent },
+	"swap_used":        func(m *models.SystemMetrics) float64 { return float64(m.Memory.SwapUsed) / bytesPerGB },
+
+	// Disk (sizes in GB, speeds in MB/s)
+	"disk":             func(m *models.SystemMetrics) float64 { return m.Disk.Percent },
+	"disk_total":       func(m *models.SystemMetrics) float64 { return float64(m.Disk.Total) / bytesPerGB },
+	"disk_used":        func(m *models.SystemMetrics) float64 { return float64(m.Disk.Used) / bytesPerGB },
+	"disk_free":        func(m *models.SystemMetrics) float64 { return float64(m.Disk.Free) / bytesPerGB },
+	"disk_read_speed":  func(m *models.SystemMetrics) float64 { return m.Disk.ReadSpeed },
+	"disk_write_speed": func(m *models.SystemMetrics) float64 { return m.Disk.WriteSpeed },
+
+	// Network (speeds in Mbps, counters summed over interfaces)
+	"network_upload_speed":   func(m *models.SystemMetrics) float64 { return m.Network.UploadSpeed },
+	"network_download_speed": func(m *models.SystemMetrics) float64 { return m.Network.DownloadSpeed },
+	"network_sent":           func(m *models.SystemMetrics) float64 { return float64(m.Network.TotalSent) },
+	"network_received":       func(m *models.SystemMetrics) float64 { return float64(m.Network.TotalReceived) },
+	"network_errors": func(m *models.SystemMetrics) float64 {
+		var total uint64
+		for _, iface := range m.Network.Interfaces {
+			total += iface.Errors
+		}
+		return float64(total)
+	},
+	"network_drops": func(m *models.SystemMetrics) float64 {
+		var total uint64
+		for _, iface := range m.Network.Interfaces {
+			total += iface.Drops
+		}
+		return float64(total)
+	},
+
+	// Processes
+	"processes_total":   func(m *models.SystemMetrics) float64 { return float64(m.Processes.TotalProcesses) },
+	"processes_running": func(m *models.SystemMetrics) float64 { return float64(m.Processes.RunningProcesses) },
+	"processes_stopped": func(m *models.SystemMetrics) float64 { return float64(m.Processes.StoppedProcesses) },
+	"processes_zombie":  func(m *models.SystemMetrics) float64 { return float64(m.Processes.ZombieProcesses) },
+
+	// Kernel limits
+	"file_handles": func(m *models.SystemMetrics) float64 { return m.Limits.FileHandlesPercent },
+	"pid_usage":    func(m *models.SystemMetrics) float64 { return m.Limits.PIDPercent },
+	"threads":      func(m *models.SystemMetrics) float64 { return float64(m.Limits.Threads) },
+	"conntrack":    func(m *models.SystemMetrics) float64 { return m.Limits.ConntrackPercent },
+	"process_fds":  func(m *models.SystemMetrics) float64 { return m.Limits.MaxProcessFDPercent },
+
+	// Host
+	"uptime": func(m *models.SystemMetrics) float64 { return m.Uptime.Seconds() },
+}
+
+// MetricFieldNames returns the sorted names of all fields usable in alert expressions
+func MetricFieldNames() []string {
+	names := make([]string, 0, len(metricFields))
+	for name := range metricFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsMetricField reports whether name is a known metric field
+func IsMetricField(name string) bool {
+	_, exists := metricFields[strings.ToLower(name)]
+	return exists
+}
+
+// MetricFieldValue returns the value of a named field in a metrics sample
+func MetricFieldValue(metrics *models.SystemMetrics, name string) (float64, bool) {
+	getter, exists := metricFields[strings.ToLower(name)]
+	if !exists || metrics == nil {
+		return 0, false
+	}
+	return getter(metrics), true
+}
+
+// loadAverage returns the load average at index i, or 0 if it wasn't collected
+func loadAverage(m *models.SystemMetrics, i int) float64 {
+	if len(m.CPU.LoadAvg) > i {
+		return m.CPU.LoadAvg[i]
+	}
+	return 0
+}
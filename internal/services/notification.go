@@ -0,0 +1,358 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// Notification channel types
+const (
+	ChannelTypeEmail   = "email"
+	ChannelTypeWebhook = "webhook"
+	ChannelTypeSlack   = "slack"
+	ChannelTypeDiscord = "discord"
+)
+
+// NotificationChannelTypes returns the supported notification channel types
+func NotificationChannelTypes() []string {
+	return []string{ChannelTypeEmail, ChannelTypeWebhook, ChannelTypeSlack, ChannelTypeDiscord}
+}
+
+// ValidateNotificationChannel validates a notification channel and normalizes its settings
+func ValidateNotificationChannel(channel *models.NotificationChannel) error {
+	channel.Name = strings.TrimSpace(channel.Name)
+	if channel.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	channel.Type = strings.ToLower(strings.TrimSpace(channel.Type))
+	switch channel.Type {
+	case ChannelTypeEmail:
+		channel.Config.Recipients = strings.TrimSpace(channel.Config.Recipients)
+		if channel.Config.Recipients == "" {
+			return fmt.Errorf("email channels need recipients")
+		}
+		if _, err := mail.ParseAddressList(channel.Config.Recipients); err != nil {
+			return fmt.Errorf("invalid recipients: %w", err)
+		}
+
+	case ChannelTypeWebhook, ChannelTypeSlack, ChannelTypeDiscord:
+		channel.Config.URL = strings.TrimSpace(channel.Config.URL)
+		parsed, err := url.Parse(channel.Config.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%s channels need an http or https URL", channel.Type)
+		}
+		if channel.Type == ChannelTypeWebhook {
+			return validateWebhookSettings(&channel.Config)
+		}
+		if channel.Config.Method != "" || len(channel.Config.Headers) > 0 || channel.Config.Body != "" ||
+			channel.Config.Auth != nil || channel.Config.Secret != "" {
+			return fmt.Errorf("method, headers, body, auth and secret only apply to webhook channels")
+		}
+
+	default:
+		return fmt.Errorf("type must be one of: %s", strings.Join(NotificationChannelTypes(), ", "))
+	}
+
+	return nil
+}
+
+// validateWebhookSettings validates and normalizes the request settings of a webhook channel
+func validateWebhookSettings(settings *models.NotificationChannelConfig) error {
+	settings.Method = strings.ToUpper(strings.TrimSpace(settings.Method))
+	switch settings.Method {
+	case "", http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return fmt.Errorf("method must be POST, PUT or PATCH")
+	}
+
+	for name, value := range settings.Headers {
+		if !validHeaderName(name) {
+			return fmt.Errorf("invalid header name: %q", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %s: value must be a single line", name)
+		}
+	}
+
+	if settings.Body != "" {
+		templates := models.MessageTemplates{Body: settings.Body}
+		if err := ValidateMessageTemplates(ChannelTypeWebhook, &templates); err != nil {
+			return fmt.Errorf("body: %w", err)
+		}
+		settings.Body = templates.Body
+	}
+
+	if auth := settings.Auth; auth != nil {
+		auth.Type = strings.ToLower(strings.TrimSpace(auth.Type))
+		switch auth.Type {
+		case WebhookAuthBasic:
+			if auth.Username == "" {
+				return fmt.Errorf("basic auth needs a username")
+			}
+			auth.Token = ""
+		case WebhookAuthBearer:
+			if auth.Token == "" {
+				return fmt.Errorf("bearer auth needs a token")
+			}
+			auth.Username, auth.Password = "", ""
+		default:
+			return fmt.Errorf("auth type must be %s or %s", WebhookAuthBasic, WebhookAuthBearer)
+		}
+	}
+
+	return nil
+}
+
+// validHeaderName reports whether name is a valid HTTP header field name
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > '~' || r <= ' ' || strings.ContainsRune("\"(),/:;<=>?@[\\]{}", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateNotificationRoute validates a notification route
+func ValidateNotificationRoute(route *models.NotificationRoute) error {
+	route.Name = strings.TrimSpace(route.Name)
+	if len(route.ChannelIDs) == 0 {
+		return fmt.Errorf("at least one channel is required")
+	}
+	if route.GroupWait < 0 || route.GroupInterval < 0 {
+		return fmt.Errorf("group wait and interval cannot be negative")
+	}
+
+	groupBy, err := normalizeGroupBy(route.GroupBy)
+	if err != nil {
+		return err
+	}
+	route.GroupBy = groupBy
+
+	return validateMatchers(route.Matchers)
+}
+
+// SetNotificationRepository sets the repository notification channels, routes and templates
+// are loaded from
+func (as *AlertService) SetNotificationRepository(notificationRepo repository.NotificationRepository) {
+	as.mutex.Lock()
+	as.notificationRepo = notificationRepo
+	as.mutex.Unlock()
+
+	as.ReloadNotificationRouting()
+}
+
+// ReloadNotificationRouting refreshes the cached channels, routes and templates; call it after
+// they change
+func (as *AlertService) ReloadNotificationRouting() {
+	as.mutex.RLock()
+	notificationRepo := as.notificationRepo
+	as.mutex.RUnlock()
+	if notificationRepo == nil {
+		return
+	}
+
+	channels, err := notificationRepo.GetChannels()
+	if err != nil {
+		log.Printf("❌ Failed to load notification channels: %v", err)
+		return
+	}
+	routes, err := notificationRepo.GetRoutes()
+	if err != nil {
+		log.Printf("❌ Failed to load notification routes: %v", err)
+		return
+	}
+	templates, err := notificationRepo.GetTemplates()
+	if err != nil {
+		log.Printf("❌ Failed to load notification templates: %v", err)
+		return
+	}
+
+	byID := make(map[uint]*models.NotificationChannel, len(channels))
+	for _, channel := range channels {
+		byID[channel.ID] = channel
+	}
+	byType := make(map[string]models.MessageTemplates, len(templates))
+	for _, template := range templates {
+		byType[template.ChannelType] = template.MessageTemplates
+	}
+
+	as.mutex.Lock()
+	as.channels = byID
+	as.routes = routes
+	as.templates = byType
+	as.mutex.Unlock()
+}
+
+// notificationChannel returns a cached notification channel by ID
+func (as *AlertService) notificationChannel(id uint) *models.NotificationChannel {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+	return as.channels[id]
+}
+
+// routedChannels holds a matching route and its enabled channels
+type routedChannels struct {
+	route    *models.NotificationRoute
+	channels []*models.NotificationChannel
+}
+
+// matchRoutes returns the routes matching an alert on a host with their enabled channels.
+// Channels already used by an earlier route are left out.
+func (as *AlertService) matchRoutes(alert *models.Alert, hostname string) []routedChannels {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	var matched []routedChannels
+	seen := make(map[uint]bool)
+	for _, route := range as.routes {
+		if !route.Enabled || !MatchersMatch(route.Matchers, alert, hostname) {
+			continue
+		}
+
+		var channels []*models.NotificationChannel
+		for _, id := range route.ChannelIDs {
+			channel, exists := as.channels[id]
+			if exists && channel.Enabled && !seen[id] {
+				seen[id] = true
+				channels = append(channels, channel)
+			}
+		}
+		if len(channels) > 0 {
+			matched = append(matched, routedChannels{route: route, channels: channels})
+		}
+
+		if !route.Continue {
+			break
+		}
+	}
+	return matched
+}
+
+// routeChannels returns the enabled channels of the routes matching an alert on a host,
+// each channel once
+func (as *AlertService) routeChannels(alert *models.Alert, hostname string) []*models.NotificationChannel {
+	var channels []*models.NotificationChannel
+	for _, matched := range as.matchRoutes(alert, hostname) {
+		channels = append(channels, matched.channels...)
+	}
+	return channels
+}
+
+// sendNotifications sends an alert to the channels of its matching routes. Alerts no route
+// matches fall back to their own email and webhook settings. Routes with group keys, and the
+// fallback when ALERTS_GROUP_BY is set, collect alerts into notification groups instead.
+func (as *AlertService) sendNotifications(alert *models.Alert, history *models.AlertHistory) {
+	routes := as.matchRoutes(alert, history.Hostname)
+	if len(routes) == 0 {
+		if len(as.config.GroupBy) > 0 {
+			as.addToNotificationGroup(nil, nil, alert, history)
+			return
+		}
+		if notifiesResolution(alert, history) {
+			as.sendAlertNotifications(alert, history)
+		}
+		return
+	}
+
+	for _, matched := range routes {
+		if len(matched.route.GroupBy) > 0 {
+			as.addToNotificationGroup(matched.route, matched.channels, alert, history)
+			continue
+		}
+		if !notifiesResolution(alert, history) {
+			continue
+		}
+		for _, channel := range matched.channels {
+			as.notifyChannel(channel, alert, history)
+		}
+	}
+}
+
+// notifiesResolution reports whether a notification is sent about an incident, which is
+// false for resolved incidents of alerts that opted out of resolution notifications
+func notifiesResolution(alert *models.Alert, history *models.AlertHistory) bool {
+	return !history.Resolved || !alert.SkipResolved
+}
+
+// incidentDuration returns how long a resolved incident was firing, or 0 while it still is
+func incidentDuration(history *models.AlertHistory) time.Duration {
+	if !history.Resolved || history.ResolvedAt.Before(history.CreatedAt) {
+		return 0
+	}
+	return history.ResolvedAt.Sub(history.CreatedAt)
+}
+
+// formatIncidentDuration formats the duration of a resolved incident to the second, e.g. 1h5m30s
+func formatIncidentDuration(history *models.AlertHistory) string {
+	return incidentDuration(history).Round(time.Second).String()
+}
+
+// notifyChannel sends an alert to a notification channel and records the delivery
+func (as *AlertService) notifyChannel(channel *models.NotificationChannel, alert *models.Alert, history *models.AlertHistory) {
+	if err := as.deliverToChannel(channel, alert, history); err != nil {
+		log.Printf("❌ Failed to notify channel %s: %v", channel.Name, err)
+		return
+	}
+	log.Printf("📨 Alert sent to channel %s", channel.Name)
+
+	sent := NotificationWebhook
+	if channel.Type == ChannelTypeEmail {
+		sent = NotificationEmail
+	}
+	if history.ID != 0 {
+		if err := as.alertRepo.MarkNotificationSent(history.ID, sent); err != nil {
+			log.Printf("❌ Failed to update %s sent status: %v", sent, err)
+		}
+	}
+}
+
+// deliverToChannel sends an alert to a notification channel
+func (as *AlertService) deliverToChannel(channel *models.NotificationChannel, alert *models.Alert, history *models.AlertHistory) error {
+	if channel.Type == ChannelTypeEmail {
+		if as.emailSender == nil {
+			return fmt.Errorf("email service is not configured")
+		}
+		channelAlert := *alert
+		channelAlert.EmailRecipients = channel.Config.Recipients
+		return as.emailSender.SendAlert(&channelAlert, history, as.RenderNotification(channel.Type, alert, history))
+	}
+
+	if as.webhookSender == nil {
+		return fmt.Errorf("webhook service is not configured")
+	}
+	return as.webhookSender.SendAlertTo(channel, alert, history, as.renderChannelNotification(channel, alert, history))
+}
+
+// SendTestNotification sends a sample alert to a notification channel
+func (as *AlertService) SendTestNotification(channel *models.NotificationChannel) error {
+	alert := &models.Alert{
+		Name:       "GoDash test notification",
+		MetricType: "cpu",
+		Condition:  ">",
+		Threshold:  80,
+		Severity:   "info",
+	}
+	history := &models.AlertHistory{
+		Hostname:    "test-host",
+		MetricValue: 85,
+		Threshold:   80,
+		Severity:    "info",
+		Message:     fmt.Sprintf("Test notification for channel %s", channel.Name),
+	}
+	history.CreatedAt = time.Now()
+
+	return as.deliverToChannel(channel, alert, history)
+}
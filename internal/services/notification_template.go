@@ -0,0 +1,309 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// NotificationRecentWindow is how far back the recent metric values of a notification reach
+const NotificationRecentWindow = 15 * time.Minute
+
+// NotificationMessage is a notification rendered from message templates. Empty parts are
+// rendered by the built-in layout of the sender.
+type NotificationMessage struct {
+	Subject string `json:"subject,omitempty"`
+	Text    string `json:"text,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	Body    string `json:"body,omitempty"` // Valid JSON
+}
+
+// NotificationHost describes the host of a notification
+type NotificationHost struct {
+	Hostname    string
+	Labels      map[string]string
+	Description string
+}
+
+// NotificationTemplateData is the data message templates are executed with
+type NotificationTemplateData struct {
+	Alert    *models.Alert
+	History  *models.AlertHistory
+	Host     NotificationHost
+	Status   string             // firing or resolved
+	Duration string             // How long a resolved incident was firing, e.g. 5m30s
+	Unit     string             // Unit of the alert metric, e.g. %
+	Metrics  map[string]float64 // Latest value of every metric field of the host (0 without recent samples)
+	Recent   []MetricSample     // Values of the alert metric over the last NotificationRecentWindow, oldest first
+}
+
+// notificationTemplateFuncs are available to every message template
+var notificationTemplateFuncs = map[string]interface{}{
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"title":      func(s string) string { return cases.Title(language.English).String(s) },
+	"unit":       metricUnit,
+	"formatTime": func(t time.Time, layout string) string { return t.Format(layout) },
+}
+
+// ValidateMessageTemplates parses the message templates of a channel type and renders them
+// against a sample incident
+func ValidateMessageTemplates(channelType string, templates *models.MessageTemplates) error {
+	templates.Subject = strings.TrimSpace(templates.Subject)
+	templates.Body = strings.TrimSpace(templates.Body)
+
+	switch channelType {
+	case ChannelTypeEmail:
+		if templates.Body != "" {
+			return fmt.Errorf("email templates have no body, use text and html")
+		}
+	case ChannelTypeWebhook, ChannelTypeSlack, ChannelTypeDiscord:
+		if templates.Subject != "" || templates.HTML != "" {
+			return fmt.Errorf("%s templates have no subject or html, use text and body", channelType)
+		}
+		if channelType == ChannelTypeWebhook && templates.Text != "" {
+			return fmt.Errorf("webhook templates have no text, use body")
+		}
+	default:
+		return fmt.Errorf("channel type must be one of: %s", strings.Join(NotificationChannelTypes(), ", "))
+	}
+
+	alert, history := sampleIncident(nil, "sample-host", false)
+	if _, err := RenderMessageTemplates(*templates, newNotificationTemplateData(alert, history)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateAlertTemplates validates the per channel type message templates of an alert
+func ValidateAlertTemplates(templates map[string]models.MessageTemplates) error {
+	for channelType, channelTemplates := range templates {
+		if err := ValidateMessageTemplates(channelType, &channelTemplates); err != nil {
+			return fmt.Errorf("%s templates: %w", channelType, err)
+		}
+		templates[channelType] = channelTemplates
+	}
+	return nil
+}
+
+// RenderMessageTemplates executes message templates; the body must render to valid JSON
+func RenderMessageTemplates(templates models.MessageTemplates, data NotificationTemplateData) (*NotificationMessage, error) {
+	var message NotificationMessage
+	var err error
+
+	if message.Subject, err = renderTextTemplate("subject", templates.Subject, data); err != nil {
+		return nil, err
+	}
+	message.Subject = strings.Join(strings.Fields(message.Subject), " ") // Headers are single line
+	if message.Text, err = renderTextTemplate("text", templates.Text, data); err != nil {
+		return nil, err
+	}
+	if message.Body, err = renderTextTemplate("body", templates.Body, data); err != nil {
+		return nil, err
+	}
+	if message.Body != "" && !json.Valid([]byte(message.Body)) {
+		return nil, fmt.Errorf("body template does not render valid JSON")
+	}
+
+	if templates.HTML != "" {
+		tmpl, err := htmltemplate.New("html").Funcs(notificationTemplateFuncs).Parse(templates.HTML)
+		if err != nil {
+			return nil, fmt.Errorf("invalid html template: %w", err)
+		}
+		var buffer bytes.Buffer
+		if err := tmpl.Execute(&buffer, data); err != nil {
+			return nil, fmt.Errorf("failed to render html template: %w", err)
+		}
+		message.HTML = buffer.String()
+	}
+
+	return &message, nil
+}
+
+// renderTextTemplate executes one text template; an empty template renders nothing
+func renderTextTemplate(name, text string, data NotificationTemplateData) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New(name).Funcs(notificationTemplateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buffer.String(), nil
+}
+
+// messageTemplates returns the templates of a channel type for an alert: the templates of the
+// alert override those of the channel type field by field
+func (as *AlertService) messageTemplates(channelType string, alert *models.Alert) models.MessageTemplates {
+	as.mutex.RLock()
+	templates := as.templates[channelType]
+	as.mutex.RUnlock()
+
+	override := alert.Templates[channelType]
+	if override.Subject != "" {
+		templates.Subject = override.Subject
+	}
+	if override.Text != "" {
+		templates.Text = override.Text
+	}
+	if override.HTML != "" {
+		templates.HTML = override.HTML
+	}
+	if override.Body != "" {
+		templates.Body = override.Body
+	}
+	return templates
+}
+
+// RenderNotification renders the message templates of a channel type for an incident. It
+// returns nil, so the built-in layout is sent, when there are no templates or they fail.
+func (as *AlertService) RenderNotification(channelType string, alert *models.Alert, history *models.AlertHistory) *NotificationMessage {
+	return as.renderTemplates(channelType, as.messageTemplates(channelType, alert), alert, history)
+}
+
+// renderChannelNotification renders the message templates of a notification channel for an
+// incident. The body template of a webhook channel overrides that of the channel type, and
+// is overridden by that of the alert.
+func (as *AlertService) renderChannelNotification(channel *models.NotificationChannel, alert *models.Alert, history *models.AlertHistory) *NotificationMessage {
+	templates := as.messageTemplates(channel.Type, alert)
+	if channel.Config.Body != "" && alert.Templates[channel.Type].Body == "" {
+		templates.Body = channel.Config.Body
+	}
+	return as.renderTemplates(channel.Type, templates, alert, history)
+}
+
+// renderTemplates renders message templates for an incident, returning nil when there are none
+// or they fail
+func (as *AlertService) renderTemplates(channelType string, templates models.MessageTemplates, alert *models.Alert, history *models.AlertHistory) *NotificationMessage {
+	if templates == (models.MessageTemplates{}) {
+		return nil
+	}
+
+	message, err := RenderMessageTemplates(templates, as.notificationTemplateData(alert, history))
+	if err != nil {
+		log.Printf("❌ Failed to render %s notification template for %s: %v", channelType, alert.Name, err)
+		return nil
+	}
+	return message
+}
+
+// newNotificationTemplateData returns the template data of an incident without host details
+// or metric values
+func newNotificationTemplateData(alert *models.Alert, history *models.AlertHistory) NotificationTemplateData {
+	data := NotificationTemplateData{
+		Alert:    alert,
+		History:  history,
+		Host:     NotificationHost{Hostname: history.Hostname},
+		Status:   AlertStateFiring,
+		Duration: formatIncidentDuration(history),
+		Unit:     metricUnit(alert.MetricType),
+		Metrics:  make(map[string]float64, len(sampleFieldNames)),
+	}
+	if history.Resolved {
+		data.Status = AlertStateResolved
+	}
+	for _, name := range sampleFieldNames {
+		data.Metrics[name] = 0
+	}
+	return data
+}
+
+// notificationTemplateData collects the data message templates are executed with
+func (as *AlertService) notificationTemplateData(alert *models.Alert, history *models.AlertHistory) NotificationTemplateData {
+	data := newNotificationTemplateData(alert, history)
+
+	now := time.Now()
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	data.Host.Labels = as.hostLabels[history.Hostname]
+	data.Host.Description = as.hostDescriptions[history.Hostname]
+	if buffer, exists := as.samples[history.Hostname]; exists && len(buffer.times) > 0 {
+		latest := buffer.values[len(buffer.values)-1]
+		for i, name := range sampleFieldNames {
+			data.Metrics[name] = latest[i]
+		}
+		data.Recent = buffer.samples(alert.MetricType, NotificationRecentWindow, now)
+	}
+	return data
+}
+
+// PreviewNotification renders message templates of a channel type against a sample incident
+// of an alert (0 = a sample CPU alert) on a host. Nil templates preview the saved templates.
+func (as *AlertService) PreviewNotification(channelType string, templates *models.MessageTemplates, alertID uint, hostname string, resolved bool) (*NotificationMessage, error) {
+	var alert *models.Alert
+	if alertID != 0 {
+		var err error
+		if alert, err = as.alertRepo.GetAlertByID(alertID); err != nil {
+			return nil, err
+		}
+	}
+	if hostname == "" {
+		hostname = "sample-host"
+	}
+	alert, history := sampleIncident(alert, hostname, resolved)
+
+	if templates == nil {
+		saved := as.messageTemplates(channelType, alert)
+		templates = &saved
+	}
+	if err := ValidateMessageTemplates(channelType, templates); err != nil {
+		return nil, err
+	}
+	return RenderMessageTemplates(*templates, as.notificationTemplateData(alert, history))
+}
+
+// sampleIncident builds an incident of an alert for template previews; a nil alert is
+// replaced by a sample CPU alert
+func sampleIncident(alert *models.Alert, hostname string, resolved bool) (*models.Alert, *models.AlertHistory) {
+	if alert == nil {
+		alert = &models.Alert{
+			Name:       "High CPU usage",
+			MetricType: "cpu",
+			Condition:  ">",
+			Threshold:  80,
+			Severity:   "warning",
+		}
+	}
+
+	value := alert.Threshold + 10
+	if exceeds(alert.Condition, alert.Threshold-10, alert.Threshold) {
+		value = alert.Threshold - 10
+	}
+
+	now := time.Now()
+	history := &models.AlertHistory{
+		AlertID:     alert.ID,
+		Hostname:    hostname,
+		MetricValue: value,
+		Threshold:   alert.Threshold,
+		Severity:    alert.Severity,
+		Message:     fmt.Sprintf("%s on %s: %.2f%s %s %.2f%s", alert.Name, hostname, value, metricUnit(alert.MetricType), alert.Condition, alert.Threshold, metricUnit(alert.MetricType)),
+		PeakValue:   value,
+		Resolved:    resolved,
+	}
+	history.CreatedAt = now.Add(-5 * time.Minute)
+	if resolved {
+		history.ResolvedAt = now
+	}
+	return alert, history
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/config"
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func TestValidateMessageTemplates(t *testing.T) {
+	templates := &models.MessageTemplates{
+		Subject: " [{{upper .Status}}] {{.Alert.Name}}\n",
+		Text:    "{{.History.Hostname}} at {{printf \"%.1f\" .History.MetricValue}}{{.Unit}}",
+		HTML:    "<b>{{.Alert.Name}}</b>",
+	}
+	require.NoError(t, ValidateMessageTemplates(ChannelTypeEmail, templates))
+	assert.Equal(t, "[{{upper .Status}}] {{.Alert.Name}}", templates.Subject)
+
+	require.NoError(t, ValidateMessageTemplates(ChannelTypeWebhook, &models.MessageTemplates{
+		Body: `{"alert": {{json .Alert.Name}}, "cpu": {{index .Metrics "cpu"}}}`,
+	}))
+
+	tests := []struct {
+		name        string
+		channelType string
+		templates   models.MessageTemplates
+	}{
+		{"unknown channel type", "sms", models.MessageTemplates{Text: "hi"}},
+		{"syntax error", ChannelTypeEmail, models.MessageTemplates{Subject: "{{.Alert.Name"}},
+		{"unknown field", ChannelTypeSlack, models.MessageTemplates{Text: "{{.Alert.Nmae}}"}},
+		{"unknown function", ChannelTypeDiscord, models.MessageTemplates{Text: "{{shout .Alert.Name}}"}},
+		{"invalid JSON body", ChannelTypeSlack, models.MessageTemplates{Body: `{"text": {{.Alert.Name}}}`}},
+		{"email body", ChannelTypeEmail, models.MessageTemplates{Body: `{}`}},
+		{"slack subject", ChannelTypeSlack, models.MessageTemplates{Subject: "hi"}},
+		{"webhook text", ChannelTypeWebhook, models.MessageTemplates{Text: "hi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, ValidateMessageTemplates(tt.channelType, &tt.templates))
+		})
+	}
+}
+
+func TestAlertService_RenderNotification(t *testing.T) {
+	as := NewAlertService(nil, newFakeAlertRepo(), nil, nil)
+	as.templates = map[string]models.MessageTemplates{
+		ChannelTypeSlack: {Text: "{{.Alert.Name}} on {{.Host.Hostname}} ({{.Host.Description}}, team {{.Host.Labels.team}})"},
+	}
+	as.hostLabels = map[string]map[string]string{"web-1": {"team": "infra"}}
+	as.hostDescriptions = map[string]string{"web-1": "frontend"}
+	as.recordSample(&models.SystemMetrics{Hostname: "web-1", CPU: models.CPUMetrics{Usage: 91}, Memory: models.MemoryMetrics{Percent: 40}})
+
+	alert := &models.Alert{Name: "high cpu", MetricType: "cpu", Condition: ">", Threshold: 80}
+	history := &models.AlertHistory{Hostname: "web-1", MetricValue: 91}
+
+	message := as.RenderNotification(ChannelTypeSlack, alert, history)
+	require.NotNil(t, message)
+	assert.Equal(t, "high cpu on web-1 (frontend, team infra)", message.Text)
+
+	// Alert templates override the channel type templates field by field
+	alert.Templates = map[string]models.MessageTemplates{
+		ChannelTypeSlack: {Body: `{"text": {{json .Status}}, "memory": {{index .Metrics "memory"}}, "recent": {{len .Recent}}}`},
+	}
+	message = as.RenderNotification(ChannelTypeSlack, alert, history)
+	require.NotNil(t, message)
+	assert.Equal(t, "high cpu on web-1 (frontend, team infra)", message.Text)
+	assert.JSONEq(t, `{"text": "firing", "memory": 40, "recent": 1}`, message.Body)
+
+	// No templates, or templates that fail to render, keep the built-in layout
+	assert.Nil(t, as.RenderNotification(ChannelTypeEmail, alert, history))
+	alert.Templates[ChannelTypeEmail] = models.MessageTemplates{Text: "{{.Alert.Nope}}"}
+	assert.Nil(t, as.RenderNotification(ChannelTypeEmail, alert, history))
+}
+
+func TestAlertService_DeliverRenderedTemplates(t *testing.T) {
+	as, sender := newRoutedAlertService()
+	as.templates = map[string]models.MessageTemplates{
+		ChannelTypeSlack: {Text: "{{.Alert.Name}} is {{.Status}}"},
+	}
+
+	alert := &models.Alert{Name: "cpu", Severity: "warning"}
+	require.NoError(t, as.deliverToChannel(as.channels[2], alert, &models.AlertHistory{Hostname: "web-1", Resolved: true}))
+	require.NoError(t, as.deliverToChannel(as.channels[1], alert, &models.AlertHistory{Hostname: "web-1"}))
+
+	require.Len(t, sender.rendered, 2)
+	assert.Equal(t, "cpu is resolved", sender.rendered[0].Text)
+	assert.Nil(t, sender.rendered[1])
+}
+
+func TestAlertService_PreviewNotification(t *testing.T) {
+	alert := &models.Alert{BaseModel: models.BaseModel{ID: 7}, Name: "low disk", MetricType: "disk_free", Condition: "<", Threshold: 20}
+	as := NewAlertService(nil, newFakeAlertRepo(alert), nil, nil)
+
+	message, err := as.PreviewNotification(ChannelTypeEmail, &models.MessageTemplates{
+		Subject: "{{.Alert.Name}} {{.Status}} on {{.Host.Hostname}}",
+		Text:    "{{.History.MetricValue}} after {{.Duration}}",
+	}, 7, "db-1", true)
+	require.NoError(t, err)
+	assert.Equal(t, "low disk resolved on db-1", message.Subject)
+	assert.Equal(t, "10 after 5m0s", message.Text)
+
+	// Without templates the saved ones are rendered
+	as.templates = map[string]models.MessageTemplates{ChannelTypeDiscord: {Text: "{{.Alert.Name}} on {{.Host.Hostname}}"}}
+	message, err = as.PreviewNotification(ChannelTypeDiscord, nil, 0, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "High CPU usage on sample-host", message.Text)
+
+	_, err = as.PreviewNotification(ChannelTypeEmail, nil, 99, "", false)
+	assert.Error(t, err)
+	_, err = as.PreviewNotification(ChannelTypeSlack, &models.MessageTemplates{Body: "{{.Alert.Name}}"}, 0, "", false)
+	assert.Error(t, err)
+}
+
+func TestHTTPWebhookSender_RenderedMessage(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(&config.WebhookConfig{DefaultTimeout: time.Second})
+	alert := &models.Alert{Name: "cpu", MetricType: "cpu", Condition: ">", Threshold: 80}
+	history := &models.AlertHistory{Hostname: "web-1", MetricValue: 90}
+
+	webhook := &models.NotificationChannel{Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: server.URL}}
+	slack := &models.NotificationChannel{Type: ChannelTypeSlack, Config: models.NotificationChannelConfig{URL: server.URL}}
+	require.NoError(t, sender.SendAlertTo(webhook, alert, history, &NotificationMessage{Body: `{"custom": true}`}))
+	require.NoError(t, sender.SendAlertTo(slack, alert, history, &NotificationMessage{Text: "custom text"}))
+
+	require.Len(t, received, 2)
+	assert.JSONEq(t, `{"custom": true}`, received[0])
+	assert.Contains(t, received[1], `"text":"custom text"`)
+	assert.Contains(t, received[1], `"attachments"`) // The rest of the built-in payload is kept
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/config"
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func TestValidateNotificationChannel(t *testing.T) {
+	channel := &models.NotificationChannel{Name: " ops ", Type: "Slack", Config: models.NotificationChannelConfig{URL: " https://hooks.slack.com/services/x "}}
+	require.NoError(t, ValidateNotificationChannel(channel))
+	assert.Equal(t, "ops", channel.Name)
+	assert.Equal(t, ChannelTypeSlack, channel.Type)
+	assert.Equal(t, "https://hooks.slack.com/services/x", channel.Config.URL)
+
+	tests := []struct {
+		name    string
+		channel models.NotificationChannel
+	}{
+		{"no name", models.NotificationChannel{Type: ChannelTypeEmail, Config: models.NotificationChannelConfig{Recipients: "a@example.com"}}},
+		{"unknown type", models.NotificationChannel{Name: "x", Type: "sms"}},
+		{"no recipients", models.NotificationChannel{Name: "x", Type: ChannelTypeEmail}},
+		{"bad recipients", models.NotificationChannel{Name: "x", Type: ChannelTypeEmail, Config: models.NotificationChannelConfig{Recipients: "not an address"}}},
+		{"bad url", models.NotificationChannel{Name: "x", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: "ftp://example.com"}}},
+		{"bad method", models.NotificationChannel{Name: "x", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: "https://example.com", Method: "GET"}}},
+		{"bad header name", models.NotificationChannel{Name: "x", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: "https://example.com", Headers: map[string]string{"X Team": "ops"}}}},
+		{"multi-line header", models.NotificationChannel{Name: "x", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: "https://example.com", Headers: map[string]string{"X-Team": "ops\r\nX-Evil: 1"}}}},
+		{"bad body", models.NotificationChannel{Name: "x", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: "https://example.com", Body: "{{.Alert.Name}}"}}},
+		{"unknown auth", models.NotificationChannel{Name: "x", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: "https://example.com", Auth: &models.WebhookAuth{Type: "digest"}}}},
+		{"bearer without token", models.NotificationChannel{Name: "x", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{URL: "https://example.com", Auth: &models.WebhookAuth{Type: "bearer"}}}},
+		{"slack secret", models.NotificationChannel{Name: "x", Type: ChannelTypeSlack, Config: models.NotificationChannelConfig{URL: "https://hooks.slack.com/x", Secret: "s"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, ValidateNotificationChannel(&tt.channel))
+		})
+	}
+}
+
+func TestValidateNotificationChannel_WebhookSettings(t *testing.T) {
+	channel := &models.NotificationChannel{Name: "hook", Type: ChannelTypeWebhook, Config: models.NotificationChannelConfig{
+		URL:     "https://example.com/hook",
+		Method:  " put ",
+		Headers: map[string]string{"X-Team": "ops"},
+		Body:    ` {"alert": {{json .Alert.Name}}} `,
+		Auth:    &models.WebhookAuth{Type: "Basic", Username: "godash", Password: "secret", Token: "unused"},
+	}}
+	require.NoError(t, ValidateNotificationChannel(channel))
+	assert.Equal(t, http.MethodPut, channel.Config.Method)
+	assert.Equal(t, `{"alert": {{json .Alert.Name}}}`, channel.Config.Body)
+	assert.Equal(t, WebhookAuthBasic, channel.Config.Auth.Type)
+	assert.Empty(t, channel.Config.Auth.Token)
+}
+
+func TestHTTPWebhookSender_ChannelSettings(t *testing.T) {
+	var request *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	channel := &models.NotificationChannel{BaseModel: models.BaseModel{ID: 1}, Name: "hook", Type: ChannelTypeWebhook, Enabled: true,
+		Config: models.NotificationChannelConfig{
+			URL:     server.URL,
+			Method:  http.MethodPatch,
+			Headers: map[string]string{"X-Team": "ops"},
+			Body:    `{"summary": {{json .Alert.Name}}, "host": {{json .Host.Hostname}}}`,
+			Auth:    &models.WebhookAuth{Type: WebhookAuthBearer, Token: "t0ken"},
+			Secret:  "shared",
+		}}
+	as := NewAlertService(nil, newFakeAlertRepo(), nil, NewWebhookSender(&config.WebhookConfig{DefaultTimeout: time.Second}))
+
+	alert := &models.Alert{Name: "high cpu", MetricType: "cpu", Condition: ">", Threshold: 80}
+	require.NoError(t, as.deliverToChannel(channel, alert, &models.AlertHistory{Hostname: "web-1", MetricValue: 90}))
+
+	require.NotNil(t, request)
+	assert.Equal(t, http.MethodPatch, request.Method)
+	assert.Equal(t, "ops", request.Header.Get("X-Team"))
+	assert.Equal(t, "Bearer t0ken", request.Header.Get("Authorization"))
+	assert.JSONEq(t, `{"summary": "high cpu", "host": "web-1"}`, string(body))
+
+	timestamp, err := strconv.ParseInt(request.Header.Get(WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+	assert.Equal(t, SignWebhookPayload("shared", timestamp, body), request.Header.Get(WebhookSignatureHeader))
+
+	// Alert body templates win over the channel body template
+	alert.Templates = map[string]models.MessageTemplates{ChannelTypeWebhook: {Body: `{"custom": true}`}}
+	require.NoError(t, as.deliverToChannel(channel, alert, &models.AlertHistory{Hostname: "web-1"}))
+	assert.JSONEq(t, `{"custom": true}`, string(body))
+
+	// Basic auth, and plain POSTs without settings
+	channel.Config.Auth = &models.WebhookAuth{Type: WebhookAuthBasic, Username: "godash", Password: "pw"}
+	require.NoError(t, as.deliverToChannel(channel, alert, &models.AlertHistory{Hostname: "web-1"}))
+	username, password, ok := request.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "godash:pw", username+":"+password)
+
+	channel.Config = models.NotificationChannelConfig{URL: server.URL}
+	require.NoError(t, as.deliverToChannel(channel, &models.Alert{Name: "cpu", MetricType: "cpu"}, &models.AlertHistory{Hostname: "web-1"}))
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Empty(t, request.Header.Get(WebhookSignatureHeader))
+	assert.Empty(t, request.Header.Get("Authorization"))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// echo -n '1700000000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686", SignWebhookPayload("secret", 1700000000, []byte(`{"a":1}`)))
+}
+
+// newRoutedAlertService returns an alert service with an email, a Slack and a disabled channel
+func newRoutedAlertService(routes ...*models.NotificationRoute) (*AlertService, *recordingSender) {
+	sender := &recordingSender{}
+	as := NewAlertService(nil, newFakeAlertRepo(), sender, sender)
+	as.channels = map[uint]*models.NotificationChannel{
+		1: {BaseModel: models.BaseModel{ID: 1}, Name: "oncall", Type: ChannelTypeEmail, Enabled: true, Config: models.NotificationChannelConfig{Recipients: "oncall@example.com"}},
+		2: {BaseModel: models.BaseModel{ID: 2}, Name: "ops", Type: ChannelTypeSlack, Enabled: true, Config: models.NotificationChannelConfig{URL: "https://hooks.slack.com/ops"}},
+		3: {BaseModel: models.BaseModel{ID: 3}, Name: "old", Type: ChannelTypeWebhook, Enabled: false, Config: models.NotificationChannelConfig{URL: "https://old.example.com"}},
+	}
+	as.routes = routes
+	return as, sender
+}
+
+func TestAlertService_RouteChannels(t *testing.T) {
+	critical := &models.NotificationRoute{Enabled: true, Matchers: []models.AlertMatcher{{Name: MatchSeverity, Value: "critical"}}, ChannelIDs: []uint{1, 3}, Continue: true}
+	web := &models.NotificationRoute{Enabled: true, Matchers: []models.AlertMatcher{{Name: MatchHostname, Value: "web-.*", IsRegex: true}}, ChannelIDs: []uint{2, 1}}
+	catchAll := &models.NotificationRoute{Enabled: true, ChannelIDs: []uint{2}}
+	as, _ := newRoutedAlertService(critical, web, catchAll)
+
+	names := func(channels []*models.NotificationChannel) []string {
+		var result []string
+		for _, channel := range channels {
+			result = append(result, channel.Name)
+		}
+		return result
+	}
+
+	alert := &models.Alert{Name: "cpu", Severity: "critical"}
+	// Continue falls through to the next match; disabled and repeated channels are skipped
+	assert.Equal(t, []string{"oncall", "ops"}, names(as.routeChannels(alert, "web-1")))
+
+	// The first match without continue ends routing
+	alert.Severity = "warning"
+	assert.Equal(t, []string{"ops", "oncall"}, names(as.routeChannels(alert, "web-1")))
+	assert.Equal(t, []string{"ops"}, names(as.routeChannels(alert, "db-1")))
+
+	web.Enabled = false
+	assert.Equal(t, []string{"ops"}, names(as.routeChannels(alert, "web-1")))
+}
+
+func TestAlertService_SendNotificationsThroughChannels(t *testing.T) {
+	route := &models.NotificationRoute{Enabled: true, Matchers: []models.AlertMatcher{{Name: "team", Value: "infra"}}, ChannelIDs: []uint{1, 2}}
+	as, sender := newRoutedAlertService(route)
+
+	alert := &models.Alert{Name: "cpu", Severity: "warning", Labels: map[string]string{"team": "infra"},
+		EmailEnabled: true, EmailRecipients: "inline@example.com"}
+	as.sendNotifications(alert, &models.AlertHistory{Hostname: "web-1"})
+	assert.Equal(t, []string{"oncall@example.com", "slack https://hooks.slack.com/ops"}, sender.destinations())
+
+	// Alerts no route matches use their own settings
+	alert.Labels = nil
+	as.sendNotifications(alert, &models.AlertHistory{Hostname: "web-1"})
+	assert.Equal(t, "inline@example.com", sender.destinations()[2])
+
+	// Escalation steps can notify channels
+	as.notifyEscalationStep(alert, &models.AlertHistory{Hostname: "web-1"}, models.EscalationStep{ChannelID: 2})
+	assert.Equal(t, "slack https://hooks.slack.com/ops", sender.destinations()[3])
+	as.notifyEscalationStep(alert, &models.AlertHistory{Hostname: "web-1"}, models.EscalationStep{ChannelID: 3})
+	assert.Len(t, sender.destinations(), 4)
+}
+
+func TestAlertService_ResolvedIncidentPeakValue(t *testing.T) {
+	alert := &models.Alert{BaseModel: models.BaseModel{ID: 1}, Name: "high cpu", MetricType: "cpu", Condition: ">", Threshold: 80, IsActive: true}
+	repo := newFakeAlertRepo(alert)
+	sender := &recordingSender{}
+	as := NewAlertService(nil, repo, sender, sender)
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 97, "", nil))
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 85, "", nil))
+	assert.Equal(t, 97.0, as.instances[key].PeakValue)
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 10, "", nil))
+	history := repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.True(t, history[0].Resolved)
+	assert.Equal(t, 97.0, history[0].PeakValue)
+}
+
+func TestAlertService_SkipResolvedNotifications(t *testing.T) {
+	route := &models.NotificationRoute{Enabled: true, ChannelIDs: []uint{2}}
+	as, sender := newRoutedAlertService(route)
+
+	alert := &models.Alert{Name: "cpu", Severity: "warning", EmailEnabled: true, EmailRecipients: "inline@example.com"}
+	resolved := &models.AlertHistory{Hostname: "web-1", Resolved: true}
+	as.sendNotifications(alert, resolved)
+	assert.Len(t, sender.destinations(), 1)
+
+	alert.SkipResolved = true
+	as.sendNotifications(alert, resolved)
+	assert.Len(t, sender.destinations(), 1)
+
+	// Firing notifications are still sent
+	as.sendNotifications(alert, &models.AlertHistory{Hostname: "web-1"})
+	assert.Len(t, sender.destinations(), 2)
+
+	// Alerts without routes use their own settings
+	as.routes = nil
+	as.sendNotifications(alert, resolved)
+	assert.Len(t, sender.destinations(), 2)
+}
+
+func TestResolvedNotificationPayloads(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	alert := &models.Alert{Name: "high cpu", MetricType: "cpu", Condition: ">", Threshold: 80}
+	history := &models.AlertHistory{Hostname: "web-1", MetricValue: 40, Threshold: 80, Severity: "warning",
+		PeakValue: 97.5, Resolved: true, ResolvedAt: createdAt.Add(5*time.Minute + 30*time.Second)}
+	history.CreatedAt = createdAt
+	assert.Equal(t, "5m30s", formatIncidentDuration(history))
+
+	w := NewWebhookSender(&config.WebhookConfig{}).(*HTTPWebhookSender)
+	generic := w.createGenericPayload(alert, history)
+	assert.Equal(t, 97.5, generic.Alert.PeakValue)
+	assert.Equal(t, 330.0, generic.Alert.Duration)
+
+	slack := w.createSlackPayload(alert, history)
+	assert.Contains(t, slack.Attachments[0].Fields, SlackField{Title: "Duration", Value: "5m30s", Short: true})
+	assert.Contains(t, slack.Attachments[0].Fields, SlackField{Title: "Peak Value", Value: "97.50%", Short: true})
+
+	discord := w.createDiscordPayload(alert, history)
+	assert.Contains(t, discord.Embeds[0].Fields, DiscordEmbedField{Name: "Duration", Value: "5m30s", Inline: true})
+
+	s := &SMTPEmailSender{}
+	data := AlertEmailData{MetricType: "cpu", Resolved: true, Duration: "5m30s", PeakValue: 97.5, Timestamp: history.ResolvedAt}
+	html, err := s.generateHTMLBody(data)
+	require.NoError(t, err)
+	assert.Contains(t, html, "5m30s")
+	assert.Contains(t, s.generateTextBody(data), "Peak Value: 97.50%")
+
+	// Firing incidents have no duration yet
+	history.Resolved = false
+	assert.Zero(t, w.createGenericPayload(alert, history).Alert.Duration)
+}
@@ -0,0 +1,549 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eyzaun/godash/internal/config"
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// Probe types
+const (
+	ProbeTypeHTTP = "http" // HTTP(S) request with status, body and TLS checks
+	ProbeTypeTCP  = "tcp"  // TCP connect
+	ProbeTypeDNS  = "dns"  // DNS resolve
+)
+
+// Probe metric types evaluated against probe results
+const (
+	MetricProbeSuccess   = "probe_success"    // 1 when the check succeeded, 0 otherwise
+	MetricProbeLatency   = "probe_latency"    // Check latency in milliseconds
+	MetricProbeTLSExpiry = "probe_tls_expiry" // Days until the endpoint's certificate expires
+)
+
+const (
+	defaultProbeInterval = 60 * time.Second
+	defaultProbeTimeout  = 10 * time.Second
+	probeReloadInterval  = 30 * time.Second
+	probeCleanupInterval = time.Hour
+	maxProbeBodyBytes    = 1024 * 1024
+)
+
+// ProbeMetricTypes returns all probe metric types
+func ProbeMetricTypes() []string {
+	return []string{MetricProbeSuccess, MetricProbeLatency, MetricProbeTLSExpiry}
+}
+
+// IsProbeMetricType reports whether a metric type is evaluated from probe results
+func IsProbeMetricType(metricType string) bool {
+	for _, t := range ProbeMetricTypes() {
+		if strings.EqualFold(t, metricType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateProbe validates a probe configuration
+func ValidateProbe(probe *models.Probe) error {
+	if strings.TrimSpace(probe.Name) == "" {
+		return fmt.Errorf("probe name is required")
+	}
+
+	target := strings.TrimSpace(probe.Target)
+	if target == "" {
+		return fmt.Errorf("probe target is required")
+	}
+
+	switch strings.ToLower(probe.Type) {
+	case ProbeTypeHTTP:
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid HTTP probe target: %s (expected http:// or https:// URL)", target)
+		}
+		if probe.BodyMatch != "" {
+			if _, err := regexp.Compile(probe.BodyMatch); err != nil {
+				return fmt.Errorf("invalid body match regex: %w", err)
+			}
+		}
+		if probe.ExpectedStatus != 0 && (probe.ExpectedStatus < 100 || probe.ExpectedStatus > 599) {
+			return fmt.Errorf("invalid expected status: %d", probe.ExpectedStatus)
+		}
+	case ProbeTypeTCP:
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			return fmt.Errorf("invalid TCP probe target: %s (expected host:port)", target)
+		}
+	case ProbeTypeDNS:
+		if strings.ContainsAny(target, "/: ") {
+			return fmt.Errorf("invalid DNS probe target: %s (expected hostname)", target)
+		}
+	default:
+		return fmt.Errorf("invalid probe type: %s. Valid types: %s, %s, %s", probe.Type, ProbeTypeHTTP, ProbeTypeTCP, ProbeTypeDNS)
+	}
+
+	if probe.Interval < 0 || probe.Timeout < 0 || probe.TLSExpiryDays < 0 {
+		return fmt.Errorf("interval, timeout and TLS expiry days must not be negative")
+	}
+
+	return nil
+}
+
+// ProbeService schedules synthetic probes and records their results
+type ProbeService struct {
+	probeRepo    repository.ProbeRepository
+	alertService *AlertService
+	config       *config.Config
+
+	// Scheduling state
+	probes          []*models.Probe
+	nextRun         map[uint]time.Time
+	inFlight        map[uint]bool
+	lastReload      time.Time
+	reloadRequested bool
+	lastCleanup     time.Time
+
+	isRunning bool
+	stopChan  chan bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	mutex     sync.RWMutex
+
+	// Statistics
+	checksCount   int64
+	failuresCount int64
+	lastCheckTime time.Time
+}
+
+// NewProbeService creates a new probe service
+func NewProbeService(cfg *config.Config, probeRepo repository.ProbeRepository) *ProbeService {
+	return &ProbeService{
+		probeRepo: probeRepo,
+		config:    cfg,
+		nextRun:   make(map[uint]time.Time),
+		inFlight:  make(map[uint]bool),
+		stopChan:  make(chan bool, 1),
+	}
+}
+
+// SetAlertService sets the alert service that evaluates probe results
+func (ps *ProbeService) SetAlertService(alertService *AlertService) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.alertService = alertService
+	log.Println("🔗 Alert service integrated with probe service")
+}
+
+// Start starts the probe scheduler
+func (ps *ProbeService) Start(ctx context.Context) error {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	if ps.isRunning {
+		return fmt.Errorf("probe service is already running")
+	}
+
+	log.Println("📡 Starting probe service...")
+
+	ps.ctx, ps.cancel = context.WithCancel(ctx)
+	ps.reloadRequested = true
+
+	go ps.schedulerRoutine()
+
+	ps.isRunning = true
+	log.Println("✅ Probe service started successfully")
+
+	return nil
+}
+
+// Stop stops the probe scheduler
+func (ps *ProbeService) Stop() error {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	if !ps.isRunning {
+		return fmt.Errorf("probe service is not running")
+	}
+
+	log.Println("🛑 Stopping probe service...")
+
+	if ps.cancel != nil {
+		ps.cancel()
+	}
+
+	select {
+	case ps.stopChan <- true:
+	default:
+		// Channel might be full or closed
+	}
+
+	ps.isRunning = false
+	log.Println("✅ Probe service stopped successfully")
+
+	return nil
+}
+
+// Reload makes the scheduler pick up probe configuration changes on its next tick
+func (ps *ProbeService) Reload() {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.reloadRequested = true
+}
+
+// RunNow runs a probe immediately, stores the result and evaluates alerts
+func (ps *ProbeService) RunNow(probe *models.Probe) *models.ProbeResult {
+	return ps.executeProbe(probe)
+}
+
+// GetStats returns probe service statistics
+func (ps *ProbeService) GetStats() map[string]interface{} {
+	ps.mutex.RLock()
+	defer ps.mutex.RUnlock()
+
+	return map[string]interface{}{
+		"is_running":      ps.isRunning,
+		"probes":          len(ps.probes),
+		"checks_count":    ps.checksCount,
+		"failures_count":  ps.failuresCount,
+		"last_check_time": ps.lastCheckTime,
+	}
+}
+
+// schedulerRoutine runs due probes once per second
+func (ps *ProbeService) schedulerRoutine() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ps.runDueProbes()
+			ps.cleanupOldResults()
+
+		case <-ps.stopChan:
+			log.Println("📡 Probe scheduler stopped")
+			return
+
+		case <-ps.ctx.Done():
+			log.Println("📡 Probe scheduler cancelled")
+			return
+		}
+	}
+}
+
+// runDueProbes reloads the probe list if needed and starts probes whose interval has elapsed
+func (ps *ProbeService) runDueProbes() {
+	now := time.Now()
+
+	ps.mutex.Lock()
+	if ps.reloadRequested || now.Sub(ps.lastReload) >= probeReloadInterval {
+		probes, err := ps.probeRepo.GetEnabledProbes()
+		if err != nil {
+			log.Printf("❌ Failed to load probes: %v", err)
+		} else {
+			ps.probes = probes
+		}
+		ps.lastReload = now
+		ps.reloadRequested = false
+	}
+
+	var due []*models.Probe
+	for _, probe := range ps.probes {
+		if ps.inFlight[probe.ID] || now.Before(ps.nextRun[probe.ID]) {
+			continue
+		}
+		ps.inFlight[probe.ID] = true
+		ps.nextRun[probe.ID] = now.Add(probeInterval(probe))
+		due = append(due, probe)
+	}
+	ps.mutex.Unlock()
+
+	for _, probe := range due {
+		go func(probe *models.Probe) {
+			defer func() {
+				ps.mutex.Lock()
+				delete(ps.inFlight, probe.ID)
+				ps.mutex.Unlock()
+			}()
+			ps.executeProbe(probe)
+		}(probe)
+	}
+}
+
+// cleanupOldResults deletes probe results outside the metrics retention period
+func (ps *ProbeService) cleanupOldResults() {
+	if ps.config == nil || ps.config.Metrics.RetentionDays <= 0 {
+		return
+	}
+
+	ps.mutex.Lock()
+	if time.Since(ps.lastCleanup) < probeCleanupInterval {
+		ps.mutex.Unlock()
+		return
+	}
+	ps.lastCleanup = time.Now()
+	ps.mutex.Unlock()
+
+	cutoff := time.Now().AddDate(0, 0, -ps.config.Metrics.RetentionDays)
+	if deleted, err := ps.probeRepo.DeleteOldProbeResults(cutoff); err != nil {
+		log.Printf("❌ Failed to clean up probe results: %v", err)
+	} else if deleted > 0 {
+		log.Printf("🧹 Cleaned up %d old probe results", deleted)
+	}
+}
+
+// executeProbe runs a probe, stores the result and feeds it to the alert service
+func (ps *ProbeService) executeProbe(probe *models.Probe) *models.ProbeResult {
+	result := RunProbe(probe)
+
+	if err := ps.probeRepo.CreateProbeResult(result); err != nil {
+		log.Printf("❌ Failed to store probe result for %s: %v", probe.Name, err)
+	}
+
+	probe.LastCheck = result.Timestamp
+	probe.LastSuccess = result.Success
+	probe.LastLatencyMs = result.LatencyMs
+	probe.LastError = result.Error
+	if err := ps.probeRepo.UpdateProbeStatus(probe); err != nil {
+		log.Printf("❌ Failed to update probe status for %s: %v", probe.Name, err)
+	}
+
+	ps.mutex.Lock()
+	ps.checksCount++
+	if !result.Success {
+		ps.failuresCount++
+	}
+	ps.lastCheckTime = result.Timestamp
+	alertService := ps.alertService
+	ps.mutex.Unlock()
+
+	if alertService != nil {
+		alertService.CheckProbeResult(probe, result)
+	}
+
+	return result
+}
+
+// RunProbe performs a single probe check without storing the result
+func RunProbe(probe *models.Probe) *models.ProbeResult {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout(probe))
+	defer cancel()
+
+	result := &models.ProbeResult{
+		ProbeID:   probe.ID,
+		Timestamp: time.Now(),
+	}
+
+	var err error
+	switch strings.ToLower(probe.Type) {
+	case ProbeTypeHTTP:
+		err = runHTTPProbe(ctx, probe, result)
+	case ProbeTypeTCP:
+		err = runTCPProbe(ctx, probe, result)
+	case ProbeTypeDNS:
+		err = runDNSProbe(ctx, probe, result)
+	default:
+		err = fmt.Errorf("unsupported probe type: %s", probe.Type)
+	}
+
+	if err != nil {
+		result.Success = false
+		result.Error = err.Error()
+	} else {
+		result.Success = true
+	}
+
+	return result
+}
+
+// runHTTPProbe requests the target URL and checks status, body and certificate expiry
+func runHTTPProbe(ctx context.Context, probe *models.Probe, result *models.ProbeResult) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.Target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "GoDash-Probe/1.0")
+
+	transport := &http.Transport{
+		Proxy:             http.ProxyFromEnvironment,
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: probe.SkipTLSVerify},
+		DisableKeepAlives: true,
+	}
+	client := &http.Client{Transport: transport}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.LatencyMs = msSince(start)
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBodyBytes))
+	result.LatencyMs = msSince(start)
+	result.StatusCode = resp.StatusCode
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		result.TLSExpiryDays = time.Until(resp.TLS.PeerCertificates[0].NotAfter).Hours() / 24
+	}
+
+	if probe.ExpectedStatus != 0 {
+		if resp.StatusCode != probe.ExpectedStatus {
+			return fmt.Errorf("unexpected status code %d (expected %d)", resp.StatusCode, probe.ExpectedStatus)
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if probe.BodyMatch != "" {
+		re, err := regexp.Compile(probe.BodyMatch)
+		if err != nil {
+			return fmt.Errorf("invalid body match regex: %w", err)
+		}
+		if !re.Match(body) {
+			return fmt.Errorf("response body does not match %q", probe.BodyMatch)
+		}
+	}
+
+	if probe.TLSExpiryDays > 0 && resp.TLS != nil && result.TLSExpiryDays < float64(probe.TLSExpiryDays) {
+		return fmt.Errorf("certificate expires in %.1f days (minimum %d)", result.TLSExpiryDays, probe.TLSExpiryDays)
+	}
+
+	return nil
+}
+
+// runTCPProbe opens a TCP connection to the target
+func runTCPProbe(ctx context.Context, probe *models.Probe, result *models.ProbeResult) error {
+	var dialer net.Dialer
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", probe.Target)
+	result.LatencyMs = msSince(start)
+	if err != nil {
+		return fmt.Errorf("connect failed: %w", err)
+	}
+	conn.Close()
+
+	return nil
+}
+
+// runDNSProbe resolves the target hostname
+func runDNSProbe(ctx context.Context, probe *models.Probe, result *models.ProbeResult) error {
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, probe.Target)
+	result.LatencyMs = msSince(start)
+	if err != nil {
+		return fmt.Errorf("resolve failed: %w", err)
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("no addresses found for %s", probe.Target)
+	}
+
+	return nil
+}
+
+// probeInterval returns the configured interval or the default
+func probeInterval(probe *models.Probe) time.Duration {
+	if probe.Interval > 0 {
+		return time.Duration(probe.Interval) * time.Second
+	}
+	return defaultProbeInterval
+}
+
+// probeTimeout returns the configured timeout or the default
+func probeTimeout(probe *models.Probe) time.Duration {
+	if probe.Timeout > 0 {
+		return time.Duration(probe.Timeout) * time.Second
+	}
+	return defaultProbeTimeout
+}
+
+// msSince returns the milliseconds elapsed since start
+func msSince(start time.Time) float64 {
+	return float64(time.Since(start).Microseconds()) / 1000
+}
+
+// CheckProbeResult evaluates probe alerts against a probe result
+func (as *AlertService) CheckProbeResult(probe *models.Probe, result *models.ProbeResult) {
+	if !as.config.EnableAlerts || probe == nil || result == nil {
+		return
+	}
+
+	alerts, err := as.alertRepo.GetActiveAlerts()
+	if err != nil {
+		log.Printf("❌ Failed to get active alerts: %v", err)
+		return
+	}
+
+	source := "probe:" + probe.Name
+	for _, alert := range alerts {
+		if !IsProbeMetricType(alert.MetricType) {
+			continue
+		}
+		if alert.ProbeID != 0 && alert.ProbeID != probe.ID {
+			continue
+		}
+
+		var value float64
+		switch strings.ToLower(alert.MetricType) {
+		case MetricProbeSuccess:
+			if result.Success {
+				value = 1
+			}
+		case MetricProbeLatency:
+			value = result.LatencyMs
+		case MetricProbeTLSExpiry:
+			// Only HTTPS probes that reached the server carry certificate data
+			if !strings.HasPrefix(strings.ToLower(probe.Target), "https://") || result.StatusCode == 0 {
+				continue
+			}
+			value = result.TLSExpiryDays
+		}
+
+		as.mutex.Lock()
+		as.checkedCount++
+		as.mutex.Unlock()
+
+		extra := map[string]interface{}{
+			"probe_id":     probe.ID,
+			"probe_target": probe.Target,
+		}
+		message := as.generateProbeAlertMessage(alert, probe, result, value)
+		if err := as.evaluateAlertValue(alert, source, value, message, extra); err != nil {
+			log.Printf("❌ Error evaluating probe alert %d: %v", alert.ID, err)
+		}
+	}
+}
+
+// generateProbeAlertMessage generates a human-readable message for probe alerts
+func (as *AlertService) generateProbeAlertMessage(alert *models.Alert, probe *models.Probe, result *models.ProbeResult, value float64) string {
+	target := fmt.Sprintf("Probe '%s' (%s)", probe.Name, probe.Target)
+
+	switch strings.ToLower(alert.MetricType) {
+	case MetricProbeSuccess:
+		if result.Success {
+			return fmt.Sprintf("%s succeeded in %.0f ms", target, result.LatencyMs)
+		}
+		return fmt.Sprintf("%s failed: %s", target, result.Error)
+	case MetricProbeLatency:
+		return fmt.Sprintf("%s latency %s %.0f ms (current: %.0f ms)", target, alert.Condition, alert.Threshold, value)
+	case MetricProbeTLSExpiry:
+		return fmt.Sprintf("%s certificate expires in %.1f days (threshold: %s %.0f days)", target, value, alert.Condition, alert.Threshold)
+	default:
+		return fmt.Sprintf("%s %s %s %.2f (current: %.2f)", target, alert.MetricType, alert.Condition, alert.Threshold, value)
+	}
+}
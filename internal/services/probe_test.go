@@ -0,0 +1,159 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func newTestHTTPServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"healthy"}`)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestRunProbe_HTTP(t *testing.T) {
+	server := newTestHTTPServer()
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		probe       models.Probe
+		wantSuccess bool
+		wantStatus  int
+		wantError   string
+	}{
+		{
+			name:        "success",
+			probe:       models.Probe{Type: ProbeTypeHTTP, Target: server.URL + "/ok"},
+			wantSuccess: true,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "body match",
+			probe:       models.Probe{Type: ProbeTypeHTTP, Target: server.URL + "/ok", BodyMatch: `"status":"healthy"`},
+			wantSuccess: true,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:       "body mismatch",
+			probe:      models.Probe{Type: ProbeTypeHTTP, Target: server.URL + "/ok", BodyMatch: "degraded"},
+			wantStatus: http.StatusOK,
+			wantError:  "does not match",
+		},
+		{
+			name:       "unexpected status",
+			probe:      models.Probe{Type: ProbeTypeHTTP, Target: server.URL + "/missing"},
+			wantStatus: http.StatusNotFound,
+			wantError:  "unexpected status code 404",
+		},
+		{
+			name:        "expected status",
+			probe:       models.Probe{Type: ProbeTypeHTTP, Target: server.URL + "/missing", ExpectedStatus: http.StatusNotFound},
+			wantSuccess: true,
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RunProbe(&tt.probe)
+
+			assert.Equal(t, tt.wantSuccess, result.Success, result.Error)
+			assert.Equal(t, tt.wantStatus, result.StatusCode)
+			assert.Greater(t, result.LatencyMs, 0.0)
+			if tt.wantError != "" {
+				assert.Contains(t, result.Error, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRunProbe_HTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	// The test certificate is self-signed, so verification fails by default
+	result := RunProbe(&models.Probe{Type: ProbeTypeHTTP, Target: server.URL})
+	assert.False(t, result.Success)
+
+	result = RunProbe(&models.Probe{Type: ProbeTypeHTTP, Target: server.URL, SkipTLSVerify: true})
+	assert.True(t, result.Success, result.Error)
+	assert.Greater(t, result.TLSExpiryDays, 0.0)
+
+	// Require more remaining validity than the test certificate has
+	result = RunProbe(&models.Probe{Type: ProbeTypeHTTP, Target: server.URL, SkipTLSVerify: true, TLSExpiryDays: 1000000})
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "certificate expires in")
+}
+
+func TestRunProbe_TCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+
+	result := RunProbe(&models.Probe{Type: ProbeTypeTCP, Target: addr})
+	assert.True(t, result.Success, result.Error)
+
+	listener.Close()
+
+	result = RunProbe(&models.Probe{Type: ProbeTypeTCP, Target: addr, Timeout: 2})
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "connect failed")
+}
+
+func TestRunProbe_DNS(t *testing.T) {
+	result := RunProbe(&models.Probe{Type: ProbeTypeDNS, Target: "localhost"})
+	assert.True(t, result.Success, result.Error)
+
+	result = RunProbe(&models.Probe{Type: ProbeTypeDNS, Target: "does-not-exist.invalid", Timeout: 2})
+	assert.False(t, result.Success)
+}
+
+func TestValidateProbe(t *testing.T) {
+	valid := []models.Probe{
+		{Name: "web", Type: ProbeTypeHTTP, Target: "https://example.com/health"},
+		{Name: "db", Type: ProbeTypeTCP, Target: "db.internal:5432"},
+		{Name: "dns", Type: ProbeTypeDNS, Target: "example.com"},
+	}
+	for _, probe := range valid {
+		assert.NoError(t, ValidateProbe(&probe), probe.Name)
+	}
+
+	invalid := []models.Probe{
+		{Name: "", Type: ProbeTypeHTTP, Target: "https://example.com"},
+		{Name: "scheme", Type: ProbeTypeHTTP, Target: "ftp://example.com"},
+		{Name: "regex", Type: ProbeTypeHTTP, Target: "https://example.com", BodyMatch: "("},
+		{Name: "status", Type: ProbeTypeHTTP, Target: "https://example.com", ExpectedStatus: 42},
+		{Name: "port", Type: ProbeTypeTCP, Target: "db.internal"},
+		{Name: "host", Type: ProbeTypeDNS, Target: "http://example.com"},
+		{Name: "type", Type: "icmp", Target: "example.com"},
+	}
+	for _, probe := range invalid {
+		assert.Error(t, ValidateProbe(&probe), probe.Name)
+	}
+}
+
+func TestIsProbeMetricType(t *testing.T) {
+	for _, metricType := range ProbeMetricTypes() {
+		assert.True(t, IsProbeMetricType(metricType))
+		assert.True(t, IsProbeMetricType(strings.ToUpper(metricType)))
+	}
+	assert.False(t, IsProbeMetricType("cpu"))
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// Process watch metric types evaluated against the process collector's data
+const (
+	MetricProcessCount    = "process_count"    // Number of matching processes
+	MetricProcessCPU      = "process_cpu"      // Highest CPU usage (%) among matching processes
+	MetricProcessRSS      = "process_rss"      // Highest resident memory (MB) among matching processes
+	MetricProcessRestarts = "process_restarts" // PID changes within the alert window
+	MetricProcessUptime   = "process_uptime"   // Longest running time (seconds) among matching processes
+)
+
+// defaultProcessWindow is used for restart counting when an alert has no window
+const defaultProcessWindow = 5 * time.Minute
+
+// ProcessMetricTypes returns all process watch metric types
+func ProcessMetricTypes() []string {
+	return []string{
+		MetricProcessCount,
+		MetricProcessCPU,
+		MetricProcessRSS,
+		MetricProcessRestarts,
+		MetricProcessUptime,
+	}
+}
+
+// IsProcessMetricType reports whether a metric type targets processes
+func IsProcessMetricType(metricType string) bool {
+	for _, t := range ProcessMetricTypes() {
+		if strings.EqualFold(t, metricType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessMatcher matches processes by exact name or regular expression
+type ProcessMatcher struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+// NewProcessMatcher creates a matcher for a process name or regex pattern
+func NewProcessMatcher(pattern string, isRegex bool) (*ProcessMatcher, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("process pattern is required for process metric types")
+	}
+
+	if !isRegex {
+		return &ProcessMatcher{name: pattern}, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid process regex %q: %w", pattern, err)
+	}
+	return &ProcessMatcher{regex: re}, nil
+}
+
+// Match reports whether a process name matches
+func (m *ProcessMatcher) Match(name string) bool {
+	if m.regex != nil {
+		return m.regex.MatchString(name)
+	}
+	// Names are compared case-insensitively and ignore a Windows ".exe" suffix
+	return strings.EqualFold(strings.TrimSuffix(strings.ToLower(name), ".exe"), strings.TrimSuffix(strings.ToLower(m.name), ".exe"))
+}
+
+// processWatchState tracks the PIDs seen for a process watch alert on a host
+type processWatchState struct {
+	pids     map[int32]bool
+	seen     bool
+	restarts []time.Time
+}
+
+// evaluateProcessMetric computes the value of a process watch alert and the PID it refers to
+func (as *AlertService) evaluateProcessMetric(alert *models.Alert, metrics *models.SystemMetrics) (float64, int32, error) {
+	matcher, err := NewProcessMatcher(alert.ProcessPattern, alert.ProcessRegex)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var matched []models.ProcessInfo
+	for _, proc := range metrics.Processes.Processes {
+		if matcher.Match(proc.Name) {
+			matched = append(matched, proc)
+		}
+	}
+
+	switch strings.ToLower(alert.MetricType) {
+	case MetricProcessCount:
+		var pid int32
+		if len(matched) > 0 {
+			pid = matched[0].PID
+		}
+		return float64(len(matched)), pid, nil
+
+	case MetricProcessCPU:
+		var value float64
+		var pid int32
+		for _, proc := range matched {
+			if pid == 0 || proc.CPUPercent > value {
+				value, pid = proc.CPUPercent, proc.PID
+			}
+		}
+		return value, pid, nil
+
+	case MetricProcessRSS:
+		var value uint64
+		var pid int32
+		for _, proc := range matched {
+			if pid == 0 || proc.MemoryBytes > value {
+				value, pid = proc.MemoryBytes, proc.PID
+			}
+		}
+		return float64(value) / (1024 * 1024), pid, nil
+
+	case MetricProcessUptime:
+		var value float64
+		var pid int32
+		for _, proc := range matched {
+			if proc.StartTime.IsZero() {
+				continue
+			}
+			uptime := metrics.Timestamp.Sub(proc.StartTime).Seconds()
+			if pid == 0 || uptime > value {
+				value, pid = uptime, proc.PID
+			}
+		}
+		return value, pid, nil
+
+	case MetricProcessRestarts:
+		window := defaultProcessWindow
+		if alert.Window > 0 {
+			window = time.Duration(alert.Window) * time.Second
+		}
+		key := fmt.Sprintf("%d_%s", alert.ID, metrics.Hostname)
+		restarts, pid := as.trackProcessRestarts(key, matched, metrics.Timestamp, window)
+		return float64(restarts), pid, nil
+
+	default:
+		return 0, 0, fmt.Errorf("unsupported process metric type: %s", alert.MetricType)
+	}
+}
+
+// trackProcessRestarts records PID changes for an alert key and returns the
+// number of restarts within the window along with the newest matching PID.
+// A restart is a PID that disappeared and was replaced by a new one, or a
+// process that comes back after being absent.
+func (as *AlertService) trackProcessRestarts(key string, matched []models.ProcessInfo, now time.Time, window time.Duration) (int, int32) {
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	state, exists := as.processWatch[key]
+	if !exists {
+		state = &processWatchState{pids: make(map[int32]bool)}
+		as.processWatch[key] = state
+	}
+
+	current := make(map[int32]bool, len(matched))
+	var newestPID int32
+	var newestStart time.Time
+	appeared := 0
+	for _, proc := range matched {
+		current[proc.PID] = true
+		if !state.pids[proc.PID] {
+			appeared++
+		}
+		if newestPID == 0 || proc.StartTime.After(newestStart) {
+			newestPID, newestStart = proc.PID, proc.StartTime
+		}
+	}
+
+	disappeared := 0
+	for pid := range state.pids {
+		if !current[pid] {
+			disappeared++
+		}
+	}
+
+	if state.seen {
+		restarts := appeared
+		if len(state.pids) > 0 && disappeared < restarts {
+			restarts = disappeared
+		}
+		for i := 0; i < restarts; i++ {
+			state.restarts = append(state.restarts, now)
+		}
+	}
+
+	// Drop restarts that fall outside the window
+	cutoff := now.Add(-window)
+	kept := state.restarts[:0]
+	for _, t := range state.restarts {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	state.restarts = kept
+
+	state.pids = current
+	state.seen = state.seen || len(current) > 0
+
+	return len(state.restarts), newestPID
+}
+
+// generateProcessAlertMessage generates a human-readable message for process watch alerts
+func (as *AlertService) generateProcessAlertMessage(alert *models.Alert, value float64, pid int32, hostname string) string {
+	var subject string
+	switch strings.ToLower(alert.MetricType) {
+	case MetricProcessCount:
+		subject = fmt.Sprintf("Process count %s %.0f (threshold: %.0f)", alert.Condition, value, alert.Threshold)
+	case MetricProcessCPU:
+		subject = fmt.Sprintf("Process CPU %s %.2f%% (threshold: %.2f%%)", alert.Condition, value, alert.Threshold)
+	case MetricProcessRSS:
+		subject = fmt.Sprintf("Process RSS %s %.2f MB (threshold: %.2f MB)", alert.Condition, value, alert.Threshold)
+	case MetricProcessRestarts:
+		subject = fmt.Sprintf("Process restarts %s %.0f (threshold: %.0f)", alert.Condition, value, alert.Threshold)
+	case MetricProcessUptime:
+		subject = fmt.Sprintf("Process uptime %s %s (threshold: %s)", alert.Condition,
+			time.Duration(value*float64(time.Second)).Round(time.Second),
+			time.Duration(alert.Threshold*float64(time.Second)).Round(time.Second))
+	default:
+		subject = fmt.Sprintf("%s %s %.2f (threshold: %.2f)", alert.MetricType, alert.Condition, value, alert.Threshold)
+	}
+
+	target := fmt.Sprintf("'%s'", alert.ProcessPattern)
+	if pid > 0 {
+		target = fmt.Sprintf("'%s' (PID %d)", alert.ProcessPattern, pid)
+	}
+
+	return fmt.Sprintf("%s for %s on %s", subject, target, hostname)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	valid := []string{"* * * * *", "0 2 * * sun", "*/15 9-17 * * mon-fri", "0 0 1,15 * *", "@daily", "30 4 * jan-mar 7"}
+	for _, spec := range valid {
+		_, err := ParseCronSchedule(spec)
+		assert.NoError(t, err, spec)
+	}
+
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "x * * * *"}
+	for _, spec := range invalid {
+		_, err := ParseCronSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCronSchedule_Matches(t *testing.T) {
+	schedule, err := ParseCronSchedule("*/15 9-17 * * mon-fri")
+	require.NoError(t, err)
+
+	// 2024-01-01 is a Monday
+	assert.True(t, schedule.Matches(time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)))
+	assert.False(t, schedule.Matches(time.Date(2024, 1, 1, 9, 31, 0, 0, time.UTC)))
+	assert.False(t, schedule.Matches(time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.Matches(time.Date(2024, 1, 6, 9, 30, 0, 0, time.UTC)))
+
+	// Sunday as 7, and either day field matching when both are restricted
+	sunday, err := ParseCronSchedule("0 0 1 * 7")
+	require.NoError(t, err)
+	assert.True(t, sunday.Matches(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, sunday.Matches(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, sunday.Matches(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCronSchedule_LastStartAndNext(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 2 * * *")
+	require.NoError(t, err)
+
+	start, ok := schedule.LastStart(time.Date(2024, 1, 1, 2, 45, 0, 0, time.UTC), time.Hour)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), start)
+
+	_, ok = schedule.LastStart(time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), time.Hour)
+	assert.False(t, ok)
+
+	next, ok := schedule.Next(time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC), next)
+}
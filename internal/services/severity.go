@@ -0,0 +1,155 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+// severityRank orders alert severities from info to critical
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 3
+	case "warning":
+		return 2
+	case "info":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ValidateSeverityLevels validates the levels of a multi-level alert and normalizes their
+// severities. Levels must be ordered from least to most severe, with thresholds moving in
+// the direction of the condition, e.g. ascending for greater-than conditions.
+func ValidateSeverityLevels(condition string, levels []models.SeverityLevel) error {
+	if len(levels) == 0 {
+		return nil
+	}
+
+	ascending := true
+	switch strings.ToLower(strings.TrimSpace(condition)) {
+	case ">", ">=", "gt", "gte":
+	case "<", "<=", "lt", "lte":
+		ascending = false
+	default:
+		return fmt.Errorf("severity levels require a >, >=, < or <= condition")
+	}
+
+	for i := range levels {
+		level := &levels[i]
+		level.Severity = strings.ToLower(strings.TrimSpace(level.Severity))
+		if severityRank(level.Severity) == 0 {
+			return fmt.Errorf("level %d: invalid severity %q. Valid severities: info, warning, critical", i+1, level.Severity)
+		}
+		if i == 0 {
+			continue
+		}
+
+		previous := levels[i-1]
+		if severityRank(level.Severity) <= severityRank(previous.Severity) {
+			return fmt.Errorf("level %d: levels must be ordered from least to most severe", i+1)
+		}
+		if ascending && level.Threshold <= previous.Threshold {
+			return fmt.Errorf("level %d: thresholds must increase with severity for %s conditions", i+1, condition)
+		}
+		if !ascending && level.Threshold >= previous.Threshold {
+			return fmt.Errorf("level %d: thresholds must decrease with severity for %s conditions", i+1, condition)
+		}
+	}
+	return nil
+}
+
+// alertSeverity returns the severity of the highest level of an alert a value meets, or the
+// severity of the alert when it has no levels or the value meets none of them
+func (as *AlertService) alertSeverity(alert *models.Alert, value float64) string {
+	for i := len(alert.Levels) - 1; i >= 0; i-- {
+		if met, err := as.evaluateCondition(alert.Condition, value, alert.Levels[i].Threshold); err == nil && met {
+			return alert.Levels[i].Severity
+		}
+	}
+	return alert.Severity
+}
+
+// nextSeverity returns the severity of a firing instance after an evaluation. A level change
+// only takes effect once the value held the new level for the alert duration, so values
+// oscillating around a level threshold don't move the incident back and forth.
+// Callers must hold as.mutex.
+func (as *AlertService) nextSeverity(alert *models.Alert, instance *models.AlertInstance, value float64, now time.Time) string {
+	severity := as.alertSeverity(alert, value)
+	if instance.State != AlertStateFiring || instance.Severity == "" || severity == instance.Severity {
+		instance.PendingSeverity = ""
+		instance.PendingSeveritySince = time.Time{}
+		return severity
+	}
+
+	if severity != instance.PendingSeverity {
+		instance.PendingSeverity = severity
+		instance.PendingSeveritySince = now
+	}
+	if now.Sub(instance.PendingSeveritySince) < time.Duration(alert.Duration)*time.Second {
+		return instance.Severity
+	}
+
+	instance.PendingSeverity = ""
+	instance.PendingSeveritySince = time.Time{}
+	return severity
+}
+
+// alertAtSeverity returns a copy of an alert with the severity and threshold of its level at
+// severity, so incidents and notifications describe that level
+func alertAtSeverity(alert *models.Alert, severity string) *models.Alert {
+	if severity == "" || severity == alert.Severity {
+		return alert
+	}
+	for _, level := range alert.Levels {
+		if level.Severity == severity {
+			leveled := *alert
+			leveled.Severity = level.Severity
+			leveled.Threshold = level.Threshold
+			return &leveled
+		}
+	}
+	return alert
+}
+
+// changeIncidentSeverity moves a firing incident to the severity of its alert and, when
+// notify is set, notifies about the escalation or de-escalation
+func (as *AlertService) changeIncidentSeverity(alert *models.Alert, instance *models.AlertInstance, previous, message string, extra map[string]interface{}, notify bool) {
+	history, err := as.alertRepo.GetIncident(instance.HistoryID)
+	if err != nil {
+		log.Printf("❌ Failed to load incident %d: %v", instance.HistoryID, err)
+		return
+	}
+
+	direction := "escalated"
+	if severityRank(alert.Severity) < severityRank(previous) {
+		direction = "de-escalated"
+	}
+	change := fmt.Sprintf("%s to %s", direction, alert.Severity)
+
+	history.Severity = alert.Severity
+	history.Threshold = alert.Threshold
+	history.MetricValue = instance.LastValue
+	history.PeakValue = instance.PeakValue
+	history.Message = fmt.Sprintf("%s%s: %s", strings.ToUpper(change[:1]), change[1:], message)
+	if err := as.alertRepo.UpdateAlertHistory(history); err != nil {
+		log.Printf("❌ Failed to update alert history: %v", err)
+	}
+
+	log.Printf("📈 Alert %d (%s) on %s %s from %s", alert.ID, alert.Name, instance.Hostname, change, previous)
+	as.recordIncidentEvent(history.ID, IncidentEventSeverity, SystemUser, fmt.Sprintf("%s from %s", change, previous))
+	if !notify {
+		return
+	}
+
+	eventExtra := map[string]interface{}{"previous_severity": previous}
+	for key, value := range extra {
+		eventExtra[key] = value
+	}
+	as.emitAlertEvent(&AlertEvent{Type: AlertEventFiring, Alert: alert, Instance: *instance, History: history, Extra: eventExtra})
+}
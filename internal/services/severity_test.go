@@ -0,0 +1,154 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func TestValidateSeverityLevels(t *testing.T) {
+	levels := []models.SeverityLevel{{Severity: " Warning", Threshold: 80}, {Severity: "critical", Threshold: 95}}
+	require.NoError(t, ValidateSeverityLevels(">", levels))
+	assert.Equal(t, "warning", levels[0].Severity)
+	require.NoError(t, ValidateSeverityLevels("<", []models.SeverityLevel{{Severity: "warning", Threshold: 20}, {Severity: "critical", Threshold: 5}}))
+
+	tests := []struct {
+		name      string
+		condition string
+		levels    []models.SeverityLevel
+	}{
+		{"equality condition", "==", []models.SeverityLevel{{Severity: "warning", Threshold: 80}}},
+		{"unknown severity", ">", []models.SeverityLevel{{Severity: "page", Threshold: 80}}},
+		{"severity order", ">", []models.SeverityLevel{{Severity: "critical", Threshold: 80}, {Severity: "warning", Threshold: 95}}},
+		{"repeated severity", ">", []models.SeverityLevel{{Severity: "warning", Threshold: 80}, {Severity: "warning", Threshold: 95}}},
+		{"threshold order", ">", []models.SeverityLevel{{Severity: "warning", Threshold: 95}, {Severity: "critical", Threshold: 80}}},
+		{"threshold order below", "<", []models.SeverityLevel{{Severity: "warning", Threshold: 5}, {Severity: "critical", Threshold: 20}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, ValidateSeverityLevels(tt.condition, tt.levels))
+		})
+	}
+}
+
+// severityRecorder records the severities of broadcast alert events
+type severityRecorder struct {
+	events []string
+}
+
+func (r *severityRecorder) BroadcastAlertEvent(eventType string, data map[string]interface{}) {
+	r.events = append(r.events, eventType+" "+data["severity"].(string))
+}
+
+func TestAlertService_SeverityLevels(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	alert.Severity = "warning"
+	alert.Levels = []models.SeverityLevel{{Severity: "warning", Threshold: 80}, {Severity: "critical", Threshold: 95}}
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+	as.config.CooldownPeriod = 0
+	recorder := &severityRecorder{}
+	as.SetWebSocketHandler(recorder)
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 85, "cpu at 85", nil))
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 97, "cpu at 97", nil))
+
+	// The incident escalates in place
+	history := repo.historySnapshot()
+	require.Len(t, history, 1)
+	assert.Equal(t, "critical", history[0].Severity)
+	assert.Equal(t, 95.0, history[0].Threshold)
+	assert.Equal(t, "Escalated to critical: cpu at 97", history[0].Message)
+	assert.Equal(t, "critical", as.instances[key].Severity)
+
+	// Staying at a level does not notify again within the cooldown
+	as.config.CooldownPeriod = time.Hour
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 98, "cpu at 98", nil))
+	as.config.CooldownPeriod = 0
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "cpu at 90", nil))
+	history = repo.historySnapshot()
+	assert.Equal(t, "warning", history[0].Severity)
+	assert.Equal(t, "De-escalated to warning: cpu at 90", history[0].Message)
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 10, "", nil))
+	assert.Equal(t, []string{
+		AlertEventFiring + " warning", AlertEventFiring + " critical",
+		AlertEventFiring + " warning", AlertEventResolved + " warning",
+	}, recorder.events)
+
+	events, err := repo.GetIncidentEvents(history[0].ID)
+	require.NoError(t, err)
+	var changes []string
+	for _, event := range events {
+		if event.Type == IncidentEventSeverity {
+			changes = append(changes, event.Comment)
+		}
+	}
+	assert.Equal(t, []string{"escalated to critical from warning", "de-escalated to warning from critical"}, changes)
+}
+
+func TestAlertService_SeverityLevelOscillation(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	alert.Severity = "warning"
+	alert.Levels = []models.SeverityLevel{{Severity: "warning", Threshold: 80}, {Severity: "critical", Threshold: 95}}
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+	recorder := &severityRecorder{}
+	as.SetWebSocketHandler(recorder)
+	key := alertInstanceKey(alert.ID, "web-1")
+
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 85, "", nil))
+	alert.Duration = 60
+
+	// Oscillating across the critical threshold never holds the new level
+	for i := 0; i < 5; i++ {
+		require.NoError(t, as.evaluateAlertValue(alert, "web-1", 97, "", nil))
+		require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	}
+	assert.Equal(t, []string{AlertEventFiring + " warning"}, recorder.events)
+	assert.Equal(t, "warning", repo.historySnapshot()[0].Severity)
+
+	// Holding the level for the alert duration escalates, even within the cooldown
+	holdLevel := func(value float64) {
+		require.NoError(t, as.evaluateAlertValue(alert, "web-1", value, "", nil))
+		as.instances[key].PendingSeveritySince = time.Now().Add(-time.Minute)
+		require.NoError(t, as.evaluateAlertValue(alert, "web-1", value, "", nil))
+	}
+	holdLevel(97)
+	assert.Equal(t, "critical", as.instances[key].Severity)
+	assert.Equal(t, "critical", repo.historySnapshot()[0].Severity)
+	assert.Equal(t, []string{AlertEventFiring + " warning", AlertEventFiring + " critical"}, recorder.events)
+
+	// De-escalations within the cooldown change the level silently
+	holdLevel(90)
+	assert.Equal(t, "warning", as.instances[key].Severity)
+	assert.Len(t, recorder.events, 2)
+
+	// After the cooldown they notify
+	holdLevel(97)
+	as.instances[key].PendingSeverity = "warning"
+	as.instances[key].PendingSeveritySince = time.Now().Add(-time.Minute)
+	as.instances[key].LastNotifiedAt = time.Now().Add(-as.config.CooldownPeriod)
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	assert.Equal(t, []string{
+		AlertEventFiring + " warning", AlertEventFiring + " critical",
+		AlertEventFiring + " critical", AlertEventFiring + " warning",
+	}, recorder.events)
+
+	// Acknowledged incidents still notify escalations, but not de-escalations
+	as.instances[key].Acknowledged = true
+	holdLevel(97)
+	assert.Len(t, recorder.events, 5)
+	as.instances[key].LastNotifiedAt = time.Now().Add(-as.config.CooldownPeriod)
+	holdLevel(90)
+	assert.Equal(t, "warning", as.instances[key].Severity)
+	assert.Len(t, recorder.events, 5)
+}
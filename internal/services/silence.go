@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/eyzaun/godash/internal/models"
+	"github.com/eyzaun/godash/internal/repository"
+)
+
+// Silence statuses
+const (
+	SilenceStatusPending   = "pending"   // Not started yet
+	SilenceStatusActive    = "active"    // Suppressing notifications now
+	SilenceStatusScheduled = "scheduled" // Recurring, between maintenance windows
+	SilenceStatusExpired   = "expired"   // Ended
+)
+
+// MaxMaintenanceWindow bounds the length of recurring maintenance windows
+const MaxMaintenanceWindow = 7 * 24 * time.Hour
+
+// ValidateSilence validates a silence and fills in defaults
+func ValidateSilence(silence *models.Silence) error {
+	if len(silence.Matchers) == 0 {
+		return fmt.Errorf("at least one matcher is required")
+	}
+
+	if err := validateMatchers(silence.Matchers); err != nil {
+		return err
+	}
+
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = time.Now()
+	}
+
+	if silence.Schedule == "" {
+		if silence.EndsAt.IsZero() || !silence.EndsAt.After(silence.StartsAt) {
+			return fmt.Errorf("ends_at must be after starts_at")
+		}
+		return nil
+	}
+
+	if _, err := ParseCronSchedule(silence.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	if silence.Duration <= 0 {
+		return fmt.Errorf("maintenance windows need a positive duration")
+	}
+	if time.Duration(silence.Duration)*time.Second > MaxMaintenanceWindow {
+		return fmt.Errorf("maintenance windows cannot be longer than %s", MaxMaintenanceWindow)
+	}
+	if _, err := time.LoadLocation(silence.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+	if !silence.EndsAt.IsZero() && !silence.EndsAt.After(silence.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+	return nil
+}
+
+// SilenceStatus returns whether a silence is pending, active, scheduled or expired at now
+func SilenceStatus(silence *models.Silence, now time.Time) string {
+	switch {
+	case now.Before(silence.StartsAt):
+		return SilenceStatusPending
+	case silence.Schedule == "" && !now.Before(silence.EndsAt):
+		return SilenceStatusExpired
+	case silence.Schedule != "" && !silence.EndsAt.IsZero() && !now.Before(silence.EndsAt):
+		return SilenceStatusExpired
+	case silence.Schedule != "" && !inMaintenanceWindow(silence, now):
+		return SilenceStatusScheduled
+	default:
+		return SilenceStatusActive
+	}
+}
+
+// SilenceActive reports whether a silence suppresses notifications at now
+func SilenceActive(silence *models.Silence, now time.Time) bool {
+	return SilenceStatus(silence, now) == SilenceStatusActive
+}
+
+// inMaintenanceWindow reports whether now falls inside one of a recurring silence's windows
+func inMaintenanceWindow(silence *models.Silence, now time.Time) bool {
+	schedule, err := ParseCronSchedule(silence.Schedule)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(silence.Timezone)
+	if err != nil {
+		return false
+	}
+
+	_, ok := schedule.LastStart(now.In(location), time.Duration(silence.Duration)*time.Second)
+	return ok
+}
+
+// SilenceMatches reports whether all matchers of a silence match an alert on a host
+func SilenceMatches(silence *models.Silence, alert *models.Alert, hostname string) bool {
+	return MatchersMatch(silence.Matchers, alert, hostname)
+}
+
+// SetSilenceRepository sets the repository silences are loaded from
+func (as *AlertService) SetSilenceRepository(silenceRepo repository.SilenceRepository) {
+	as.mutex.Lock()
+	as.silenceRepo = silenceRepo
+	as.mutex.Unlock()
+
+	as.ReloadSilences()
+}
+
+// ReloadSilences refreshes the cached silences; call it after silences change
+func (as *AlertService) ReloadSilences() {
+	as.mutex.RLock()
+	silenceRepo := as.silenceRepo
+	as.mutex.RUnlock()
+	if silenceRepo == nil {
+		return
+	}
+
+	silences, err := silenceRepo.GetCurrentSilences(time.Now())
+	if err != nil {
+		log.Printf("❌ Failed to load silences: %v", err)
+		return
+	}
+
+	as.mutex.Lock()
+	as.silences = silences
+	as.mutex.Unlock()
+}
+
+// matchingSilence returns the active silence covering an alert on a host, if any
+func (as *AlertService) matchingSilence(alert *models.Alert, hostname string, now time.Time) *models.Silence {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	for _, silence := range as.silences {
+		if SilenceActive(silence, now) && SilenceMatches(silence, alert, hostname) {
+			return silence
+		}
+	}
+	return nil
+}
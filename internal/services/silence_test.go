@@ -0,0 +1,115 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eyzaun/godash/internal/models"
+)
+
+func TestValidateSilence(t *testing.T) {
+	now := time.Now()
+
+	silence := &models.Silence{
+		Matchers: []models.AlertMatcher{{Name: " hostname ", Value: "web-1"}},
+		EndsAt:   now.Add(time.Hour),
+	}
+	require.NoError(t, ValidateSilence(silence))
+	assert.Equal(t, "hostname", silence.Matchers[0].Name)
+	assert.False(t, silence.StartsAt.IsZero())
+
+	tests := []struct {
+		name    string
+		silence models.Silence
+	}{
+		{"no matchers", models.Silence{EndsAt: now.Add(time.Hour)}},
+		{"bad regex", models.Silence{Matchers: []models.AlertMatcher{{Name: "hostname", Value: "(", IsRegex: true}}, EndsAt: now.Add(time.Hour)}},
+		{"no end", models.Silence{Matchers: []models.AlertMatcher{{Name: "hostname", Value: "web-1"}}}},
+		{"bad schedule", models.Silence{Matchers: []models.AlertMatcher{{Name: "hostname", Value: "web-1"}}, Schedule: "0 2 * *", Duration: 3600}},
+		{"no duration", models.Silence{Matchers: []models.AlertMatcher{{Name: "hostname", Value: "web-1"}}, Schedule: "0 2 * * sun"}},
+		{"bad timezone", models.Silence{Matchers: []models.AlertMatcher{{Name: "hostname", Value: "web-1"}}, Schedule: "0 2 * * sun", Duration: 3600, Timezone: "Mars/Olympus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, ValidateSilence(&tt.silence))
+		})
+	}
+
+	// Recurring windows may run forever
+	window := &models.Silence{
+		Matchers: []models.AlertMatcher{{Name: "hostname", Value: "db-1"}},
+		Schedule: "0 2 * * sun",
+		Duration: 7200,
+		Timezone: "UTC",
+	}
+	assert.NoError(t, ValidateSilence(window))
+}
+
+func TestSilenceStatus(t *testing.T) {
+	now := time.Date(2024, 1, 7, 2, 30, 0, 0, time.UTC) // Sunday
+
+	oneOff := &models.Silence{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	assert.Equal(t, SilenceStatusActive, SilenceStatus(oneOff, now))
+	assert.Equal(t, SilenceStatusPending, SilenceStatus(oneOff, now.Add(-2*time.Hour)))
+	assert.Equal(t, SilenceStatusExpired, SilenceStatus(oneOff, now.Add(time.Hour)))
+
+	window := &models.Silence{StartsAt: now.AddDate(0, -1, 0), Schedule: "0 2 * * sun", Duration: 3600, Timezone: "UTC"}
+	assert.Equal(t, SilenceStatusActive, SilenceStatus(window, now))
+	assert.Equal(t, SilenceStatusScheduled, SilenceStatus(window, now.Add(time.Hour)))
+
+	window.EndsAt = now.Add(-time.Minute)
+	assert.Equal(t, SilenceStatusExpired, SilenceStatus(window, now))
+}
+
+func TestSilenceMatches(t *testing.T) {
+	alert := &models.Alert{Name: "High CPU", Severity: "critical", Labels: map[string]string{"team": "infra"}}
+
+	silence := &models.Silence{Matchers: []models.AlertMatcher{
+		{Name: MatchAlertName, Value: "High CPU"},
+		{Name: MatchHostname, Value: "web-.*", IsRegex: true},
+		{Name: "team", Value: "infra"},
+	}}
+	assert.True(t, SilenceMatches(silence, alert, "web-1"))
+	assert.False(t, SilenceMatches(silence, alert, "db-1"))
+	// Regexes are anchored
+	assert.False(t, SilenceMatches(silence, alert, "old-web-1"))
+
+	silence.Matchers = append(silence.Matchers, models.AlertMatcher{Name: MatchSeverity, Value: "warning"})
+	assert.False(t, SilenceMatches(silence, alert, "web-1"))
+}
+
+// silenceRecorder records whether broadcast alert events were silenced
+type silenceRecorder struct {
+	silenced []bool
+}
+
+func (r *silenceRecorder) BroadcastAlertEvent(eventType string, data map[string]interface{}) {
+	r.silenced = append(r.silenced, data["silenced"].(bool))
+}
+
+func TestAlertService_SilencedAlertStillFires(t *testing.T) {
+	alert := newDurationAlert()
+	alert.Duration = 0
+	repo := newFakeAlertRepo(alert)
+	as := NewAlertService(nil, repo, nil, nil)
+	recorder := &silenceRecorder{}
+	as.SetWebSocketHandler(recorder)
+
+	now := time.Now()
+	as.silences = []*models.Silence{{
+		BaseModel: models.BaseModel{ID: 7},
+		Matchers:  []models.AlertMatcher{{Name: MatchHostname, Value: "web-1"}},
+		StartsAt:  now.Add(-time.Minute),
+		EndsAt:    now.Add(time.Hour),
+	}}
+
+	// The silenced host still records a firing incident
+	require.NoError(t, as.evaluateAlertValue(alert, "web-1", 90, "", nil))
+	require.NoError(t, as.evaluateAlertValue(alert, "web-2", 90, "", nil))
+	assert.Len(t, repo.historySnapshot(), 2)
+	assert.Equal(t, AlertStateFiring, as.instances[alertInstanceKey(alert.ID, "web-1")].State)
+	assert.Equal(t, []bool{true, false}, recorder.silenced)
+}
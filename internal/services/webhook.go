@@ -2,9 +2,13 @@ package services
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,11 +20,25 @@ import (
 
 // WebhookSender interface for sending webhook notifications
 type WebhookSender interface {
-	SendAlert(alert *models.Alert, history *models.AlertHistory) error
+	SendAlert(alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error
+	SendAlertTo(channel *models.NotificationChannel, alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error
 	SendTestWebhook(url string, payload map[string]interface{}) error
 	ValidateConfiguration() error
 }
 
+// Signed webhook requests carry the Unix time they were sent at and the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>", keyed with the channel secret and prefixed with sha256=
+const (
+	WebhookTimestampHeader = "X-GoDash-Timestamp"
+	WebhookSignatureHeader = "X-GoDash-Signature"
+)
+
+// Webhook authentication types
+const (
+	WebhookAuthBasic  = "basic"
+	WebhookAuthBearer = "bearer"
+)
+
 // HTTPWebhookSender implements WebhookSender using HTTP client
 type HTTPWebhookSender struct {
 	client     *http.Client
@@ -51,16 +69,19 @@ type WebhookPayload struct {
 
 // AlertWebhookData represents alert data in webhook payload
 type AlertWebhookData struct {
-	ID           uint      `json:"id"`
-	Name         string    `json:"name"`
-	MetricType   string    `json:"metric_type"`
-	Condition    string    `json:"condition"`
-	Threshold    float64   `json:"threshold"`
-	CurrentValue float64   `json:"current_value"`
-	Severity     string    `json:"severity"`
-	Message      string    `json:"message"`
-	Hostname     string    `json:"hostname"`
-	TriggeredAt  time.Time `json:"triggered_at"`
+	ID           uint       `json:"id"`
+	Name         string     `json:"name"`
+	MetricType   string     `json:"metric_type"`
+	Condition    string     `json:"condition"`
+	Threshold    float64    `json:"threshold"`
+	CurrentValue float64    `json:"current_value"`
+	Severity     string     `json:"severity"`
+	Message      string     `json:"message"`
+	Hostname     string     `json:"hostname"`
+	TriggeredAt  time.Time  `json:"triggered_at"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+	PeakValue    float64    `json:"peak_value"`
+	Duration     float64    `json:"duration_seconds,omitempty"` // How long a resolved incident was firing
 }
 
 // SystemWebhookData represents system data in webhook payload
@@ -125,8 +146,10 @@ type DiscordEmbedFooter struct {
 	Text string `json:"text"`
 }
 
-// SendAlert sends webhook notification for an alert
-func (w *HTTPWebhookSender) SendAlert(alert *models.Alert, history *models.AlertHistory) error {
+// SendAlert sends webhook notification for an alert. A rendered message replaces the built-in
+// payload with its body, or the Slack text or Discord content with its text; nil keeps the
+// built-in payload.
+func (w *HTTPWebhookSender) SendAlert(alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error {
 	if w.config == nil {
 		return fmt.Errorf("webhook configuration is not available")
 	}
@@ -135,14 +158,37 @@ func (w *HTTPWebhookSender) SendAlert(alert *models.Alert, history *models.Alert
 		return fmt.Errorf("webhook not enabled or URL not configured for alert: %s", alert.Name)
 	}
 
-	// Detect webhook type and create appropriate payload
-	payload, err := w.createPayload(alert, history)
+	channel := &models.NotificationChannel{
+		Type:   DetectWebhookFormat(alert.WebhookURL),
+		Config: models.NotificationChannelConfig{URL: alert.WebhookURL},
+	}
+	return w.SendAlertTo(channel, alert, history, message)
+}
+
+// SendAlertTo sends webhook notification for an alert to a webhook, Slack or Discord channel
+// in the payload format of its type, applying the request settings of webhook channels
+func (w *HTTPWebhookSender) SendAlertTo(channel *models.NotificationChannel, alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) error {
+	if w.config == nil {
+		return fmt.Errorf("webhook configuration is not available")
+	}
+
+	url := channel.Config.URL
+	if url == "" {
+		return fmt.Errorf("webhook URL not configured")
+	}
+
+	if message != nil && message.Body != "" {
+		return w.sendWithRetry(url, json.RawMessage(message.Body), &channel.Config)
+	}
+
+	// Create the payload for the webhook type
+	payload, err := w.createPayload(url, channel.Type, alert, history, message)
 	if err != nil {
 		return fmt.Errorf("failed to create webhook payload: %w", err)
 	}
 
 	// Send webhook with retry mechanism
-	return w.sendWithRetry(alert.WebhookURL, payload)
+	return w.sendWithRetry(url, payload, &channel.Config)
 }
 
 // SendTestWebhook sends a test webhook
@@ -166,7 +212,7 @@ func (w *HTTPWebhookSender) SendTestWebhook(url string, payload map[string]inter
 		testPayload[k] = v
 	}
 
-	return w.sendWebhook(url, testPayload)
+	return w.sendWebhook(url, testPayload, nil)
 }
 
 // ValidateConfiguration validates webhook configuration
@@ -186,25 +232,58 @@ func (w *HTTPWebhookSender) ValidateConfiguration() error {
 	return nil
 }
 
-// createPayload creates webhook payload based on URL type
-func (w *HTTPWebhookSender) createPayload(alert *models.Alert, history *models.AlertHistory) (interface{}, error) {
-	url := strings.ToLower(alert.WebhookURL)
+// createPayload creates webhook payload for a format, detecting it from the URL when empty.
+// The text of a rendered message replaces the Slack text or Discord content.
+func (w *HTTPWebhookSender) createPayload(url, format string, alert *models.Alert, history *models.AlertHistory, message *NotificationMessage) (interface{}, error) {
+	if format == "" {
+		format = DetectWebhookFormat(url)
+	}
+
+	switch format {
+	case ChannelTypeSlack:
+		payload := w.createSlackPayload(alert, history)
+		if message != nil && message.Text != "" {
+			payload.Text = message.Text
+		}
+		return payload, nil
+	case ChannelTypeDiscord:
+		payload := w.createDiscordPayload(alert, history)
+		if message != nil && message.Text != "" {
+			payload.Content = message.Text
+		}
+		return payload, nil
+	case ChannelTypeWebhook:
+		return w.createGenericPayload(alert, history), nil
+	default:
+		return nil, fmt.Errorf("unsupported webhook format: %s", format)
+	}
+}
+
+// DetectWebhookFormat detects the payload format of a webhook URL: slack, discord or webhook
+func DetectWebhookFormat(url string) string {
+	url = strings.ToLower(url)
 
-	// Detect webhook type
 	switch {
 	case strings.Contains(url, "slack.com"):
-		return w.createSlackPayload(alert, history), nil
+		return ChannelTypeSlack
 	case strings.Contains(url, "discord.com") || strings.Contains(url, "discordapp.com"):
-		return w.createDiscordPayload(alert, history), nil
+		return ChannelTypeDiscord
 	default:
-		return w.createGenericPayload(alert, history), nil
+		return ChannelTypeWebhook
 	}
 }
 
 // createGenericPayload creates a generic webhook payload
 func (w *HTTPWebhookSender) createGenericPayload(alert *models.Alert, history *models.AlertHistory) WebhookPayload {
+	eventType := AlertEventFiring
+	var resolvedAt *time.Time
+	if history.Resolved {
+		eventType = AlertEventResolved
+		resolvedAt = &history.ResolvedAt
+	}
+
 	return WebhookPayload{
-		Type:      "alert_triggered",
+		Type:      eventType,
 		Timestamp: time.Now(),
 		Alert: AlertWebhookData{
 			ID:           alert.ID,
@@ -217,6 +296,9 @@ func (w *HTTPWebhookSender) createGenericPayload(alert *models.Alert, history *m
 			Message:      history.Message,
 			Hostname:     history.Hostname,
 			TriggeredAt:  history.CreatedAt,
+			ResolvedAt:   resolvedAt,
+			PeakValue:    history.PeakValue,
+			Duration:     incidentDuration(history).Seconds(),
 		},
 		System: SystemWebhookData{
 			Hostname:     history.Hostname,
@@ -240,29 +322,40 @@ func (w *HTTPWebhookSender) createSlackPayload(alert *models.Alert, history *mod
 		emoji = ":rotating_light:"
 	}
 
-	unit := "%"
-	if !strings.Contains(strings.ToLower(alert.MetricType), "cpu") &&
-		!strings.Contains(strings.ToLower(alert.MetricType), "memory") &&
-		!strings.Contains(strings.ToLower(alert.MetricType), "disk") {
-		unit = ""
+	text := fmt.Sprintf("%s *%s Alert*: %s", emoji, w.titleCaser.String(history.Severity), alert.Name)
+	if history.Resolved {
+		color = "#28a745"
+		text = fmt.Sprintf(":white_check_mark: *Resolved*: %s", alert.Name)
+	}
+
+	unit := metricUnit(alert.MetricType)
+
+	fields := []SlackField{
+		{Title: "Current Value", Value: fmt.Sprintf("%.2f%s", history.MetricValue, unit), Short: true},
+		{Title: "Threshold", Value: fmt.Sprintf("%.2f%s", alert.Threshold, unit), Short: true},
+		{Title: "Condition", Value: alert.Condition, Short: true},
+		{Title: "Hostname", Value: history.Hostname, Short: true},
+	}
+	timestamp := history.CreatedAt
+	if history.Resolved {
+		fields = append(fields,
+			SlackField{Title: "Duration", Value: formatIncidentDuration(history), Short: true},
+			SlackField{Title: "Peak Value", Value: fmt.Sprintf("%.2f%s", history.PeakValue, unit), Short: true},
+		)
+		timestamp = history.ResolvedAt
 	}
 
 	return SlackPayload{
-		Text:      fmt.Sprintf("%s *%s Alert*: %s", emoji, w.titleCaser.String(history.Severity), alert.Name),
+		Text:      text,
 		Username:  "GoDash Monitor",
 		IconEmoji: ":chart_with_upwards_trend:",
 		Attachments: []SlackAttachment{
 			{
-				Color: color,
-				Title: fmt.Sprintf("%s on %s", w.titleCaser.String(alert.MetricType), history.Hostname),
-				Text:  history.Message,
-				Fields: []SlackField{
-					{Title: "Current Value", Value: fmt.Sprintf("%.2f%s", history.MetricValue, unit), Short: true},
-					{Title: "Threshold", Value: fmt.Sprintf("%.2f%s", alert.Threshold, unit), Short: true},
-					{Title: "Condition", Value: alert.Condition, Short: true},
-					{Title: "Hostname", Value: history.Hostname, Short: true},
-				},
-				Timestamp: history.CreatedAt.Unix(),
+				Color:     color,
+				Title:     fmt.Sprintf("%s on %s", w.titleCaser.String(alert.MetricType), history.Hostname),
+				Text:      history.Message,
+				Fields:    fields,
+				Timestamp: timestamp.Unix(),
 			},
 		},
 	}
@@ -279,40 +372,51 @@ func (w *HTTPWebhookSender) createDiscordPayload(alert *models.Alert, history *m
 		color = 0xdc3545 // red
 	}
 
-	unit := "%"
-	if !strings.Contains(strings.ToLower(alert.MetricType), "cpu") &&
-		!strings.Contains(strings.ToLower(alert.MetricType), "memory") &&
-		!strings.Contains(strings.ToLower(alert.MetricType), "disk") {
-		unit = ""
+	content := fmt.Sprintf("🚨 **%s Alert**: %s", w.titleCaser.String(history.Severity), alert.Name)
+	if history.Resolved {
+		color = 0x28a745
+		content = fmt.Sprintf("✅ **Resolved**: %s", alert.Name)
+	}
+
+	unit := metricUnit(alert.MetricType)
+
+	fields := []DiscordEmbedField{
+		{Name: "Current Value", Value: fmt.Sprintf("%.2f%s", history.MetricValue, unit), Inline: true},
+		{Name: "Threshold", Value: fmt.Sprintf("%.2f%s", alert.Threshold, unit), Inline: true},
+		{Name: "Condition", Value: alert.Condition, Inline: true},
+		{Name: "Hostname", Value: history.Hostname, Inline: true},
+		{Name: "Severity", Value: w.titleCaser.String(history.Severity), Inline: true},
+	}
+	timestamp := history.CreatedAt
+	if history.Resolved {
+		fields = append(fields,
+			DiscordEmbedField{Name: "Duration", Value: formatIncidentDuration(history), Inline: true},
+			DiscordEmbedField{Name: "Peak Value", Value: fmt.Sprintf("%.2f%s", history.PeakValue, unit), Inline: true},
+		)
+		timestamp = history.ResolvedAt
 	}
 
 	return DiscordPayload{
 		Username:  "GoDash Monitor",
 		AvatarURL: "https://cdn.discordapp.com/embed/avatars/0.png",
-		Content:   fmt.Sprintf("🚨 **%s Alert**: %s", w.titleCaser.String(history.Severity), alert.Name),
+		Content:   content,
 		Embeds: []DiscordEmbed{
 			{
 				Title:       fmt.Sprintf("%s Alert on %s", w.titleCaser.String(alert.MetricType), history.Hostname),
 				Description: history.Message,
 				Color:       color,
-				Fields: []DiscordEmbedField{
-					{Name: "Current Value", Value: fmt.Sprintf("%.2f%s", history.MetricValue, unit), Inline: true},
-					{Name: "Threshold", Value: fmt.Sprintf("%.2f%s", alert.Threshold, unit), Inline: true},
-					{Name: "Condition", Value: alert.Condition, Inline: true},
-					{Name: "Hostname", Value: history.Hostname, Inline: true},
-					{Name: "Severity", Value: w.titleCaser.String(history.Severity), Inline: true},
-				},
+				Fields:      fields,
 				Footer: DiscordEmbedFooter{
 					Text: "GoDash System Monitor",
 				},
-				Timestamp: history.CreatedAt,
+				Timestamp: timestamp,
 			},
 		},
 	}
 }
 
 // sendWithRetry sends webhook with retry mechanism
-func (w *HTTPWebhookSender) sendWithRetry(url string, payload interface{}) error {
+func (w *HTTPWebhookSender) sendWithRetry(url string, payload interface{}, settings *models.NotificationChannelConfig) error {
 	var lastErr error
 
 	for attempt := 0; attempt <= w.config.MaxRetries; attempt++ {
@@ -321,7 +425,7 @@ func (w *HTTPWebhookSender) sendWithRetry(url string, payload interface{}) error
 			time.Sleep(w.config.RetryDelay * time.Duration(attempt))
 		}
 
-		err := w.sendWebhook(url, payload)
+		err := w.sendWebhook(url, payload, settings)
 		if err == nil {
 			return nil // Success
 		}
@@ -337,20 +441,29 @@ func (w *HTTPWebhookSender) sendWithRetry(url string, payload interface{}) error
 	return fmt.Errorf("webhook failed after %d attempts: %w", w.config.MaxRetries+1, lastErr)
 }
 
-// sendWebhook sends a single webhook request
-func (w *HTTPWebhookSender) sendWebhook(url string, payload interface{}) error {
+// sendWebhook sends a single webhook request with the method, headers, authentication and
+// signature of the channel settings (nil = a plain POST)
+func (w *HTTPWebhookSender) sendWebhook(url string, payload interface{}, settings *models.NotificationChannelConfig) error {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal webhook payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	method := http.MethodPost
+	if settings != nil && settings.Method != "" {
+		method = settings.Method
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create webhook request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "GoDash-Monitor/1.0")
+	if settings != nil {
+		applyWebhookSettings(req, jsonData, settings, time.Now())
+	}
 
 	resp, err := w.client.Do(req)
 	if err != nil {
@@ -368,6 +481,39 @@ func (w *HTTPWebhookSender) sendWebhook(url string, payload interface{}) error {
 	return nil
 }
 
+// applyWebhookSettings sets the custom headers, authentication and signature of a request
+func applyWebhookSettings(req *http.Request, body []byte, settings *models.NotificationChannelConfig, now time.Time) {
+	for name, value := range settings.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if auth := settings.Auth; auth != nil {
+		switch auth.Type {
+		case WebhookAuthBasic:
+			req.SetBasicAuth(auth.Username, auth.Password)
+		case WebhookAuthBearer:
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
+		}
+	}
+
+	if settings.Secret != "" {
+		timestamp := now.Unix()
+		req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(settings.Secret, timestamp, body))
+	}
+}
+
+// SignWebhookPayload returns the signature header value of a webhook request body sent at a
+// Unix timestamp. Receivers recompute it with the shared secret, compare it in constant time
+// and reject stale timestamps to prevent replays.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
 // HTTPError represents an HTTP error response
 type HTTPError struct {
 	StatusCode int
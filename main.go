@@ -32,8 +32,11 @@ type Application struct {
 	database         *database.Database
 	metricsRepo      repository.MetricsRepository
 	alertRepo        repository.AlertRepository
+	probeRepo        repository.ProbeRepository
 	collectorService *services.CollectorService
 	alertService     *services.AlertService
+	probeService     *services.ProbeService
+	certService      *services.CertificateService
 	emailSender      services.EmailSender
 	webhookSender    services.WebhookSender
 	router           *api.Router
@@ -99,6 +102,11 @@ func initializeApplication() (*Application, error) {
 	// Initialize repositories
 	metricsRepo := repository.NewMetricsRepository(db.DB)
 	alertRepo := repository.NewAlertRepository(db.DB)
+	probeRepo := repository.NewProbeRepository(db.DB)
+	silenceRepo := repository.NewSilenceRepository(db.DB)
+	policyRepo := repository.NewEscalationPolicyRepository(db.DB)
+	notificationRepo := repository.NewNotificationRepository(db.DB)
+	hostRepo := repository.NewHostRepository(db.DB)
 
 	// Initialize notification services
 	var emailSender services.EmailSender
@@ -117,9 +125,18 @@ func initializeApplication() (*Application, error) {
 	// Initialize services
 	collectorService := services.NewCollectorService(cfg, metricsRepo)
 	alertService := services.NewAlertService(cfg, alertRepo, emailSender, webhookSender)
-
-	// Link alert service to collector service
+	probeService := services.NewProbeService(cfg, probeRepo)
+	certService := services.NewCertificateService(cfg)
+
+	// Link alert service to collector, probe and certificate services
+	alertService.SetMetricsRepository(metricsRepo)
+	alertService.SetSilenceRepository(silenceRepo)
+	alertService.SetEscalationPolicyRepository(policyRepo)
+	alertService.SetNotificationRepository(notificationRepo)
+	alertService.SetHostRepository(hostRepo)
 	collectorService.SetAlertService(alertService)
+	probeService.SetAlertService(alertService)
+	certService.SetAlertService(alertService)
 
 	// Initialize API router
 	// Setup embedded assets if available (single-exe mode)
@@ -141,11 +158,18 @@ func initializeApplication() (*Application, error) {
 		}
 	}
 
-	router := api.New(cfg, metricsRepo, alertRepo, collectorService, alertService, emailSender, webhookSender, tplFS, statFS)
+	router := api.New(cfg, metricsRepo, alertRepo, probeRepo, silenceRepo, policyRepo, notificationRepo, hostRepo, collectorService, alertService, probeService, certService, emailSender, webhookSender, tplFS, statFS)
 
 	// Connect alert service to WebSocket handler for real-time alert broadcasting
 	alertService.SetWebSocketHandler(router.GetWebSocketHandler())
 
+	// Reconcile alerts with the rule files of the rules directory
+	if cfg.Alerts.RulesDir != "" {
+		if _, err := router.GetAlertHandler().SyncRulesDirectory(cfg.Alerts.RulesDir); err != nil {
+			log.Printf("❌ Failed to sync alert rules: %v", err)
+		}
+	}
+
 	// Create HTTP server
 	server := &http.Server{
 		Addr:         cfg.GetServerAddress(),
@@ -159,8 +183,11 @@ func initializeApplication() (*Application, error) {
 		database:         db,
 		metricsRepo:      metricsRepo,
 		alertRepo:        alertRepo,
+		probeRepo:        probeRepo,
 		collectorService: collectorService,
 		alertService:     alertService,
+		probeService:     probeService,
+		certService:      certService,
 		emailSender:      emailSender,
 		webhookSender:    webhookSender,
 		router:           router,
@@ -181,6 +208,16 @@ func (app *Application) run(ctx context.Context) error {
 		log.Printf("⚠️ Failed to start alert service: %v", err)
 	}
 
+	// Start probe service
+	if err := app.probeService.Start(ctx); err != nil {
+		log.Printf("⚠️ Failed to start probe service: %v", err)
+	}
+
+	// Start certificate service
+	if err := app.certService.Start(ctx); err != nil {
+		log.Printf("⚠️ Failed to start certificate service: %v", err)
+	}
+
 	// Start HTTP server in a goroutine
 	serverErrors := make(chan error, 1)
 	go func() {
@@ -281,6 +318,26 @@ func (app *Application) shutdown() error {
 		}
 	}
 
+	// Stop probe service
+	if app.probeService != nil {
+		log.Println("Stopping probe service...")
+		if err := app.probeService.Stop(); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("probe service stop error: %w", err))
+		} else {
+			log.Println("Probe service stopped")
+		}
+	}
+
+	// Stop certificate service
+	if app.certService != nil {
+		log.Println("Stopping certificate service...")
+		if err := app.certService.Stop(); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("certificate service stop error: %w", err))
+		} else {
+			log.Println("Certificate service stopped")
+		}
+	}
+
 	// Close database connection
 	log.Println("Closing database connection...")
 	if err := app.database.Close(); err != nil {
@@ -73,6 +73,11 @@ class AlertManager {
             webhookCheckbox.addEventListener('change', this.toggleWebhookFields.bind(this));
         }
 
+        const metricTypeSelect = document.getElementById('metricType');
+        if (metricTypeSelect) {
+            metricTypeSelect.addEventListener('change', this.toggleExpressionFields.bind(this));
+        }
+
         this.log('👂 Alert Manager event listeners setup complete');
     }
 
@@ -183,12 +188,17 @@ class AlertManager {
                 </div>
                 <div class="alert-details">
                     <div class="alert-condition">
-                        ${alert.metric_type.toUpperCase()} ${alert.condition} ${alert.threshold}${this.getMetricUnit(alert.metric_type)}
+                        ${alert.metric_type === 'expression' && alert.expression
+                            ? this.escapeHtml(alert.expression)
+                            : alert.metric_type === 'anomaly'
+                            ? `${(alert.anomaly_field || '').toUpperCase()} ANOMALY ${alert.condition} ${alert.threshold} σ (${alert.anomaly_method || 'ewma'}, ${alert.seasonality || 'daily'})`
+                            : `${alert.aggregation ? `${alert.aggregation.toUpperCase()}(${alert.metric_type.toUpperCase()}, ${alert.window || 300}s)` : alert.metric_type.toUpperCase()} ${alert.condition} ${alert.threshold}${this.getMetricUnit(alert.metric_type)}`}
                     </div>
                     <div class="alert-meta">
                         <span>Triggered: ${alert.triggered_count || 0} times</span>
                         ${alert.last_triggered ? `<span>Last: ${this.formatDate(alert.last_triggered)}</span>` : ''}
                     </div>
+                    ${alert.metric_type === 'anomaly' ? `<div class="alert-baseline" id="alertBaseline-${alert.id}">Learning baseline...</div>` : ''}
                     <div class="alert-notifications">
                         ${alert.email_enabled ? '<span class="notification-badge">Email</span>' : ''}
                         ${alert.webhook_enabled ? '<span class="notification-badge">Webhook</span>' : ''}
@@ -199,6 +209,40 @@ class AlertManager {
 
         alertsList.innerHTML = alertsHTML;
         this.log('Rendered', this.alerts.length, 'alerts');
+
+        this.alerts
+            .filter(alert => alert.metric_type === 'anomaly')
+            .forEach(alert => this.loadAnomalyBaseline(alert.id));
+    }
+
+    /**
+     * Load and display the expected range of an anomaly alert for each host
+     */
+    async loadAnomalyBaseline(alertId) {
+        const container = document.getElementById(`alertBaseline-${alertId}`);
+        if (!container) return;
+
+        try {
+            const response = await fetch(`${this.apiUrl}/alerts/${alertId}/baseline`);
+            if (!response.ok) throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+
+            const result = await response.json();
+            const bands = (result.data || []).filter(band => band.current && band.current.ready);
+            if (bands.length === 0) {
+                container.textContent = 'Learning baseline...';
+                return;
+            }
+
+            container.innerHTML = bands.map(band => `
+                <span class="baseline-band">
+                    ${this.escapeHtml(band.hostname)}: expected ${band.current.expected.toFixed(2)}
+                    (${band.current.lower.toFixed(2)} to ${band.current.upper.toFixed(2)})
+                </span>
+            `).join('');
+        } catch (error) {
+            this.log('❌ Failed to load anomaly baseline:', error);
+            container.textContent = '';
+        }
     }
 
     /**
@@ -239,6 +283,40 @@ class AlertManager {
         }
     }
 
+    /**
+     * Parse "key=value, key=value" into a labels object
+     */
+    parseLabels(text) {
+        const labels = {};
+        text.split(',').forEach(pair => {
+            const index = pair.indexOf('=');
+            if (index > 0) {
+                labels[pair.slice(0, index).trim()] = pair.slice(index + 1).trim();
+            }
+        });
+        return labels;
+    }
+
+    /**
+     * Parse a comma separated list, skipping empty entries
+     */
+    parseList(text) {
+        return text.split(',').map(item => item.trim()).filter(item => item !== '');
+    }
+
+    /**
+     * Build severity levels from the base severity and threshold and "critical=95" pairs
+     */
+    parseLevels(text, severity, threshold) {
+        const higher = Object.entries(this.parseLabels(text))
+            .map(([level, value]) => ({ severity: level.toLowerCase(), threshold: parseFloat(value) }))
+            .filter(level => !isNaN(level.threshold));
+        if (higher.length === 0) {
+            return [];
+        }
+        return [{ severity, threshold }, ...higher];
+    }
+
     /**
      * Handle create alert form submission
      */
@@ -249,15 +327,36 @@ class AlertManager {
         const alertData = {
             name: formData.get('name'),
             metric_type: formData.get('metric_type'),
-            condition: formData.get('condition'),
-            threshold: parseFloat(formData.get('threshold')),
+            condition: formData.get('condition') || '',
+            threshold: parseFloat(formData.get('threshold')) || 0,
+            clear_threshold: formData.get('clear_threshold') ? parseFloat(formData.get('clear_threshold')) : null,
             duration: parseInt(formData.get('duration')) || 0,
             severity: formData.get('severity'),
+            levels: this.parseLevels(formData.get('levels') || '', formData.get('severity'), parseFloat(formData.get('threshold')) || 0),
             description: formData.get('description') || '',
+            labels: this.parseLabels(formData.get('labels') || ''),
+            host_selector: {
+                include: this.parseList(formData.get('host_include') || ''),
+                exclude: this.parseList(formData.get('host_exclude') || '')
+            },
+            expression: formData.get('expression') || '',
+            aggregation: formData.get('aggregation') || '',
+            mountpoint: formData.get('mountpoint') || '',
+            absent_metric: formData.get('absent_metric') || '',
+            anomaly_field: formData.get('anomaly_field') || '',
+            anomaly_method: formData.get('anomaly_method') || '',
+            seasonality: formData.get('seasonality') || '',
+            anomaly_direction: formData.get('anomaly_direction') || '',
             email_enabled: formData.has('email_enabled'),
             email_recipients: formData.get('email_recipients') || '',
             webhook_enabled: formData.has('webhook_enabled'),
-            webhook_url: formData.get('webhook_url') || ''
+            webhook_url: formData.get('webhook_url') || '',
+            skip_resolved: !formData.has('notify_resolved'),
+            probe_id: parseInt(formData.get('probe_id')) || 0,
+            certificate_filter: formData.get('certificate_filter') || '',
+            process_pattern: formData.get('process_pattern') || '',
+            process_regex: formData.has('process_regex'),
+            window: parseInt(formData.get('window')) || 0
         };
 
         try {
@@ -418,6 +517,7 @@ class AlertManager {
             form.reset();
             this.toggleEmailFields();
             this.toggleWebhookFields();
+            this.toggleExpressionFields();
         }
     }
 
@@ -447,6 +547,34 @@ class AlertManager {
         }
     }
 
+    /**
+     * Toggle expression and condition fields based on metric type.
+     * Expression rules fire when the expression is true, so condition and threshold are not used.
+     */
+    toggleExpressionFields() {
+        const metricType = document.getElementById('metricType');
+        const expression = document.getElementById('alertExpression');
+        const condition = document.getElementById('condition');
+        const threshold = document.getElementById('threshold');
+        const clearThreshold = document.getElementById('clear_threshold');
+        const isExpression = metricType && metricType.value === 'expression';
+
+        if (expression) {
+            expression.disabled = !isExpression;
+            expression.required = isExpression;
+        }
+        if (condition) {
+            condition.disabled = isExpression;
+        }
+        if (threshold) {
+            threshold.disabled = isExpression;
+            threshold.required = !isExpression;
+        }
+        if (clearThreshold) {
+            clearThreshold.disabled = isExpression;
+        }
+    }
+
     /**
      * Toggle alerts panel visibility
      */
@@ -546,6 +674,23 @@ class AlertManager {
         }
     }
 
+    /**
+     * Handle real-time alert resolved events via WebSocket
+     */
+    handleAlertResolved(alertData) {
+        if (!alertData) return;
+
+        this.log('Handling alert resolved:', alertData);
+
+        const hostname = alertData.hostname || 'system';
+        const alertName = alertData.alert_name || alertData.name || 'System Alert';
+
+        this.showNotification(`RESOLVED: ${alertName} on ${hostname}`, 'success', 5000);
+
+        // Refresh stats
+        this.loadAlertStats();
+    }
+
     /**
      * Get metric unit for display
      */
@@ -556,7 +701,23 @@ class AlertManager {
             disk: '%',
             load_avg_1: '',
             load_avg_5: '',
-            load_avg_15: ''
+            load_avg_15: '',
+            file_handles: '%',
+            pid_usage: '%',
+            threads: '',
+            conntrack: '%',
+            process_fds: '%',
+            probe_success: '',
+            probe_latency: ' ms',
+            probe_tls_expiry: ' days',
+            cert_expiry_days: ' days',
+            process_count: '',
+            process_cpu: '%',
+            process_rss: ' MB',
+            process_restarts: '',
+            process_uptime: 's',
+            disk_forecast: ' h',
+            no_data: 's'
         };
         return units[metricType] || '%';
     }
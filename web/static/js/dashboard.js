@@ -293,7 +293,7 @@ class Dashboard {
             this.connectionAttempts = 0;
             this.updateConnectionStatus('connected', 'Connected');
             this.hideNotification();
-            this.websocket.subscribe(['metrics', 'system_status', 'alert_triggered']);
+            this.websocket.subscribe(['metrics', 'system_status', 'alert_triggered', 'alert_resolved']);
         });
 
         this.websocket.on('disconnect', (event) => {
@@ -336,6 +336,12 @@ class Dashboard {
             }
         });
 
+        this.websocket.on('alert_resolved', (data) => {
+            if (this.alertManager && this.alertManager.handleAlertResolved) {
+                this.alertManager.handleAlertResolved(data);
+            }
+        });
+
         this.websocket.connect();
     }
 
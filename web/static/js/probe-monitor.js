@@ -0,0 +1,190 @@
+/**
+ * Probe Monitor - Synthetic HTTP/TCP/DNS probe status and latency
+ */
+class ProbeMonitor {
+    constructor(apiUrl = '/api/v1') {
+        this.apiUrl = apiUrl;
+        this.probes = [];
+        this.charts = {};
+        this.refreshInterval = 30000; // 30 seconds
+        this.refreshTimer = null;
+        this.initialized = false;
+    }
+
+    /**
+     * Initialize and start periodic refresh
+     */
+    async init() {
+        if (this.initialized) return;
+
+        await this.refresh();
+        this.refreshTimer = setInterval(() => this.refresh(), this.refreshInterval);
+        this.initialized = true;
+        this.log('Probe Monitor initialized');
+    }
+
+    /**
+     * Load probes and their recent results
+     */
+    async refresh() {
+        const container = document.getElementById('probeList');
+        if (!container) return;
+
+        try {
+            const response = await fetch(`${this.apiUrl}/probes`);
+            const result = await response.json();
+            if (!result.success) {
+                throw new Error(result.message || 'Failed to load probes');
+            }
+
+            this.probes = result.data || [];
+            if (this.probes.length === 0) {
+                this.destroyCharts();
+                container.innerHTML = '<div class="probe-empty">No probes configured. Create one via POST /api/v1/probes.</div>';
+                return;
+            }
+
+            const details = await Promise.all(this.probes.map(probe => this.loadResults(probe.id)));
+            this.render(container, details);
+        } catch (error) {
+            this.log('Failed to load probes:', error);
+            container.innerHTML = '<div class="probe-empty">Failed to load probes</div>';
+        }
+    }
+
+    /**
+     * Load the last hour of results for a probe
+     */
+    async loadResults(probeId) {
+        try {
+            const response = await fetch(`${this.apiUrl}/probes/${probeId}/results?hours=1&limit=120`);
+            const result = await response.json();
+            return result.success ? result.data : null;
+        } catch (error) {
+            this.log('Failed to load probe results:', error);
+            return null;
+        }
+    }
+
+    /**
+     * Render probe cards with latency sparklines
+     */
+    render(container, details) {
+        this.destroyCharts();
+
+        container.innerHTML = this.probes.map((probe, index) => {
+            const summary = (details[index] && details[index].summary) || {};
+            const statusClass = !probe.enabled ? 'disabled' : (probe.last_success ? 'up' : 'down');
+            const statusText = !probe.enabled ? 'DISABLED' : (probe.last_success ? 'UP' : 'DOWN');
+            const lastCheck = probe.last_check && !probe.last_check.startsWith('0001')
+                ? new Date(probe.last_check).toLocaleTimeString()
+                : 'Never';
+
+            return `
+                <div class="probe-item">
+                    <div class="probe-header">
+                        <div class="probe-name">
+                            <span class="probe-title">${this.escapeHtml(probe.name)}</span>
+                            <span class="probe-target">${probe.type.toUpperCase()} ${this.escapeHtml(probe.target)}</span>
+                        </div>
+                        <div class="probe-status ${statusClass}">${statusText}</div>
+                    </div>
+                    <div class="probe-chart-container">
+                        <canvas id="probe-chart-${probe.id}"></canvas>
+                    </div>
+                    <div class="probe-info">
+                        <span>Latency: ${(probe.last_latency_ms || 0).toFixed(1)} ms</span>
+                        <span>Success (1h): ${(summary.success_rate || 0).toFixed(1)}%</span>
+                        <span>Last check: ${lastCheck}</span>
+                    </div>
+                    ${probe.last_error ? `<div class="probe-error">${this.escapeHtml(probe.last_error)}</div>` : ''}
+                </div>
+            `;
+        }).join('');
+
+        this.probes.forEach((probe, index) => {
+            const results = (details[index] && details[index].results) || [];
+            this.renderChart(probe, results.slice().reverse());
+        });
+    }
+
+    /**
+     * Draw a latency sparkline; failed checks are highlighted
+     */
+    renderChart(probe, results) {
+        const canvas = document.getElementById(`probe-chart-${probe.id}`);
+        if (!canvas || typeof Chart === 'undefined') return;
+
+        this.charts[probe.id] = new Chart(canvas, {
+            type: 'line',
+            data: {
+                labels: results.map(r => new Date(r.timestamp)),
+                datasets: [{
+                    data: results.map(r => r.latency_ms),
+                    borderColor: '#00d4ff',
+                    borderWidth: 2,
+                    pointRadius: results.map(r => (r.success ? 0 : 3)),
+                    pointBackgroundColor: '#f44336',
+                    tension: 0.3,
+                    fill: false
+                }]
+            },
+            options: {
+                responsive: true,
+                maintainAspectRatio: false,
+                animation: false,
+                plugins: { legend: { display: false } },
+                scales: {
+                    x: { type: 'time', display: false },
+                    y: { beginAtZero: true, ticks: { color: '#888' } }
+                }
+            }
+        });
+    }
+
+    /**
+     * Destroy existing charts before re-rendering
+     */
+    destroyCharts() {
+        Object.values(this.charts).forEach(chart => chart.destroy());
+        this.charts = {};
+    }
+
+    /**
+     * Escape HTML to prevent XSS
+     */
+    escapeHtml(text) {
+        const div = document.createElement('div');
+        div.textContent = text;
+        return div.innerHTML;
+    }
+
+    /**
+     * Logging utility
+     */
+    log(...args) {
+        if (window.location.hostname === 'localhost') {
+            console.log('[ProbeMonitor]', ...args);
+        }
+    }
+
+    /**
+     * Cleanup resources
+     */
+    cleanup() {
+        if (this.refreshTimer) {
+            clearInterval(this.refreshTimer);
+            this.refreshTimer = null;
+        }
+        this.destroyCharts();
+        this.probes = [];
+        this.initialized = false;
+    }
+}
+
+// Export for use in other modules
+if (typeof module !== 'undefined' && module.exports) {
+    module.exports = ProbeMonitor;
+} else if (typeof window !== 'undefined') {
+    window.ProbeMonitor = ProbeMonitor;
+}